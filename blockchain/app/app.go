@@ -131,6 +131,9 @@ type KavaApp struct {
 
 	// the module manager
 	mm *sdk.ModuleManager
+
+	// set when the loaded auction store was written by the old block height based module, cleared once it has been migrated
+	migrateAuctionStore bool
 }
 
 // NewKavaApp is a constructor function for kavaApp
@@ -181,6 +184,8 @@ func NewKavaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 	crisisSubspace := app.paramsKeeper.Subspace(crisis.DefaultParamspace)
 	cdpSubspace := app.paramsKeeper.Subspace("cdp")
 	liquidatorSubspace := app.paramsKeeper.Subspace("liquidator")
+	auctionSubspace := app.paramsKeeper.Subspace(auction.DefaultParamspace)
+	pricefeedSubspace := app.paramsKeeper.Subspace(pricefeed.DefaultParamspace)
 
 	// add keepers
 	app.accountKeeper = auth.NewAccountKeeper(app.cdc, app.keyAccount, authSubspace, auth.ProtoBaseAccount)
@@ -196,7 +201,7 @@ func NewKavaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 	app.crisisKeeper = crisis.NewKeeper(crisisSubspace, invCheckPeriod, app.distrKeeper,
 		app.bankKeeper, app.feeCollectionKeeper)
 
-	app.pricefeedKeeper = pricefeed.NewKeeper(app.keyPricefeed, app.cdc, pricefeed.DefaultCodespace)
+	app.pricefeedKeeper = pricefeed.NewKeeper(app.keyPricefeed, app.cdc, pricefeedSubspace, pricefeed.DefaultCodespace)
 	app.cdpKeeper = cdp.NewKeeper(
 		app.cdc,
 		app.keyCdp,
@@ -208,21 +213,29 @@ func NewKavaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		app.cdc,
 		app.cdpKeeper, // CDP keeper standing in for bank
 		app.keyAuction,
+		auctionSubspace,
 	)
 	app.liquidatorKeeper = liquidator.NewKeeper(
 		app.cdc,
 		app.keyLiquidator,
 		liquidatorSubspace,
 		app.cdpKeeper,
-		app.auctionKeeper,
-		app.cdpKeeper, // CDP keeper standing in for bank
+		&app.auctionKeeper, // passed by reference, so that it will contain the hooks registered below
+		app.cdpKeeper,      // CDP keeper standing in for bank
+		app.pricefeedKeeper,
 	)
 
+	// register the auction hooks
+	// NOTE: the liquidator keeper above holds a reference to the auction keeper, so that it will contain these hooks
+	app.auctionKeeper.SetHooks(app.liquidatorKeeper.Hooks())
+
 	// register the proposal types
 	govRouter := gov.NewRouter()
 	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
 		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
-		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper))
+		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
+		AddRoute(liquidator.ModuleName, liquidator.NewProposalHandler(app.liquidatorKeeper)).
+		AddRoute(pricefeed.RouterKey, pricefeed.NewProposalHandler(app.pricefeedKeeper))
 	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.paramsKeeper, govSubspace,
 		app.bankKeeper, &stakingKeeper, gov.DefaultCodespace, govRouter)
 
@@ -251,8 +264,8 @@ func NewKavaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 
 	// During begin block slashing happens after distr.BeginBlocker so that
 	// there is nothing left over in the validator fee pool, so as to keep the
-	// CanWithdrawInvariant invariant.
-	app.mm.SetOrderBeginBlockers(mint.ModuleName, distr.ModuleName, slashing.ModuleName)
+	// CanWithdrawInvariant invariant. Auctions that ended in the last block are then closed.
+	app.mm.SetOrderBeginBlockers(mint.ModuleName, distr.ModuleName, slashing.ModuleName, auction.ModuleName)
 
 	// During the endblock, governance proposals expire, staking rewards are distributed, and the pricefeed updates
 	app.mm.SetOrderEndBlockers(gov.ModuleName, staking.ModuleName, pricefeed.ModuleName)
@@ -299,12 +312,19 @@ func NewKavaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest b
 		if err != nil {
 			cmn.Exit(err.Error())
 		}
+		// check once whether the chain is upgrading from the old block height based auction module, a new chain gets auction params from genesis
+		app.migrateAuctionStore = app.LastBlockHeight() > 0 && auction.StoreNeedsMigration(app.NewContext(true, abci.Header{}), app.auctionKeeper)
 	}
 	return app
 }
 
 // BeginBlocker application updates every begin block
 func (app *KavaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	// upgrade the auction store in the first block run by this version, if it was written by the old block height based auction module
+	if app.migrateAuctionStore {
+		auction.MigrateStore(ctx, app.auctionKeeper)
+		app.migrateAuctionStore = false
+	}
 	return app.mm.BeginBlock(ctx, req)
 }
 
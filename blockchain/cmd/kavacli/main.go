@@ -51,8 +51,10 @@ import (
 	cdpclient "github.com/kava-labs/kava-devnet/blockchain/x/cdp/client"
 	cdprest "github.com/kava-labs/kava-devnet/blockchain/x/cdp/client/rest"
 	liquidatorclient "github.com/kava-labs/kava-devnet/blockchain/x/liquidator/client"
+	liquidatorcli "github.com/kava-labs/kava-devnet/blockchain/x/liquidator/client/cli"
 	liquidatorrest "github.com/kava-labs/kava-devnet/blockchain/x/liquidator/client/rest"
 	priceclient "github.com/kava-labs/kava-devnet/blockchain/x/pricefeed/client"
+	pricefeedcli "github.com/kava-labs/kava-devnet/blockchain/x/pricefeed/client/cli"
 	pricerest "github.com/kava-labs/kava-devnet/blockchain/x/pricefeed/client/rest"
 
 
@@ -70,7 +72,10 @@ func main() {
 	app.SetAddressPrefixes()
 
 	mc := []sdk.ModuleClient{
-		govClient.NewModuleClient(gv.StoreKey, cdc, paramcli.GetCmdSubmitProposal(cdc), distrcli.GetCmdSubmitProposal(cdc)),
+		govClient.NewModuleClient(gv.StoreKey, cdc, paramcli.GetCmdSubmitProposal(cdc), distrcli.GetCmdSubmitProposal(cdc),
+			liquidatorcli.GetCmd_SubmitFreezeAuctionsProposal(cdc), liquidatorcli.GetCmd_SubmitCancelAuctionsProposal(cdc),
+			pricefeedcli.GetCmdSubmitAddOracleProposal(cdc), pricefeedcli.GetCmdSubmitRemoveOracleProposal(cdc), pricefeedcli.GetCmdSubmitReplaceOracleProposal(cdc),
+			pricefeedcli.GetCmdSubmitAssetOraclesProposal(cdc), pricefeedcli.GetCmdSubmitAssetQuorumProposal(cdc)),
 		distClient.NewModuleClient(distcmd.StoreKey, cdc),
 		stakingclient.NewModuleClient(st.StoreKey, cdc),
 		mintclient.NewModuleClient(mint.StoreKey, cdc),
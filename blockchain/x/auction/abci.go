@@ -0,0 +1,109 @@
+package auction
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BeginBlocker runs at the start of every block, closing auctions that ended in an earlier block.
+// Closing at the start of the next block means bids placed in the block at an auction's EndTime (or a batch auction's EndHeight) are still valid.
+// At most MaxClosesPerBlock auctions are closed each block, taken in turn from the auction queue, the batch auction queue and the auctions waiting to retry closing, so none of them can starve the others.
+// The rest stay queued until the next block.
+func BeginBlocker(ctx sdk.Context, k Keeper) sdk.Tags {
+	params := k.GetParams(ctx)
+	maxCloses := params.MaxClosesPerBlock
+
+	// An auction that fails to close doesn't use up a slot, the next auction is closed instead.
+	// It is taken out of its queue and retried after CloseRetryDelay, so failing auctions can't hold up the rest of the queue.
+	// At most twice MaxClosesPerBlock closes are tried each block, to bound the work done when many auctions fail.
+	maxAttempts := 2 * maxCloses
+	closed, failed := 0, 0
+	for closed < maxCloses && closed+failed < maxAttempts {
+		// read the auctions to close before closing any, as closing can modify the queues
+		candidates := getCloseCandidates(ctx, k, maxCloses-closed)
+		if len(candidates) == 0 {
+			break
+		}
+		store := ctx.KVStore(k.storeKey)
+		for _, c := range candidates {
+			if closed >= maxCloses || closed+failed >= maxAttempts {
+				break
+			}
+			auction, found := k.GetAuction(ctx, c.auctionID)
+			if !found || k.IsAuctionFrozen(ctx, c.auctionID) {
+				// the auction was closed or frozen since it was queued (only possible for retries), so it's dropped from the queue
+				store.Delete(c.queueKey)
+				continue
+			}
+			if c.retry {
+				store.Delete(c.queueKey)
+			}
+			err := closeAuction(ctx, k, c.auctionID)
+			if err != nil {
+				// don't halt the chain, move the auction out of the queue to be retried later
+				ctx.Logger().Error(fmt.Sprintf("could not close auction %d, retrying after %s: %s", c.auctionID, params.CloseRetryDelay, err))
+				k.removeAuctionFromQueue(ctx, auction)
+				k.insertIntoRetryQueue(ctx, ctx.BlockHeader().Time.Add(params.CloseRetryDelay), c.auctionID)
+				failed++
+				continue
+			}
+			closed++
+		}
+	}
+
+	// delete the bid histories of auctions that closed long enough ago
+	k.PruneBidHistories(ctx)
+
+	return sdk.Tags{}
+}
+
+// closeCandidate is an auction due to be closed, along with its key in the queue it was read from.
+type closeCandidate struct {
+	auctionID ID
+	queueKey  []byte
+	retry     bool // true if it was read from the queue of auctions waiting to retry closing
+}
+
+// getCloseCandidates reads up to n auctions due to close from each of the queues, taking them from each queue in turn.
+func getCloseCandidates(ctx sdk.Context, k Keeper, n int) []closeCandidate {
+	currentTime := ctx.BlockHeader().Time
+	iterators := []sdk.Iterator{
+		k.getExpiredQueueIterator(ctx, currentTime),
+		k.getExpiredBatchQueueIterator(ctx, ctx.BlockHeight()), // batch auctions are queued by the block height they end at
+		k.getExpiredRetryQueueIterator(ctx, currentTime),
+	}
+	var candidates []closeCandidate
+	for i := 0; i < n; i++ {
+		for j, iter := range iterators {
+			if !iter.Valid() {
+				continue
+			}
+			var auctionID ID
+			k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &auctionID)
+			candidates = append(candidates, closeCandidate{auctionID, iter.Key(), j == 2})
+			iter.Next()
+		}
+	}
+	for _, iter := range iterators {
+		iter.Close()
+	}
+	return candidates
+}
+
+// closeAuction closes an auction in its own cached context so a failure can't leave it half closed.
+// Panics are recovered and returned as errors, so a bug in closing one auction (or in the hooks of the module that started it) can't halt the chain.
+func closeAuction(ctx sdk.Context, k Keeper, auctionID ID) (err sdk.Error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = sdk.ErrInternal(fmt.Sprintf("panic while closing auction: %v", r))
+		}
+	}()
+	cacheCtx, write := ctx.CacheContext()
+	err = k.CloseAuction(cacheCtx, auctionID)
+	if err != nil {
+		return err
+	}
+	write()
+	return nil
+}
@@ -0,0 +1,206 @@
+package auction
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestBeginBlocker(t *testing.T) {
+	// setup keeper and auction
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+
+	seller := addresses[0]
+	keeper.StartForwardAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 0))
+
+	// run the beginblocker in a block at the auction's end time, bids can still be placed so it isn't closed
+	expiryTime := ctx.BlockHeader().Time.Add(DefaultMaxAuctionDuration)
+	BeginBlocker(ctx.WithBlockTime(expiryTime), keeper)
+	_, found := keeper.GetAuction(ctx, 0)
+	require.True(t, found)
+
+	// run the beginblocker, simulating a block time after auction expiry
+	BeginBlocker(ctx.WithBlockTime(expiryTime.Add(time.Second)), keeper)
+
+	// check auction has been closed
+	_, found = keeper.GetAuction(ctx, 0)
+	require.False(t, found)
+}
+
+func TestBeginBlocker_MaxClosesPerBlock(t *testing.T) {
+	// setup keeper and auctions
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	params := keeper.GetParams(ctx)
+	params.MaxClosesPerBlock = 2
+	keeper.setParams(ctx, params)
+	for i := 0; i < 3; i++ {
+		_, err := keeper.StartForwardAuction(ctx, addresses[0], sdk.NewInt64Coin("token1", 1), sdk.NewInt64Coin("token2", 0))
+		require.NoError(t, err)
+	}
+	ctx = ctx.WithBlockTime(header.Time.Add(DefaultMaxAuctionDuration + time.Second))
+
+	countAuctions := func() int {
+		count := 0
+		keeper.IterateAuctions(ctx, func(Auction) bool { count++; return false })
+		return count
+	}
+
+	// only two auctions are closed in the first block
+	BeginBlocker(ctx, keeper)
+	require.Equal(t, 1, countAuctions())
+
+	// the remaining auction is closed in the next block
+	BeginBlocker(ctx.WithBlockTime(ctx.BlockHeader().Time.Add(time.Second)), keeper)
+	require.Equal(t, 0, countAuctions())
+}
+
+func TestBeginBlocker_FailedClose(t *testing.T) {
+	// setup keeper and auctions, one of which has a bidder that can't be paid
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	seller, buyer1, buyer2 := addresses[0], addresses[1], addresses[2]
+	failingID, err := keeper.StartForwardAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 0))
+	require.NoError(t, err)
+	require.NoError(t, keeper.PlaceBid(ctx, failingID, buyer1, sdk.NewInt64Coin("token2", 10), sdk.NewInt64Coin("token1", 20)))
+	okID, err := keeper.StartForwardAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 0))
+	require.NoError(t, err)
+	require.NoError(t, keeper.PlaceBid(ctx, okID, buyer2, sdk.NewInt64Coin("token2", 10), sdk.NewInt64Coin("token1", 20)))
+
+	// close the auctions with paying buyer1 failing
+	failingKeeper := keeper
+	failingKeeper.bankKeeper = failingBankKeeper{keeper.bankKeeper, buyer1}
+	ctx = ctx.WithBlockTime(header.Time.Add(DefaultBidDuration + time.Second))
+	BeginBlocker(ctx, failingKeeper)
+
+	// the failing auction is left untouched, the other is closed
+	_, found := keeper.GetAuction(ctx, failingID)
+	require.True(t, found)
+	_, found = keeper.GetAuction(ctx, okID)
+	require.False(t, found)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 90)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 120), sdk.NewInt64Coin("token2", 90)), keeper.bankKeeper.GetCoins(ctx, buyer2))
+
+	// closing isn't retried until the retry delay is over
+	BeginBlocker(ctx.WithBlockTime(ctx.BlockHeader().Time.Add(time.Second)), keeper)
+	_, found = keeper.GetAuction(ctx, failingID)
+	require.True(t, found)
+
+	// closing is retried once the retry delay is over
+	BeginBlocker(ctx.WithBlockTime(ctx.BlockHeader().Time.Add(DefaultCloseRetryDelay+time.Second)), keeper)
+	_, found = keeper.GetAuction(ctx, failingID)
+	require.False(t, found)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 120), sdk.NewInt64Coin("token2", 90)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+}
+
+func TestBeginBlocker_FailedClosesDontBlockQueue(t *testing.T) {
+	// setup keeper, with as many failing auctions as can be closed in a block queued ahead of a healthy one
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	params := keeper.GetParams(ctx)
+	params.MaxClosesPerBlock = 2
+	keeper.setParams(ctx, params)
+	seller, failingBuyer, buyer := addresses[0], addresses[1], addresses[2]
+	var failingIDs []ID
+	for i := 0; i < params.MaxClosesPerBlock; i++ {
+		auctionID, err := keeper.StartForwardAuction(ctx, seller, sdk.NewInt64Coin("token1", 10), sdk.NewInt64Coin("token2", 0))
+		require.NoError(t, err)
+		require.NoError(t, keeper.PlaceBid(ctx, auctionID, failingBuyer, sdk.NewInt64Coin("token2", 10), sdk.NewInt64Coin("token1", 10)))
+		failingIDs = append(failingIDs, auctionID)
+	}
+	okID, err := keeper.StartForwardAuction(ctx, seller, sdk.NewInt64Coin("token1", 10), sdk.NewInt64Coin("token2", 0))
+	require.NoError(t, err)
+	require.NoError(t, keeper.PlaceBid(ctx.WithBlockTime(header.Time.Add(time.Second)), okID, buyer, sdk.NewInt64Coin("token2", 10), sdk.NewInt64Coin("token1", 10)))
+
+	// the failing auctions don't use up the slots, so the healthy auction is closed in the same block
+	failingKeeper := keeper
+	failingKeeper.bankKeeper = failingBankKeeper{keeper.bankKeeper, failingBuyer}
+	ctx = ctx.WithBlockTime(header.Time.Add(DefaultBidDuration + 2*time.Second))
+	BeginBlocker(ctx, failingKeeper)
+	_, found := keeper.GetAuction(ctx, okID)
+	require.False(t, found)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 110), sdk.NewInt64Coin("token2", 90)), keeper.bankKeeper.GetCoins(ctx, buyer))
+
+	// the failing auctions are taken out of the queue to be retried later, rather than being picked again every block
+	for _, auctionID := range failingIDs {
+		_, found := keeper.GetAuction(ctx, auctionID)
+		require.True(t, found)
+	}
+	iter := keeper.getExpiredQueueIterator(ctx, ctx.BlockHeader().Time.Add(time.Second))
+	require.Equal(t, []ID(nil), convertIteratorToSlice(keeper, iter))
+	iter.Close()
+	iter = keeper.getExpiredRetryQueueIterator(ctx, ctx.BlockHeader().Time.Add(DefaultCloseRetryDelay+time.Second))
+	require.Equal(t, failingIDs, convertIteratorToSlice(keeper, iter))
+	iter.Close()
+}
+
+func TestBeginBlocker_PanicOnClose(t *testing.T) {
+	// setup keeper and auctions, one of which panics when its bidder is paid
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	seller, buyer1, buyer2 := addresses[0], addresses[1], addresses[2]
+	panickingID, err := keeper.StartForwardAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 0))
+	require.NoError(t, err)
+	require.NoError(t, keeper.PlaceBid(ctx, panickingID, buyer1, sdk.NewInt64Coin("token2", 10), sdk.NewInt64Coin("token1", 20)))
+	okID, err := keeper.StartForwardAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 0))
+	require.NoError(t, err)
+	require.NoError(t, keeper.PlaceBid(ctx, okID, buyer2, sdk.NewInt64Coin("token2", 10), sdk.NewInt64Coin("token1", 20)))
+
+	// the panic is recovered, leaving the panicking auction untouched, and the other auction is closed
+	panickingKeeper := keeper
+	panickingKeeper.bankKeeper = panickingBankKeeper{keeper.bankKeeper, buyer1}
+	ctx = ctx.WithBlockTime(header.Time.Add(DefaultBidDuration + time.Second))
+	require.NotPanics(t, func() { BeginBlocker(ctx, panickingKeeper) })
+	_, found := keeper.GetAuction(ctx, panickingID)
+	require.True(t, found)
+	_, found = keeper.GetAuction(ctx, okID)
+	require.False(t, found)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 90)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token2", 10)), keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress))
+}
+
+func TestBeginBlocker_SharedCap(t *testing.T) {
+	// setup keeper, with more expired auctions than can be closed in a block in both queues
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	params := keeper.GetParams(ctx)
+	params.MaxClosesPerBlock = 2
+	keeper.setParams(ctx, params)
+	var forwardIDs, batchIDs []ID
+	for i := 0; i < 2; i++ {
+		auctionID, err := keeper.StartForwardAuction(ctx, addresses[0], sdk.NewInt64Coin("token1", 1), sdk.NewInt64Coin("token2", 0))
+		require.NoError(t, err)
+		forwardIDs = append(forwardIDs, auctionID)
+		auctionID, err = keeper.StartBatchAuction(ctx, addresses[0], sdk.NewInt64Coin("token1", 1), "token2", 1)
+		require.NoError(t, err)
+		batchIDs = append(batchIDs, auctionID)
+	}
+
+	// the closes are shared between the queues, so the batch auctions don't wait for the other queue to empty
+	ctx = ctx.WithBlockTime(header.Time.Add(DefaultMaxAuctionDuration + time.Second)).WithBlockHeight(header.Height + 1)
+	BeginBlocker(ctx, keeper)
+	_, found := keeper.GetAuction(ctx, forwardIDs[0])
+	require.False(t, found)
+	_, found = keeper.GetAuction(ctx, batchIDs[0])
+	require.False(t, found)
+	_, found = keeper.GetAuction(ctx, forwardIDs[1])
+	require.True(t, found)
+	_, found = keeper.GetAuction(ctx, batchIDs[1])
+	require.True(t, found)
+}
@@ -2,6 +2,7 @@ package auction
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/bank"
@@ -38,18 +39,18 @@ func TestApp_ForwardAuction(t *testing.T) {
 
 	// Check buyer's coins have decreased
 	mock.CheckBalance(t, mapp, buyer, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 90)))
-	// Check seller's coins have increased
-	mock.CheckBalance(t, mapp, seller, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 110)))
+	// Check the bid is held in escrow
+	mock.CheckBalance(t, mapp, EscrowAccountAddress, sdk.NewCoins(sdk.NewInt64Coin("token2", 10)))
 
-	// Deliver empty blocks until the auction should be closed (bid placed on block 3)
-	// TODO is there a way of skipping ahead? This takes a while and prints a lot.
-	for h := mapp.LastBlockHeight() + 1; h < int64(BidDuration)+4; h++ {
-		mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: h}})
-		mapp.EndBlock(abci.RequestEndBlock{Height: h})
-		mapp.Commit()
-	}
+	// Deliver an empty block after the auction has ended (bid placed at block time zero)
+	header = abci.Header{Height: mapp.LastBlockHeight() + 1, Time: header.Time.Add(DefaultBidDuration + time.Second)}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	mapp.EndBlock(abci.RequestEndBlock{Height: header.Height})
+	mapp.Commit()
 	// Check buyer's coins increased
 	mock.CheckBalance(t, mapp, buyer, sdk.NewCoins(sdk.NewInt64Coin("token1", 120), sdk.NewInt64Coin("token2", 90)))
+	// Check seller's coins increased
+	mock.CheckBalance(t, mapp, seller, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 110)))
 }
 
 func TestApp_ReverseAuction(t *testing.T) {
@@ -78,18 +79,21 @@ func TestApp_ReverseAuction(t *testing.T) {
 
 	// Check seller's coins have decreased
 	mock.CheckBalance(t, mapp, seller, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 100)))
-	// Check buyer's coins have increased
-	mock.CheckBalance(t, mapp, buyer, sdk.NewCoins(sdk.NewInt64Coin("token1", 120), sdk.NewInt64Coin("token2", 90)))
+	// Check buyer's coins have increased by the decrease in lot
+	mock.CheckBalance(t, mapp, buyer, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 90)))
+	// Check the bid is held in escrow
+	mock.CheckBalance(t, mapp, EscrowAccountAddress, sdk.NewCoins(sdk.NewInt64Coin("token1", 20)))
 
-	// Deliver empty blocks until the auction should be closed (bid placed on block 3)
-	for h := mapp.LastBlockHeight() + 1; h < int64(BidDuration)+4; h++ {
-		mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: h}})
-		mapp.EndBlock(abci.RequestEndBlock{Height: h})
-		mapp.Commit()
-	}
+	// Deliver an empty block after the auction has ended (bid placed at block time zero)
+	header = abci.Header{Height: mapp.LastBlockHeight() + 1, Time: header.Time.Add(DefaultBidDuration + time.Second)}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	mapp.EndBlock(abci.RequestEndBlock{Height: header.Height})
+	mapp.Commit()
 
 	// Check seller's coins increased
 	mock.CheckBalance(t, mapp, seller, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 110)))
+	// Check buyer's coins increased
+	mock.CheckBalance(t, mapp, buyer, sdk.NewCoins(sdk.NewInt64Coin("token1", 120), sdk.NewInt64Coin("token2", 90)))
 }
 func TestApp_ForwardReverseAuction(t *testing.T) {
 	// Setup
@@ -104,7 +108,7 @@ func TestApp_ForwardReverseAuction(t *testing.T) {
 	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
 	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
 	ctx := mapp.BaseApp.NewContext(false, header)
-	keeper.StartForwardReverseAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 50), recipient) // seller, lot, maxBid, otherPerson
+	keeper.StartForwardReverseAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 50), sdk.ZeroInt(), recipient) // seller, lot, maxBid, reservePrice, otherPerson
 	mapp.EndBlock(abci.RequestEndBlock{})
 	mapp.Commit()
 
@@ -118,20 +122,89 @@ func TestApp_ForwardReverseAuction(t *testing.T) {
 
 	// Check bidder's coins have decreased
 	mock.CheckBalance(t, mapp, buyer, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 50)))
-	// Check seller's coins have increased
-	mock.CheckBalance(t, mapp, seller, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 150)))
+	// Check the bid is held in escrow
+	mock.CheckBalance(t, mapp, EscrowAccountAddress, sdk.NewCoins(sdk.NewInt64Coin("token2", 50)))
 	// Check "recipient" has received coins
 	mock.CheckBalance(t, mapp, recipient, sdk.NewCoins(sdk.NewInt64Coin("token1", 105), sdk.NewInt64Coin("token2", 100)))
 
-	// Deliver empty blocks until the auction should be closed (bid placed on block 3)
-	for h := mapp.LastBlockHeight() + 1; h < int64(BidDuration)+4; h++ {
-		mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: h}})
-		mapp.EndBlock(abci.RequestEndBlock{Height: h})
-		mapp.Commit()
-	}
+	// Deliver an empty block after the auction has ended (bid placed at block time zero)
+	header = abci.Header{Height: mapp.LastBlockHeight() + 1, Time: header.Time.Add(DefaultBidDuration + time.Second)}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	mapp.EndBlock(abci.RequestEndBlock{Height: header.Height})
+	mapp.Commit()
 
 	// Check buyer's coins increased
 	mock.CheckBalance(t, mapp, buyer, sdk.NewCoins(sdk.NewInt64Coin("token1", 115), sdk.NewInt64Coin("token2", 50)))
+	// Check seller's coins increased
+	mock.CheckBalance(t, mapp, seller, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 150)))
+}
+
+func TestApp_SealedBidAuction(t *testing.T) {
+	// Setup
+	mapp, keeper, addresses, privKeys := setUpMockApp()
+	seller := addresses[0]
+	buyer1, buyer2, buyer3 := addresses[1], addresses[2], addresses[3]
+
+	// Create a block where the creation deposit is set to zero, so the seller's balance only changes by the lot
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	params := keeper.GetParams(ctx)
+	params.CreationDeposit = sdk.NewInt64Coin("token2", 0)
+	keeper.setParams(ctx, params)
+	mapp.EndBlock(abci.RequestEndBlock{})
+	mapp.Commit()
+
+	// deliver a block containing one tx from one of the mock app's accounts (the account number is the index into addresses)
+	deliver := func(blockTime time.Time, msg sdk.Msg, accNum uint64, seq uint64, expPass bool) {
+		header := abci.Header{Height: mapp.LastBlockHeight() + 1, Time: blockTime}
+		mock.SignCheckDeliver(t, mapp.Cdc, mapp.BaseApp, header, []sdk.Msg{msg}, []uint64{accNum}, []uint64{seq}, expPass, expPass, privKeys[accNum])
+	}
+	blockTime := header.Time
+
+	// Deliver a block where the seller starts an auction (lot: 20 t1, min bid: 10 t2, deposit: 5 t2)
+	deliver(blockTime, NewMsgStartSealedBidAuction(seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 10), sdk.NewInt64Coin("token2", 5)), 0, 0, true)
+	mock.CheckBalance(t, mapp, seller, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 100)))
+
+	// Deliver blocks with three sealed bids
+	deliver(blockTime, NewMsgCommitBid(0, buyer1, SealedBidHash(sdk.NewInt64Coin("token2", 30), "salt1")), 1, 0, true)
+	deliver(blockTime, NewMsgCommitBid(0, buyer2, SealedBidHash(sdk.NewInt64Coin("token2", 40), "salt2")), 2, 0, true)
+	deliver(blockTime, NewMsgCommitBid(0, buyer3, SealedBidHash(sdk.NewInt64Coin("token2", 50), "salt3")), 3, 0, true)
+
+	// Check deposits are held in escrow
+	mock.CheckBalance(t, mapp, buyer1, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 95)))
+	mock.CheckBalance(t, mapp, EscrowAccountAddress, sdk.NewCoins(sdk.NewInt64Coin("token2", 15)))
+
+	// Revealing during the commit phase fails
+	deliver(blockTime, NewMsgRevealBid(0, buyer1, sdk.NewInt64Coin("token2", 30), "salt1"), 1, 1, false)
+
+	// Deliver an empty block just after the end of the commit phase to start the reveal phase
+	blockTime = blockTime.Add(DefaultCommitDuration + time.Second)
+	header = abci.Header{Height: mapp.LastBlockHeight() + 1, Time: blockTime}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	mapp.EndBlock(abci.RequestEndBlock{Height: header.Height})
+	mapp.Commit()
+
+	// Reveal two of the bids, the second outbids the first
+	deliver(blockTime, NewMsgRevealBid(0, buyer1, sdk.NewInt64Coin("token2", 30), "salt1"), 1, 2, true)
+	mock.CheckBalance(t, mapp, buyer1, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 70)))
+	deliver(blockTime, NewMsgRevealBid(0, buyer2, sdk.NewInt64Coin("token2", 40), "salt2"), 2, 1, true)
+	mock.CheckBalance(t, mapp, buyer1, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 100)))
+	mock.CheckBalance(t, mapp, buyer2, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 60)))
+	mock.CheckBalance(t, mapp, EscrowAccountAddress, sdk.NewCoins(sdk.NewInt64Coin("token2", 45)))
+
+	// Deliver an empty block just after the end of the reveal phase to close the auction
+	blockTime = blockTime.Add(DefaultRevealDuration)
+	header = abci.Header{Height: mapp.LastBlockHeight() + 1, Time: blockTime}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	mapp.EndBlock(abci.RequestEndBlock{Height: header.Height})
+	mapp.Commit()
+
+	// Check the winner gets the lot, and the seller gets the winning bid and the unrevealed deposit
+	mock.CheckBalance(t, mapp, buyer2, sdk.NewCoins(sdk.NewInt64Coin("token1", 120), sdk.NewInt64Coin("token2", 60)))
+	mock.CheckBalance(t, mapp, buyer3, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 95)))
+	mock.CheckBalance(t, mapp, seller, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 145)))
+	mock.CheckBalance(t, mapp, EscrowAccountAddress, nil)
 }
 
 func setUpMockApp() (*mock.App, Keeper, []sdk.AccAddress, []crypto.PrivKey) {
@@ -144,20 +217,28 @@ func setUpMockApp() (*mock.App, Keeper, []sdk.AccAddress, []crypto.PrivKey) {
 	// Create keepers
 	keyAuction := sdk.NewKVStoreKey("auction")
 	bankKeeper := bank.NewBaseKeeper(mapp.AccountKeeper, mapp.ParamsKeeper.Subspace(bank.DefaultParamspace), bank.DefaultCodespace)
-	auctionKeeper := NewKeeper(mapp.Cdc, bankKeeper, keyAuction)
+	auctionKeeper := NewKeeper(mapp.Cdc, bankKeeper, keyAuction, mapp.ParamsKeeper.Subspace(DefaultParamspace))
 
 	// Register routes
 	mapp.Router().AddRoute("auction", NewHandler(auctionKeeper))
 
-	// Add endblocker
-	mapp.SetEndBlocker(
-		func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-			tags := EndBlocker(ctx, auctionKeeper)
-			return abci.ResponseEndBlock{
+	// Add beginblocker
+	mapp.SetBeginBlocker(
+		func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+			tags := BeginBlocker(ctx, auctionKeeper)
+			return abci.ResponseBeginBlock{
 				Tags: tags,
 			}
 		},
 	)
+	// Add genesis, after the mock app's own genesis setup
+	mapp.SetInitChainer(
+		func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+			res := mapp.InitChainer(ctx, req)
+			InitGenesis(ctx, auctionKeeper, DefaultGenesisState())
+			return res
+		},
+	)
 	// Mount and load the stores
 	err := mapp.CompleteSetup(keyAuction)
 	if err != nil {
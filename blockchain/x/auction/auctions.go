@@ -1,40 +1,57 @@
 package auction
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
-)
-
-const (
-	// MaxAuctionDuration max length of auction, in blocks
-	MaxAuctionDuration endTime = 2 * 24 * 3600 / 5 // roughly 2 days, at 5s block time // 34560
-	// BidDuration how long an auction gets extended when someone bids, in blocks
-	BidDuration endTime = 3 * 3600 / 5 // roughly 3 hours, at 5s block time TODO better name // 2160
+	"github.com/tendermint/tendermint/crypto"
 )
 
 // Auction is an interface to several types of auction.
 type Auction interface {
 	GetID() ID
 	SetID(ID)
-	PlaceBid(currentBlockHeight endTime, bidder sdk.AccAddress, lot sdk.Coin, bid sdk.Coin) ([]bankOutput, []bankInput, sdk.Error)
-	GetEndTime() endTime // auctions close at the end of the block with blockheight EndTime (ie bids placed in that block are valid)
-	GetPayout() bankInput
+	GetType() string
+	GetInitiator() sdk.AccAddress
+	GetBidder() sdk.AccAddress
+	GetLot() sdk.Coins
+	PlaceBid(currentTime time.Time, bidder sdk.AccAddress, lot sdk.Coin, bid sdk.Coin, params AuctionParams) ([]bankOutput, []bankInput, sdk.Error)
+	GetEndTime() time.Time                  // auctions close at the start of the first block with a block time after EndTime (ie bids placed at EndTime are valid)
+	GetPayout() ([]bankOutput, []bankInput) // coin movements to make when the auction closes
 	String() string
+	restart(currentTime time.Time, params AuctionParams) ([]bankOutput, []bankInput) // starts the auction again after it closed without bids, returning any coin movements needed
+	backstop(backstop sdk.AccAddress) ([]bankOutput, []bankInput)                    // sells the lot to the backstop at the reserve price after the auction closed without bids
+	cancel() ([]bankOutput, []bankInput)                                             // coin movements to make when the auction is cancelled, refunding bids and returning the lot
 }
 
 // BaseAuction type shared by all Auctions
 type BaseAuction struct {
-	ID         ID
-	Initiator  sdk.AccAddress // Person who starts the auction. Giving away Lot (aka seller in a forward auction)
-	Lot        sdk.Coin       // Amount of coins up being given by initiator (FA - amount for sale by seller, RA - cost of good by buyer (bid))
-	Bidder     sdk.AccAddress // Person who bids in the auction. Receiver of Lot. (aka buyer in forward auction, seller in RA)
-	Bid        sdk.Coin       // Amount of coins being given by the bidder (FA - bid, RA - amount being sold)
-	EndTime    endTime        // Block height at which the auction closes. It closes at the end of this block
-	MaxEndTime endTime        // Maximum closing time. Auctions can close before this but never after.
+	ID         ID             `json:"id"`
+	Initiator  sdk.AccAddress `json:"initiator"`    // Person who starts the auction. Giving away Lot (aka seller in a forward auction)
+	Lot        sdk.Coin       `json:"lot"`          // Amount of coins up being given by initiator (FA - amount for sale by seller, RA - cost of good by buyer (bid))
+	Bidder     sdk.AccAddress `json:"bidder"`       // Person who bids in the auction. Receiver of Lot. (aka buyer in forward auction, seller in RA)
+	Bid        sdk.Coin       `json:"bid"`          // Amount of coins being given by the bidder (FA - bid, RA - amount being sold)
+	EndTime    time.Time      `json:"end_time"`     // Time at which the auction closes. It closes at the start of the first block with a block time after this
+	MaxEndTime time.Time      `json:"max_end_time"` // Maximum closing time. Auctions can close before this but never after.
 }
 
+// Auction types, used to filter auctions in queries
+const (
+	ForwardAuctionType        = "forward"
+	ReverseAuctionType        = "reverse"
+	ForwardReverseAuctionType = "forward_reverse"
+	DutchAuctionType          = "dutch"
+	SealedBidAuctionType      = "sealed_bid"
+	BasketAuctionType         = "basket"
+	BatchAuctionType          = "batch"
+)
+
 // ID type for auction IDs
 type ID uint64
 
@@ -47,16 +64,17 @@ func NewIDFromString(s string) (ID, error) {
 	return ID(n), nil
 }
 
-type endTime int64 // TODO rename to Blockheight or don't define custom type
+// EscrowAccountAddress is the account that holds bids until they are outbid or the auction closes.
+var EscrowAccountAddress = sdk.AccAddress(crypto.AddressHash([]byte("auctionEscrow")))
 
-// Initially the input and output types from the bank module where used here. But they use sdk.Coins instad of sdk.Coin. So it caused a lot of type conversion as auction mainly uses sdk.Coin.
+// Coin movements use sdk.Coins so auctions can move several coins at once (eg the lot of a basket auction). Single coin auctions wrap their coins with sdk.NewCoins.
 type bankInput struct {
 	Address sdk.AccAddress
-	Coin    sdk.Coin
+	Coins   sdk.Coins
 }
 type bankOutput struct {
 	Address sdk.AccAddress
-	Coin    sdk.Coin
+	Coins   sdk.Coins
 }
 
 // GetID getter for auction ID
@@ -65,16 +83,75 @@ func (a BaseAuction) GetID() ID { return a.ID }
 // SetID setter for auction ID
 func (a *BaseAuction) SetID(id ID) { a.ID = id }
 
+// GetInitiator getter for auction initiator
+func (a BaseAuction) GetInitiator() sdk.AccAddress { return a.Initiator }
+
+// GetBidder getter for the current bidder
+func (a BaseAuction) GetBidder() sdk.AccAddress { return a.Bidder }
+
+// GetLot getter for auction lot
+func (a BaseAuction) GetLot() sdk.Coins { return sdk.NewCoins(a.Lot) }
+
 // GetEndTime getter for auction end time
-func (a BaseAuction) GetEndTime() endTime { return a.EndTime }
+func (a BaseAuction) GetEndTime() time.Time { return a.EndTime }
+
+// GetPayout implements Auction. The lot goes to the winning bidder and their bid is paid out of escrow to the initiator.
+func (a BaseAuction) GetPayout() ([]bankOutput, []bankInput) {
+	inputs := []bankInput{{a.Bidder, sdk.NewCoins(a.Lot)}}
+	if a.Bidder.Equals(a.Initiator) { // no bids were placed, so the lot is returned and there is nothing in escrow
+		return []bankOutput{}, inputs
+	}
+	return []bankOutput{{EscrowAccountAddress, sdk.NewCoins(a.Bid)}}, append(inputs, bankInput{a.Initiator, sdk.NewCoins(a.Bid)})
+}
+
+// restart implements Auction. The auction runs for another MaxAuctionDuration, keeping its reserve price.
+func (a *BaseAuction) restart(currentTime time.Time, params AuctionParams) ([]bankOutput, []bankInput) {
+	a.EndTime = currentTime.Add(params.MaxAuctionDuration)
+	a.MaxEndTime = a.EndTime
+	return []bankOutput{}, []bankInput{}
+}
 
-// GetPayout implements Auction
-func (a BaseAuction) GetPayout() bankInput {
-	return bankInput{a.Bidder, a.Lot}
+// backstop implements Auction. The backstop bids the reserve price (the initial bid), which is held in escrow until the payout.
+func (a *BaseAuction) backstop(backstop sdk.AccAddress) ([]bankOutput, []bankInput) {
+	a.Bidder = backstop
+	if !a.Bid.IsPositive() {
+		return []bankOutput{}, []bankInput{}
+	}
+	return []bankOutput{{backstop, sdk.NewCoins(a.Bid)}}, []bankInput{{EscrowAccountAddress, sdk.NewCoins(a.Bid)}}
 }
 
-func (e endTime) String() string {
-	return string(e)
+// cancel implements Auction. The current bid is refunded from escrow and the lot is returned to the initiator.
+func (a BaseAuction) cancel() ([]bankOutput, []bankInput) {
+	inputs := []bankInput{{a.Initiator, sdk.NewCoins(a.Lot)}}
+	if a.Bidder.Equals(a.Initiator) { // no bids were placed, so there is nothing in escrow
+		return []bankOutput{}, inputs
+	}
+	return []bankOutput{{EscrowAccountAddress, sdk.NewCoins(a.Bid)}}, append(inputs, bankInput{a.Bidder, sdk.NewCoins(a.Bid)})
+}
+
+// bidTransfers returns the coin movements to replace the current bid with a new one. The new bid is held in escrow and the old bid is refunded from escrow.
+// A bidder raising their own bid only pays the difference. The initiator's starting bid was never paid, so it isn't refunded.
+func (a BaseAuction) bidTransfers(bidder sdk.AccAddress, bid sdk.Coin) ([]bankOutput, []bankInput, sdk.Error) {
+	return bidTransfers(a.Initiator, a.Bidder, a.Bid, bidder, bid)
+}
+
+func bidTransfers(initiator sdk.AccAddress, currentBidder sdk.AccAddress, currentBid sdk.Coin, bidder sdk.AccAddress, bid sdk.Coin) ([]bankOutput, []bankInput, sdk.Error) {
+	switch {
+	case bidder.Equals(initiator):
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction initiator cannot bid")
+	case currentBidder.Equals(initiator):
+		return []bankOutput{{bidder, sdk.NewCoins(bid)}}, []bankInput{{EscrowAccountAddress, sdk.NewCoins(bid)}}, nil
+	case bidder.Equals(currentBidder):
+		if bid.IsEqual(currentBid) {
+			return []bankOutput{}, []bankInput{}, nil
+		}
+		increase := bid.Sub(currentBid)
+		return []bankOutput{{bidder, sdk.NewCoins(increase)}}, []bankInput{{EscrowAccountAddress, sdk.NewCoins(increase)}}, nil
+	default:
+		outputs := []bankOutput{{bidder, sdk.NewCoins(bid)}, {EscrowAccountAddress, sdk.NewCoins(currentBid)}}
+		inputs := []bankInput{{EscrowAccountAddress, sdk.NewCoins(bid)}, {currentBidder, sdk.NewCoins(currentBid)}}
+		return outputs, inputs, nil
+	}
 }
 
 func (a BaseAuction) String() string {
@@ -96,8 +173,11 @@ type ForwardAuction struct {
 	BaseAuction
 }
 
+// GetType implements Auction
+func (a ForwardAuction) GetType() string { return ForwardAuctionType }
+
 // NewForwardAuction creates a new forward auction
-func NewForwardAuction(seller sdk.AccAddress, lot sdk.Coin, initialBid sdk.Coin, endTime endTime) (ForwardAuction, bankOutput) {
+func NewForwardAuction(seller sdk.AccAddress, lot sdk.Coin, initialBid sdk.Coin, endTime time.Time) (ForwardAuction, bankOutput) {
 	auction := ForwardAuction{BaseAuction{
 		// no ID
 		Initiator:  seller,
@@ -107,41 +187,180 @@ func NewForwardAuction(seller sdk.AccAddress, lot sdk.Coin, initialBid sdk.Coin,
 		EndTime:    endTime,
 		MaxEndTime: endTime,
 	}}
-	output := bankOutput{seller, lot}
+	output := bankOutput{seller, sdk.NewCoins(lot)}
 	return auction, output
 }
 
 // PlaceBid implements Auction
-func (a *ForwardAuction) PlaceBid(currentBlockHeight endTime, bidder sdk.AccAddress, lot sdk.Coin, bid sdk.Coin) ([]bankOutput, []bankInput, sdk.Error) {
+func (a *ForwardAuction) PlaceBid(currentTime time.Time, bidder sdk.AccAddress, lot sdk.Coin, bid sdk.Coin, params AuctionParams) ([]bankOutput, []bankInput, sdk.Error) {
 	// TODO check lot size matches lot?
 	// check auction has not closed
-	if currentBlockHeight > a.EndTime {
+	if currentTime.After(a.EndTime) {
 		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction has closed")
 	}
-	// check bid is greater than last bid
-	if !a.Bid.IsLT(bid) { // TODO add minimum bid size
-		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("bid not greater than last bid")
+	// check bid is at least the minimum increment above the last bid, or at least the reserve price if there are no bids yet
+	if bid.Denom != a.Bid.Denom {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("bid must be in %s", a.Bid.Denom))
+	}
+	minBid := minNextBid(a.Bid, params.MinBidIncrement)
+	if a.Bidder.Equals(a.Initiator) && a.Bid.IsPositive() {
+		minBid = a.Bid
+	}
+	if bid.IsLT(minBid) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("bid too small, must be at least %s", minBid))
 	}
 	// calculate coin movements
-	outputs := []bankOutput{{bidder, bid}}                                  // new bidder pays bid now
-	inputs := []bankInput{{a.Bidder, a.Bid}, {a.Initiator, bid.Sub(a.Bid)}} // old bidder is paid back, extra goes to seller
+	outputs, inputs, err := a.bidTransfers(bidder, bid) // new bid is held in escrow, old bidder is paid back
+	if err != nil {
+		return []bankOutput{}, []bankInput{}, err
+	}
 
 	// update auction
 	a.Bidder = bidder
 	a.Bid = bid
 	// increment timeout // TODO into keeper?
-	a.EndTime = endTime(min(int64(currentBlockHeight+BidDuration), int64(a.MaxEndTime))) // TODO is there a better way to structure these types?
+	a.EndTime = earliestTime(currentTime.Add(params.BidDuration), a.MaxEndTime)
+
+	return outputs, inputs, nil
+}
+
+// BasketAuction is a forward auction where the lot is made up of several coins, eg leftover collateral of different types.
+// A forward auction of a single coin is a special case, so it has its own type (ForwardAuction) that shares BaseAuction with the other auctions.
+type BasketAuction struct {
+	ID         ID             `json:"id"`
+	Initiator  sdk.AccAddress `json:"initiator"`
+	Lot        sdk.Coins      `json:"lot"`
+	Bidder     sdk.AccAddress `json:"bidder"`
+	Bid        sdk.Coin       `json:"bid"`
+	EndTime    time.Time      `json:"end_time"`
+	MaxEndTime time.Time      `json:"max_end_time"`
+}
+
+// NewBasketAuction creates a new basket auction
+func NewBasketAuction(seller sdk.AccAddress, lot sdk.Coins, initialBid sdk.Coin, endTime time.Time) (BasketAuction, bankOutput) {
+	auction := BasketAuction{
+		// no ID
+		Initiator:  seller,
+		Lot:        lot,
+		Bidder:     seller,     // send the proceeds from the first bid back to the seller
+		Bid:        initialBid, // the reserve price, or zero
+		EndTime:    endTime,
+		MaxEndTime: endTime,
+	}
+	output := bankOutput{seller, lot}
+	return auction, output
+}
+
+// GetID implements Auction
+func (a BasketAuction) GetID() ID { return a.ID }
+
+// SetID implements Auction
+func (a *BasketAuction) SetID(id ID) { a.ID = id }
+
+// GetType implements Auction
+func (a BasketAuction) GetType() string { return BasketAuctionType }
+
+// GetInitiator implements Auction
+func (a BasketAuction) GetInitiator() sdk.AccAddress { return a.Initiator }
+
+// GetBidder implements Auction
+func (a BasketAuction) GetBidder() sdk.AccAddress { return a.Bidder }
+
+// GetLot implements Auction
+func (a BasketAuction) GetLot() sdk.Coins { return a.Lot }
+
+// GetEndTime implements Auction
+func (a BasketAuction) GetEndTime() time.Time { return a.EndTime }
+
+// PlaceBid implements Auction. Bids are always for the whole lot, so the lot argument is ignored.
+func (a *BasketAuction) PlaceBid(currentTime time.Time, bidder sdk.AccAddress, lot sdk.Coin, bid sdk.Coin, params AuctionParams) ([]bankOutput, []bankInput, sdk.Error) {
+	// check auction has not closed
+	if currentTime.After(a.EndTime) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction has closed")
+	}
+	// check bid is at least the minimum increment above the last bid, or at least the reserve price if there are no bids yet
+	if bid.Denom != a.Bid.Denom {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("bid must be in %s", a.Bid.Denom))
+	}
+	minBid := minNextBid(a.Bid, params.MinBidIncrement)
+	if a.Bidder.Equals(a.Initiator) && a.Bid.IsPositive() {
+		minBid = a.Bid
+	}
+	if bid.IsLT(minBid) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("bid too small, must be at least %s", minBid))
+	}
+	// calculate coin movements
+	outputs, inputs, err := bidTransfers(a.Initiator, a.Bidder, a.Bid, bidder, bid) // new bid is held in escrow, old bidder is paid back
+	if err != nil {
+		return []bankOutput{}, []bankInput{}, err
+	}
+
+	// update auction
+	a.Bidder = bidder
+	a.Bid = bid
+	a.EndTime = earliestTime(currentTime.Add(params.BidDuration), a.MaxEndTime)
 
 	return outputs, inputs, nil
 }
 
+// GetPayout implements Auction. The lot goes to the winning bidder and their bid is paid out of escrow to the initiator.
+func (a BasketAuction) GetPayout() ([]bankOutput, []bankInput) {
+	inputs := []bankInput{{a.Bidder, a.Lot}}
+	if a.Bidder.Equals(a.Initiator) { // no bids were placed, so the lot is returned and there is nothing in escrow
+		return []bankOutput{}, inputs
+	}
+	return []bankOutput{{EscrowAccountAddress, sdk.NewCoins(a.Bid)}}, append(inputs, bankInput{a.Initiator, sdk.NewCoins(a.Bid)})
+}
+
+// restart implements Auction. The auction runs for another MaxAuctionDuration, keeping its reserve price.
+func (a *BasketAuction) restart(currentTime time.Time, params AuctionParams) ([]bankOutput, []bankInput) {
+	a.EndTime = currentTime.Add(params.MaxAuctionDuration)
+	a.MaxEndTime = a.EndTime
+	return []bankOutput{}, []bankInput{}
+}
+
+// backstop implements Auction. The backstop bids the reserve price (the initial bid), which is held in escrow until the payout.
+func (a *BasketAuction) backstop(backstop sdk.AccAddress) ([]bankOutput, []bankInput) {
+	a.Bidder = backstop
+	if !a.Bid.IsPositive() {
+		return []bankOutput{}, []bankInput{}
+	}
+	return []bankOutput{{backstop, sdk.NewCoins(a.Bid)}}, []bankInput{{EscrowAccountAddress, sdk.NewCoins(a.Bid)}}
+}
+
+// cancel implements Auction
+func (a BasketAuction) cancel() ([]bankOutput, []bankInput) {
+	inputs := []bankInput{{a.Initiator, a.Lot}}
+	if a.Bidder.Equals(a.Initiator) {
+		return []bankOutput{}, inputs
+	}
+	return []bankOutput{{EscrowAccountAddress, sdk.NewCoins(a.Bid)}}, append(inputs, bankInput{a.Bidder, sdk.NewCoins(a.Bid)})
+}
+
+func (a BasketAuction) String() string {
+	return fmt.Sprintf(`Auction %d:
+  Initiator:              %s
+  Lot:                    %s
+  Bidder:                 %s
+  Bid:                    %s
+  End Time:               %s
+  Max End Time:           %s`,
+		a.GetID(), a.Initiator, a.Lot,
+		a.Bidder, a.Bid, a.GetEndTime().String(),
+		a.MaxEndTime.String(),
+	)
+}
+
 // ReverseAuction type for reverse auctions
 type ReverseAuction struct {
 	BaseAuction
 }
 
+// GetType implements Auction
+func (a ReverseAuction) GetType() string { return ReverseAuctionType }
+
 // NewReverseAuction creates a new reverse auction
-func NewReverseAuction(buyer sdk.AccAddress, bid sdk.Coin, initialLot sdk.Coin, endTime endTime) (ReverseAuction, bankOutput) {
+func NewReverseAuction(buyer sdk.AccAddress, bid sdk.Coin, initialLot sdk.Coin, endTime time.Time) (ReverseAuction, bankOutput) {
 	auction := ReverseAuction{BaseAuction{
 		// no ID
 		Initiator:  buyer,
@@ -151,31 +370,44 @@ func NewReverseAuction(buyer sdk.AccAddress, bid sdk.Coin, initialLot sdk.Coin,
 		EndTime:    endTime,
 		MaxEndTime: endTime,
 	}}
-	output := bankOutput{buyer, initialLot}
+	output := bankOutput{buyer, sdk.NewCoins(initialLot)}
 	return auction, output
 }
 
 // PlaceBid implements Auction
-func (a *ReverseAuction) PlaceBid(currentBlockHeight endTime, bidder sdk.AccAddress, lot sdk.Coin, bid sdk.Coin) ([]bankOutput, []bankInput, sdk.Error) {
+func (a *ReverseAuction) PlaceBid(currentTime time.Time, bidder sdk.AccAddress, lot sdk.Coin, bid sdk.Coin, params AuctionParams) ([]bankOutput, []bankInput, sdk.Error) {
 
 	// check bid size matches bid?
 	// check auction has not closed
-	if currentBlockHeight > a.EndTime {
+	if currentTime.After(a.EndTime) {
 		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction has closed")
 	}
-	// check bid is less than last bid
-	if !lot.IsLT(a.Lot) { // TODO add min bid decrements
-		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("lot not smaller than last lot")
+	// check lot is at least the minimum decrement below the last lot, or at most the reserve price if there are no bids yet
+	if lot.Denom != a.Lot.Denom {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("lot must be in %s", a.Lot.Denom))
+	}
+	maxLot := maxNextLot(a.Lot, params.MinLotDecrement)
+	firstBid := a.Bidder.Equals(a.Initiator)
+	if firstBid {
+		maxLot = a.Lot
+	}
+	if maxLot.IsLT(lot) || (!firstBid && !lot.IsLT(a.Lot)) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("lot too large, must be at most %s", maxLot))
 	}
 	// calculate coin movements
-	outputs := []bankOutput{{bidder, a.Bid}}                                // new bidder pays bid now
-	inputs := []bankInput{{a.Bidder, a.Bid}, {a.Initiator, a.Lot.Sub(lot)}} // old bidder is paid back, decrease in price for goes to buyer
+	outputs, inputs, err := a.bidTransfers(bidder, a.Bid) // new bid is held in escrow, old bidder is paid back
+	if err != nil {
+		return []bankOutput{}, []bankInput{}, err
+	}
+	if lot.IsLT(a.Lot) {
+		inputs = append(inputs, bankInput{a.Initiator, sdk.NewCoins(a.Lot.Sub(lot))}) // decrease in price goes to buyer
+	}
 
 	// update auction
 	a.Bidder = bidder
 	a.Lot = lot
 	// increment timeout // TODO into keeper?
-	a.EndTime = endTime(min(int64(currentBlockHeight+BidDuration), int64(a.MaxEndTime))) // TODO is there a better way to structure these types?
+	a.EndTime = earliestTime(currentTime.Add(params.BidDuration), a.MaxEndTime)
 
 	return outputs, inputs, nil
 }
@@ -183,10 +415,13 @@ func (a *ReverseAuction) PlaceBid(currentBlockHeight endTime, bidder sdk.AccAddr
 // ForwardReverseAuction type for forward reverse auction
 type ForwardReverseAuction struct {
 	BaseAuction
-	MaxBid      sdk.Coin
-	OtherPerson sdk.AccAddress // TODO rename, this is normally the original CDP owner
+	MaxBid      sdk.Coin       `json:"max_bid"`
+	OtherPerson sdk.AccAddress `json:"other_person"` // TODO rename, this is normally the original CDP owner
 }
 
+// GetType implements Auction
+func (a ForwardReverseAuction) GetType() string { return ForwardReverseAuctionType }
+
 func (a ForwardReverseAuction) String() string {
 	return fmt.Sprintf(`Auction %d:
   Initiator:              %s
@@ -204,58 +439,80 @@ func (a ForwardReverseAuction) String() string {
 }
 
 // NewForwardReverseAuction creates a new forward reverse auction
-func NewForwardReverseAuction(seller sdk.AccAddress, lot sdk.Coin, initialBid sdk.Coin, endTime endTime, maxBid sdk.Coin, otherPerson sdk.AccAddress) (ForwardReverseAuction, bankOutput) {
+func NewForwardReverseAuction(seller sdk.AccAddress, lot sdk.Coin, initialBid sdk.Coin, endTime time.Time, maxBid sdk.Coin, otherPerson sdk.AccAddress) (ForwardReverseAuction, bankOutput) {
 	auction := ForwardReverseAuction{
 		BaseAuction: BaseAuction{
 			// no ID
 			Initiator:  seller,
 			Lot:        lot,
 			Bidder:     seller,     // send the proceeds from the first bid back to the seller
-			Bid:        initialBid, // 0 most of the time
+			Bid:        initialBid, // the reserve price, or zero
 			EndTime:    endTime,
 			MaxEndTime: endTime},
 		MaxBid:      maxBid,
 		OtherPerson: otherPerson,
 	}
-	output := bankOutput{seller, lot}
+	output := bankOutput{seller, sdk.NewCoins(lot)}
 	return auction, output
 }
 
 // PlaceBid implements auction
-func (a *ForwardReverseAuction) PlaceBid(currentBlockHeight endTime, bidder sdk.AccAddress, lot sdk.Coin, bid sdk.Coin) (outputs []bankOutput, inputs []bankInput, err sdk.Error) {
+func (a *ForwardReverseAuction) PlaceBid(currentTime time.Time, bidder sdk.AccAddress, lot sdk.Coin, bid sdk.Coin, params AuctionParams) (outputs []bankOutput, inputs []bankInput, err sdk.Error) {
 	// check auction has not closed
-	if currentBlockHeight > a.EndTime {
+	if currentTime.After(a.EndTime) {
 		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction has closed")
 	}
+	// the bid and lot are compared in every phase, so both have to be in the auction's denoms
+	if bid.Denom != a.Bid.Denom {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("bid must be in %s", a.Bid.Denom))
+	}
+	if lot.Denom != a.Lot.Denom {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("lot must be in %s", a.Lot.Denom))
+	}
 
 	// determine phase of auction
 	switch {
 	case a.Bid.IsLT(a.MaxBid) && bid.IsLT(a.MaxBid):
 		// Forward auction phase
-		if !a.Bid.IsLT(bid) { // TODO add min bid increments
-			return []bankOutput{}, []bankInput{}, sdk.ErrInternal("bid not greater than last bid")
+		minBid := minNextBid(a.Bid, params.MinBidIncrement)
+		if a.Bidder.Equals(a.Initiator) && a.Bid.IsPositive() {
+			minBid = a.Bid // the first bid only needs to reach the reserve price
+		}
+		if a.MaxBid.IsLT(minBid) {
+			minBid = a.MaxBid // a bid of MaxBid is always big enough
+		}
+		if bid.IsLT(minBid) {
+			return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("bid too small, must be at least %s", minBid))
+		}
+		outputs, inputs, err = a.bidTransfers(bidder, bid) // new bid is held in escrow, old bidder is paid back
+		if err != nil {
+			return []bankOutput{}, []bankInput{}, err
 		}
-		outputs = []bankOutput{{bidder, bid}}                                  // new bidder pays bid now
-		inputs = []bankInput{{a.Bidder, a.Bid}, {a.Initiator, bid.Sub(a.Bid)}} // old bidder is paid back, extra goes to seller
 	case a.Bid.IsLT(a.MaxBid):
 		// Switch over phase
 		if !bid.IsEqual(a.MaxBid) { // require bid == a.MaxBid
 			return []bankOutput{}, []bankInput{}, sdk.ErrInternal("bid greater than the max bid")
 		}
-		outputs = []bankOutput{{bidder, bid}} // new bidder pays bid now
-		inputs = []bankInput{
-			{a.Bidder, a.Bid},               // old bidder is paid back
-			{a.Initiator, bid.Sub(a.Bid)},   // extra goes to seller
-			{a.OtherPerson, a.Lot.Sub(lot)}, //decrease in price for goes to original CDP owner
+		if a.Lot.IsLT(lot) {
+			return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("lot too large, must be at most %s", a.Lot))
+		}
+		outputs, inputs, err = a.bidTransfers(bidder, bid) // new bid is held in escrow, old bidder is paid back
+		if err != nil {
+			return []bankOutput{}, []bankInput{}, err
 		}
+		inputs = append(inputs, bankInput{a.OtherPerson, sdk.NewCoins(a.Lot.Sub(lot))}) // decrease in price goes to original CDP owner
 
 	case a.Bid.IsEqual(a.MaxBid):
 		// Reverse auction phase
-		if !lot.IsLT(a.Lot) { // TODO add min bid decrements
-			return []bankOutput{}, []bankInput{}, sdk.ErrInternal("lot not smaller than last lot")
+		maxLot := maxNextLot(a.Lot, params.MinLotDecrement)
+		if maxLot.IsLT(lot) || !lot.IsLT(a.Lot) {
+			return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("lot too large, must be at most %s", maxLot))
+		}
+		outputs, inputs, err = a.bidTransfers(bidder, a.Bid) // new bid is held in escrow, old bidder is paid back
+		if err != nil {
+			return []bankOutput{}, []bankInput{}, err
 		}
-		outputs = []bankOutput{{bidder, a.Bid}}                                  // new bidder pays bid now
-		inputs = []bankInput{{a.Bidder, a.Bid}, {a.OtherPerson, a.Lot.Sub(lot)}} // old bidder is paid back, decrease in price for goes to original CDP owner
+		inputs = append(inputs, bankInput{a.OtherPerson, sdk.NewCoins(a.Lot.Sub(lot))}) // decrease in price goes to original CDP owner
 	default:
 		panic("should never be reached") // TODO
 	}
@@ -265,7 +522,988 @@ func (a *ForwardReverseAuction) PlaceBid(currentBlockHeight endTime, bidder sdk.
 	a.Lot = lot
 	a.Bid = bid
 	// increment timeout
-	a.EndTime = endTime(min(int64(currentBlockHeight+BidDuration), int64(a.MaxEndTime))) // TODO is there a better way to structure these types?
+	a.EndTime = earliestTime(currentTime.Add(params.BidDuration), a.MaxEndTime)
+
+	return outputs, inputs, nil
+}
+
+// split splits part of the lot off into a new child auction, so it can be bid on separately. It is only possible in the forward phase.
+// The child gets a proportional share of the max bid and of the current bid, rounded down. The current bidder keeps their bid on both parts, so they are refunded their share when outbid on the child.
+func (a *ForwardReverseAuction) split(lot sdk.Coin) (ForwardReverseAuction, sdk.Error) {
+	if !a.Bid.IsLT(a.MaxBid) {
+		return ForwardReverseAuction{}, sdk.ErrInternal("auction lots can only be split in the forward phase")
+	}
+	if lot.Denom != a.Lot.Denom || !lot.IsPositive() || !lot.IsLT(a.Lot) {
+		return ForwardReverseAuction{}, sdk.ErrInternal(fmt.Sprintf("part of lot must be positive and less than %s", a.Lot))
+	}
+	childMaxBid := sdk.NewCoin(a.MaxBid.Denom, a.MaxBid.Amount.Mul(lot.Amount).Quo(a.Lot.Amount))
+	if !childMaxBid.IsPositive() {
+		return ForwardReverseAuction{}, sdk.ErrInternal("part of lot too small, its share of the max bid would be zero")
+	}
+	childBid := sdk.NewCoin(a.Bid.Denom, a.Bid.Amount.Mul(lot.Amount).Quo(a.Lot.Amount))
+	child := ForwardReverseAuction{
+		BaseAuction: BaseAuction{
+			Initiator:  a.Initiator,
+			Lot:        lot,
+			Bidder:     a.Bidder,
+			Bid:        childBid,
+			EndTime:    a.EndTime,
+			MaxEndTime: a.MaxEndTime},
+		MaxBid:      childMaxBid,
+		OtherPerson: a.OtherPerson,
+	}
+	// shares are rounded down the same way, so the parent's bid stays at or below its max bid
+	a.Lot = a.Lot.Sub(lot)
+	a.MaxBid = a.MaxBid.Sub(childMaxBid)
+	a.Bid = a.Bid.Sub(childBid)
+	return child, nil
+}
+
+// DutchAuction type for descending price auctions. The price starts high and decays over time, and bidders can buy some or all of the lot at the current price.
+// Purchases settle immediately. The auction closes once the lot is sold or MaxBid has been raised, with any remaining lot going to OtherPerson.
+// If the price falls below ResetPrice the auction restarts from StartPrice.
+type DutchAuction struct {
+	BaseAuction                // Lot is the amount left for sale, Bid is the total raised so far, and Bidder is the last buyer
+	MaxBid      sdk.Coin       `json:"max_bid"`      // Amount to raise. Once raised, the rest of the lot goes to OtherPerson
+	OtherPerson sdk.AccAddress `json:"other_person"` // normally the original CDP owner
+	StartPrice  sdk.Dec        `json:"start_price"`  // Price of one unit of lot (in bid coins) at StartTime. Known as top in maker.
+	StartTime   time.Time      `json:"start_time"`   // Time the price started decaying from, updated when the auction resets
+	ResetPrice  sdk.Dec        `json:"reset_price"`  // Price below which the auction restarts
+	Curve       PriceCurve     `json:"curve"`        // How the price decays over time. Known as calc in maker.
+}
+
+// GetType implements Auction
+func (a DutchAuction) GetType() string { return DutchAuctionType }
+
+func (a DutchAuction) String() string {
+	return fmt.Sprintf(`Auction %d:
+  Initiator:              %s
+  Lot:               			%s
+  Bidder:            		  %s
+  Bid:        						%s
+  End Time:   						%s
+	Max End Time:      			%s
+	Max Bid									%s
+	Other Person						%s
+	Start Price							%s
+	Start Time							%s
+	Reset Price							%s
+	Curve										%s`,
+		a.GetID(), a.Initiator, a.Lot,
+		a.Bidder, a.Bid, a.GetEndTime().String(),
+		a.MaxEndTime.String(), a.MaxBid, a.OtherPerson,
+		a.StartPrice, a.StartTime.String(), a.ResetPrice, a.Curve,
+	)
+}
+
+// NewDutchAuction creates a new dutch auction. The end time is set to when the price first falls below the reset price, capped at maxEndTime.
+func NewDutchAuction(seller sdk.AccAddress, lot sdk.Coin, maxBid sdk.Coin, otherPerson sdk.AccAddress, startTime time.Time, maxEndTime time.Time, startPrice sdk.Dec, resetPrice sdk.Dec, curve PriceCurve) (DutchAuction, bankOutput) {
+	auction := DutchAuction{
+		BaseAuction: BaseAuction{
+			// no ID
+			Initiator:  seller,
+			Lot:        lot,
+			Bidder:     seller,
+			Bid:        sdk.NewInt64Coin(maxBid.Denom, 0), // nothing raised yet
+			MaxEndTime: maxEndTime,
+		},
+		MaxBid:      maxBid,
+		OtherPerson: otherPerson,
+		StartPrice:  startPrice,
+		ResetPrice:  resetPrice,
+		Curve:       curve,
+	}
+	auction.reset(startTime)
+	output := bankOutput{seller, sdk.NewCoins(lot)}
+	return auction, output
+}
+
+// CurrentPrice returns the price of one unit of lot at a point in time.
+func (a DutchAuction) CurrentPrice(currentTime time.Time) sdk.Dec {
+	return a.Curve.PriceAt(a.StartPrice, currentTime.Sub(a.StartTime))
+}
+
+// reset restarts the price from the start price, moving the end time to the next time the price will fall below the reset price.
+func (a *DutchAuction) reset(currentTime time.Time) {
+	a.StartTime = currentTime
+	a.EndTime = a.MaxEndTime
+	if !currentTime.Before(a.MaxEndTime) {
+		return
+	}
+	// binary search for the first second the price is below the reset price, relying on the price never increasing
+	seconds := int(a.MaxEndTime.Sub(currentTime) / time.Second)
+	i := sort.Search(seconds+1, func(i int) bool {
+		return a.CurrentPrice(currentTime.Add(time.Duration(i) * time.Second)).LT(a.ResetPrice)
+	})
+	if i <= seconds {
+		a.EndTime = currentTime.Add(time.Duration(i) * time.Second)
+	}
+}
+
+// canReset returns true if the auction has reached its end time but is not finished, so should restart rather than close.
+func (a DutchAuction) canReset(currentTime time.Time) bool {
+	return currentTime.Before(a.MaxEndTime) && a.Lot.IsPositive() && a.Bid.IsLT(a.MaxBid)
+}
+
+// PlaceBid implements Auction. The lot is the amount to buy and the bid is the most the bidder is willing to pay for it.
+// Bidders pay the current price for the lot, up to the amount still to be raised, and the coins are moved straight away.
+func (a *DutchAuction) PlaceBid(currentTime time.Time, bidder sdk.AccAddress, lot sdk.Coin, bid sdk.Coin, params AuctionParams) ([]bankOutput, []bankInput, sdk.Error) {
+	// check auction has not closed
+	if currentTime.After(a.EndTime) || !a.Lot.IsPositive() || !a.Bid.IsLT(a.MaxBid) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction has closed")
+	}
+	price := a.CurrentPrice(currentTime)
+	if price.LT(a.ResetPrice) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction price is below the reset price, wait for the auction to restart")
+	}
+	if bidder.Equals(a.Initiator) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction initiator cannot bid")
+	}
+	if lot.Denom != a.Lot.Denom || bid.Denom != a.Bid.Denom {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("bid or lot has the wrong denom")
+	}
+	if !lot.IsPositive() {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("lot must be positive")
+	}
+	if a.Lot.IsLT(lot) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("lot too large, must be at most %s", a.Lot))
+	}
+
+	// work out how much lot is bought and what it costs, without raising more than MaxBid
+	remaining := a.MaxBid.Amount.Sub(a.Bid.Amount)
+	lotAmount := lot.Amount
+	if price.IsPositive() {
+		lotAmount = sdk.MinInt(lotAmount, sdk.NewDecFromInt(remaining).Quo(price).Ceil().TruncateInt())
+	}
+	cost := sdk.MinInt(sdk.NewDecFromInt(lotAmount).Mul(price).Ceil().TruncateInt(), remaining)
+	if bid.Amount.LT(cost) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal(fmt.Sprintf("bid too small, lot costs %s%s at the current price", cost, a.Bid.Denom))
+	}
+
+	// buyer pays the initiator and receives the lot
+	bought := sdk.NewCoin(a.Lot.Denom, lotAmount)
+	paid := sdk.NewCoin(a.Bid.Denom, cost)
+	outputs := []bankOutput{{bidder, sdk.NewCoins(paid)}}
+	inputs := []bankInput{{a.Initiator, sdk.NewCoins(paid)}, {bidder, sdk.NewCoins(bought)}}
+
+	// update auction
+	a.Bidder = bidder
+	a.Lot = a.Lot.Sub(bought)
+	a.Bid = a.Bid.Add(paid)
+	if !a.Bid.IsLT(a.MaxBid) {
+		// enough has been raised, return the rest of the lot to the original CDP owner
+		if a.Lot.IsPositive() {
+			inputs = append(inputs, bankInput{a.OtherPerson, sdk.NewCoins(a.Lot)})
+		}
+		a.Lot = sdk.NewCoin(a.Lot.Denom, sdk.ZeroInt())
+	}
+	if !a.Lot.IsPositive() {
+		a.EndTime = currentTime // close at the end of this block
+	}
+
+	return outputs, inputs, nil
+}
+
+// GetPayout implements Auction. Purchases are paid out as they are made, so only unsold lot is returned to the initiator.
+func (a DutchAuction) GetPayout() ([]bankOutput, []bankInput) {
+	return []bankOutput{}, []bankInput{{a.Initiator, sdk.NewCoins(a.Lot)}}
+}
+
+// cancel implements Auction. Purchases have already settled, so only the unsold lot is returned to the initiator.
+func (a DutchAuction) cancel() ([]bankOutput, []bankInput) {
+	return []bankOutput{}, []bankInput{{a.Initiator, sdk.NewCoins(a.Lot)}}
+}
+
+// restart implements Auction. The price starts again from the start price, and the auction can run for another MaxAuctionDuration.
+func (a *DutchAuction) restart(currentTime time.Time, params AuctionParams) ([]bankOutput, []bankInput) {
+	a.MaxEndTime = currentTime.Add(params.MaxAuctionDuration)
+	a.reset(currentTime)
+	return []bankOutput{}, []bankInput{}
+}
+
+// backstop implements Auction. The backstop buys the lot at the reset price, paying the initiator straight away like any other purchase.
+// Once MaxBid has been raised, the rest of the lot goes to OtherPerson.
+func (a *DutchAuction) backstop(backstop sdk.AccAddress) ([]bankOutput, []bankInput) {
+	remaining := a.MaxBid.Amount.Sub(a.Bid.Amount)
+	lotAmount := a.Lot.Amount
+	if a.ResetPrice.IsPositive() {
+		lotAmount = sdk.MinInt(lotAmount, sdk.NewDecFromInt(remaining).Quo(a.ResetPrice).Ceil().TruncateInt())
+	}
+	cost := sdk.MinInt(sdk.NewDecFromInt(lotAmount).Mul(a.ResetPrice).Ceil().TruncateInt(), remaining)
+	bought := sdk.NewCoin(a.Lot.Denom, lotAmount)
+	paid := sdk.NewCoin(a.Bid.Denom, cost)
+
+	outputs := []bankOutput{{backstop, sdk.NewCoins(paid)}}
+	inputs := []bankInput{{a.Initiator, sdk.NewCoins(paid)}, {backstop, sdk.NewCoins(bought)}}
+	if unsold := a.Lot.Sub(bought); unsold.IsPositive() {
+		inputs = append(inputs, bankInput{a.OtherPerson, sdk.NewCoins(unsold)})
+	}
+	a.Bidder = backstop
+	a.Bid = a.Bid.Add(paid)
+	a.Lot = sdk.NewCoin(a.Lot.Denom, sdk.ZeroInt())
+	return outputs, inputs
+}
+
+// Price curve types for dutch auctions
+const (
+	LinearCurve      = "linear"
+	StepCurve        = "step"
+	ExponentialCurve = "exponential"
+)
+
+// PriceCurve describes how the price in a dutch auction decays over time. Every interval the price drops by Decay, as a fraction of the start price for linear and step curves, or of the current price for exponential curves.
+// Linear and exponential curves decay continuously, step curves drop at the end of each interval.
+type PriceCurve struct {
+	Type     string        `json:"type"`     // one of LinearCurve, StepCurve or ExponentialCurve
+	Interval time.Duration `json:"interval"` // length of time the price drops by Decay over
+	Decay    sdk.Dec       `json:"decay"`    // fraction the price drops by each interval, between 0 and 1
+}
+
+// NewPriceCurve creates a new PriceCurve
+func NewPriceCurve(curveType string, interval time.Duration, decay sdk.Dec) PriceCurve {
+	return PriceCurve{Type: curveType, Interval: interval, Decay: decay}
+}
+
+// Validate checks the curve type is known and the decay is a fraction of the price.
+func (c PriceCurve) Validate() error {
+	switch c.Type {
+	case LinearCurve, StepCurve, ExponentialCurve:
+	default:
+		return fmt.Errorf("unknown price curve type: %s", c.Type)
+	}
+	if c.Interval < time.Second {
+		return fmt.Errorf("price curve interval must be at least one second: %s", c.Interval)
+	}
+	if c.Decay.IsNil() || !c.Decay.IsPositive() || c.Decay.GT(sdk.OneDec()) {
+		return fmt.Errorf("price curve decay must be above 0 and at most 1: %s", c.Decay)
+	}
+	return nil
+}
+
+// PriceAt returns the price after some time has passed since the start price. It never goes below zero.
+func (c PriceCurve) PriceAt(startPrice sdk.Dec, elapsed time.Duration) sdk.Dec {
+	if elapsed <= 0 {
+		return startPrice
+	}
+	steps := int64(elapsed / c.Interval)
+	fraction := sdk.NewDec(int64(elapsed % c.Interval)).QuoInt64(int64(c.Interval)) // progress through the current interval
+	var multiplier sdk.Dec
+	switch c.Type {
+	case LinearCurve:
+		multiplier = sdk.OneDec().Sub(c.Decay.Mul(sdk.NewDec(steps).Add(fraction)))
+	case StepCurve:
+		multiplier = sdk.OneDec().Sub(c.Decay.MulInt64(steps))
+	case ExponentialCurve:
+		// (1-decay)^steps, interpolated linearly within an interval
+		multiplier = decPow(sdk.OneDec().Sub(c.Decay), steps).Mul(sdk.OneDec().Sub(c.Decay.Mul(fraction)))
+	default:
+		panic(fmt.Sprintf("unknown price curve type: %s", c.Type))
+	}
+	if multiplier.IsNegative() {
+		return sdk.ZeroDec()
+	}
+	return startPrice.Mul(multiplier)
+}
+
+func (c PriceCurve) String() string {
+	return fmt.Sprintf("%s, decay %s every %s", c.Type, c.Decay, c.Interval)
+}
+
+// decPow raises a decimal to a non negative integer power, by repeated squaring.
+func decPow(base sdk.Dec, exponent int64) sdk.Dec {
+	result := sdk.OneDec()
+	for ; exponent > 0; exponent >>= 1 {
+		if exponent&1 == 1 {
+			result = result.Mul(base)
+		}
+		base = base.Mul(base)
+	}
+	return result
+}
+
+// Phases of a sealed bid auction
+const (
+	CommitPhase = "commit"
+	RevealPhase = "reveal"
+)
 
+// SealedBidAuction type for sealed bid (commit reveal) forward auctions.
+// During the commit phase bidders submit a hash of their bid with a deposit. During the reveal phase they reveal their bid, and the highest revealed bid wins.
+// Deposits are refunded on reveal, and forfeited to the initiator if a bid is never revealed.
+type SealedBidAuction struct {
+	BaseAuction             // Bidder and Bid are the best revealed bid so far, or the initiator and minimum bid if there isn't one
+	Deposit     sdk.Coin    `json:"deposit"`     // Amount each bidder must deposit with their sealed bid
+	Phase       string      `json:"phase"`       // CommitPhase or RevealPhase
+	RevealTime  time.Time   `json:"reveal_time"` // Time the commit phase ends and the reveal phase starts
+	SealedBids  []SealedBid `json:"sealed_bids"` // Committed bids, in the order they were placed
+}
+
+// SealedBid is a bidder's commitment to a bid in a sealed bid auction.
+type SealedBid struct {
+	Bidder   sdk.AccAddress `json:"bidder"`
+	Hash     []byte         `json:"hash"` // see SealedBidHash
+	Revealed bool           `json:"revealed"`
+}
+
+// SealedBidHash returns the hash a bidder commits to for a bid. The salt should be kept secret until the bid is revealed.
+func SealedBidHash(bid sdk.Coin, salt string) []byte {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s:%s", bid, salt)))
+	return hash[:]
+}
+
+// GetType implements Auction
+func (a SealedBidAuction) GetType() string { return SealedBidAuctionType }
+
+func (a SealedBidAuction) String() string {
+	return fmt.Sprintf(`Auction %d:
+  Initiator:              %s
+  Lot:               			%s
+  Bidder:            		  %s
+  Bid:        						%s
+  End Time:   						%s
+	Max End Time:      			%s
+	Deposit									%s
+	Phase										%s
+	Reveal Time							%s
+	Sealed Bids							%d`,
+		a.GetID(), a.Initiator, a.Lot,
+		a.Bidder, a.Bid, a.GetEndTime().String(),
+		a.MaxEndTime.String(), a.Deposit, a.Phase,
+		a.RevealTime.String(), len(a.SealedBids),
+	)
+}
+
+// NewSealedBidAuction creates a new sealed bid auction. Bids below minBid don't win.
+func NewSealedBidAuction(seller sdk.AccAddress, lot sdk.Coin, minBid sdk.Coin, deposit sdk.Coin, revealTime time.Time, endTime time.Time) (SealedBidAuction, bankOutput) {
+	auction := SealedBidAuction{
+		BaseAuction: BaseAuction{
+			// no ID
+			Initiator:  seller,
+			Lot:        lot,
+			Bidder:     seller, // send the lot back to the seller if there are no winning bids
+			Bid:        minBid,
+			EndTime:    revealTime, // the auction is in the queue for the end of the commit phase first
+			MaxEndTime: endTime,
+		},
+		Deposit:    deposit,
+		Phase:      CommitPhase,
+		RevealTime: revealTime,
+		SealedBids: []SealedBid{},
+	}
+	output := bankOutput{seller, sdk.NewCoins(lot)}
+	return auction, output
+}
+
+// PlaceBid implements Auction. Sealed bid auctions only accept committed and revealed bids.
+func (a *SealedBidAuction) PlaceBid(currentTime time.Time, bidder sdk.AccAddress, lot sdk.Coin, bid sdk.Coin, params AuctionParams) ([]bankOutput, []bankInput, sdk.Error) {
+	return []bankOutput{}, []bankInput{}, sdk.ErrInternal("sealed bid auctions only accept committed and revealed bids")
+}
+
+// CommitBid records a bidder's sealed bid, taking their deposit into escrow.
+func (a *SealedBidAuction) CommitBid(currentTime time.Time, bidder sdk.AccAddress, hash []byte) ([]bankOutput, []bankInput, sdk.Error) {
+	if a.Phase != CommitPhase || currentTime.After(a.RevealTime) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction is not accepting sealed bids")
+	}
+	if bidder.Equals(a.Initiator) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction initiator cannot bid")
+	}
+	if _, found := a.findSealedBid(bidder); found {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("bidder has already placed a sealed bid")
+	}
+
+	a.SealedBids = append(a.SealedBids, SealedBid{Bidder: bidder, Hash: hash})
+	return []bankOutput{{bidder, sdk.NewCoins(a.Deposit)}}, []bankInput{{EscrowAccountAddress, sdk.NewCoins(a.Deposit)}}, nil
+}
+
+// RevealBid checks a bid against the bidder's sealed bid and refunds their deposit.
+// If the bid beats the best bid so far it is held in escrow, and the previous best bid is refunded. Ties go to the bid revealed first.
+func (a *SealedBidAuction) RevealBid(currentTime time.Time, bidder sdk.AccAddress, bid sdk.Coin, salt string) ([]bankOutput, []bankInput, sdk.Error) {
+	if a.Phase != RevealPhase || currentTime.After(a.EndTime) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction is not accepting revealed bids")
+	}
+	i, found := a.findSealedBid(bidder)
+	if !found {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("bidder has not placed a sealed bid")
+	}
+	if a.SealedBids[i].Revealed {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("bid has already been revealed")
+	}
+	if !bytes.Equal(a.SealedBids[i].Hash, SealedBidHash(bid, salt)) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("bid and salt do not match the sealed bid")
+	}
+	a.SealedBids[i].Revealed = true
+
+	// losing bids just get their deposit back, bids must beat the best bid so far or be at least the minimum bid if there isn't one
+	noBids := a.Bidder.Equals(a.Initiator)
+	winning := bid.Denom == a.Bid.Denom && (a.Bid.IsLT(bid) || noBids && bid.IsEqual(a.Bid))
+	if !winning {
+		return []bankOutput{{EscrowAccountAddress, sdk.NewCoins(a.Deposit)}}, []bankInput{{bidder, sdk.NewCoins(a.Deposit)}}, nil
+	}
+
+	// the winning bid so far replaces the deposit in escrow
+	var outputs []bankOutput
+	var inputs []bankInput
+	switch {
+	case a.Deposit.IsLT(bid):
+		outputs = append(outputs, bankOutput{bidder, sdk.NewCoins(bid.Sub(a.Deposit))})
+		inputs = append(inputs, bankInput{EscrowAccountAddress, sdk.NewCoins(bid.Sub(a.Deposit))})
+	case bid.IsLT(a.Deposit):
+		outputs = append(outputs, bankOutput{EscrowAccountAddress, sdk.NewCoins(a.Deposit.Sub(bid))})
+		inputs = append(inputs, bankInput{bidder, sdk.NewCoins(a.Deposit.Sub(bid))})
+	}
+	if !noBids { // refund the previous best bid
+		outputs = append(outputs, bankOutput{EscrowAccountAddress, sdk.NewCoins(a.Bid)})
+		inputs = append(inputs, bankInput{a.Bidder, sdk.NewCoins(a.Bid)})
+	}
+	a.Bidder = bidder
+	a.Bid = bid
 	return outputs, inputs, nil
 }
+
+// startRevealPhase ends the commit phase, moving the end time to the end of the reveal phase.
+func (a *SealedBidAuction) startRevealPhase() {
+	a.Phase = RevealPhase
+	a.EndTime = a.MaxEndTime
+}
+
+// restart implements Auction. Deposits for bids that were never revealed go to the initiator, then a new commit phase starts.
+func (a *SealedBidAuction) restart(currentTime time.Time, params AuctionParams) ([]bankOutput, []bankInput) {
+	outputs, inputs := a.unrevealedDeposits()
+	a.SealedBids = []SealedBid{}
+	a.Phase = CommitPhase
+	a.RevealTime = currentTime.Add(params.CommitDuration)
+	a.EndTime = a.RevealTime
+	a.MaxEndTime = a.RevealTime.Add(params.RevealDuration)
+	return outputs, inputs
+}
+
+// GetPayout implements Auction. As well as paying out the winning bid, deposits for bids that were never revealed go to the initiator.
+func (a SealedBidAuction) GetPayout() ([]bankOutput, []bankInput) {
+	outputs, inputs := a.BaseAuction.GetPayout()
+	depositOutputs, depositInputs := a.unrevealedDeposits()
+	return append(outputs, depositOutputs...), append(inputs, depositInputs...)
+}
+
+// cancel implements Auction. As well as refunding the best bid, deposits for bids that were never revealed are refunded to their bidders.
+func (a SealedBidAuction) cancel() ([]bankOutput, []bankInput) {
+	outputs, inputs := a.BaseAuction.cancel()
+	for _, sealedBid := range a.SealedBids {
+		if !sealedBid.Revealed {
+			outputs = append(outputs, bankOutput{EscrowAccountAddress, sdk.NewCoins(a.Deposit)})
+			inputs = append(inputs, bankInput{sealedBid.Bidder, sdk.NewCoins(a.Deposit)})
+		}
+	}
+	return outputs, inputs
+}
+
+// unrevealedDeposits returns the coin movements to pay the deposits of bids that were never revealed to the initiator.
+func (a SealedBidAuction) unrevealedDeposits() ([]bankOutput, []bankInput) {
+	outputs := []bankOutput{}
+	inputs := []bankInput{}
+	for _, sealedBid := range a.SealedBids {
+		if !sealedBid.Revealed {
+			outputs = append(outputs, bankOutput{EscrowAccountAddress, sdk.NewCoins(a.Deposit)})
+			inputs = append(inputs, bankInput{a.Initiator, sdk.NewCoins(a.Deposit)})
+		}
+	}
+	return outputs, inputs
+}
+
+func (a SealedBidAuction) findSealedBid(bidder sdk.AccAddress) (int, bool) {
+	for i, sealedBid := range a.SealedBids {
+		if sealedBid.Bidder.Equals(bidder) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// BatchAuction type for batch auctions, which sell a pooled lot to many bidders at a single uniform clearing price.
+// Lots can be added to the pool while the auction is open. Bidders place limit orders for an amount of the lot up to a max price, and pay for the whole order up front.
+// When the auction closes the orders are filled from the highest max price down, and every filled order pays the max price of the lowest order filled.
+// Orders at that price share what is left of the lot in proportion to their amounts.
+type BatchAuction struct {
+	BaseAuction             // Bid is a zero coin in the denom orders pay in. Bidder is the initiator until an order is placed, then the bidder of the latest order.
+	EndHeight   int64       `json:"end_height"` // Last block orders and lots can be added in. The auction closes at the start of the next block.
+	Orders      BatchOrders `json:"orders"`     // Orders placed on the auction, in the order they were placed
+}
+
+// BatchOrder is a limit order to buy part of the lot of a batch auction.
+type BatchOrder struct {
+	Bidder   sdk.AccAddress `json:"bidder"`
+	Amount   sdk.Int        `json:"amount"`    // Amount of the lot wanted
+	MaxPrice sdk.Dec        `json:"max_price"` // Most the bidder will pay for each unit of the lot, in the bid denom
+}
+
+// BatchOrders is a slice of batch orders
+type BatchOrders []BatchOrder
+
+// escrow returns the amount held in escrow for an order, which pays for the whole order at its max price.
+func (o BatchOrder) escrow(denom string) sdk.Coin {
+	return sdk.NewCoin(denom, sdk.NewDecFromInt(o.Amount).Mul(o.MaxPrice).Ceil().TruncateInt())
+}
+
+// BatchFill is the part of an order filled when a batch auction clears.
+type BatchFill struct {
+	Bidder  sdk.AccAddress `json:"bidder"`
+	Lot     sdk.Coin       `json:"lot"`     // Amount of the lot bought
+	Payment sdk.Coin       `json:"payment"` // Amount paid for it at the clearing price
+	Refund  sdk.Coin       `json:"refund"`  // What is left of the order's escrow
+}
+
+// BatchClearing is the outcome of clearing a batch auction.
+type BatchClearing struct {
+	Price  sdk.Dec     `json:"price"`  // Uniform price paid for each unit of the lot, zero if no orders were placed
+	Fills  []BatchFill `json:"fills"`  // One per order, in the order they were placed
+	Sold   sdk.Coin    `json:"sold"`   // Total amount of the lot sold
+	Unsold sdk.Coin    `json:"unsold"` // Amount of the lot left over, returned to the initiator
+	Raised sdk.Coin    `json:"raised"` // Total paid for the lot, paid to the initiator
+}
+
+// GetType implements Auction
+func (a BatchAuction) GetType() string { return BatchAuctionType }
+
+func (a BatchAuction) String() string {
+	return fmt.Sprintf(`Auction %d:
+  Initiator:              %s
+  Lot:               			%s
+  Bidder:            		  %s
+  Bid:        						%s
+  End Height:							%d
+	Orders									%d`,
+		a.GetID(), a.Initiator, a.Lot,
+		a.Bidder, a.Bid, a.EndHeight, len(a.Orders),
+	)
+}
+
+// NewBatchAuction creates a new batch auction, open for orders and lots until endHeight.
+func NewBatchAuction(seller sdk.AccAddress, lot sdk.Coin, bidDenom string, startTime time.Time, endHeight int64) (BatchAuction, bankOutput) {
+	auction := BatchAuction{
+		BaseAuction: BaseAuction{
+			// no ID
+			Initiator:  seller,
+			Lot:        lot,
+			Bidder:     seller, // send the lot back to the seller if there are no orders
+			Bid:        sdk.NewInt64Coin(bidDenom, 0),
+			EndTime:    startTime, // batch auctions close by block height, so these are only the start time
+			MaxEndTime: startTime,
+		},
+		EndHeight: endHeight,
+		Orders:    BatchOrders{},
+	}
+	output := bankOutput{seller, sdk.NewCoins(lot)}
+	return auction, output
+}
+
+// PlaceBid implements Auction. Batch auctions only accept orders.
+func (a *BatchAuction) PlaceBid(currentTime time.Time, bidder sdk.AccAddress, lot sdk.Coin, bid sdk.Coin, params AuctionParams) ([]bankOutput, []bankInput, sdk.Error) {
+	return []bankOutput{}, []bankInput{}, sdk.ErrInternal("batch auctions only accept orders")
+}
+
+// PlaceOrder adds an order to the auction, taking payment for the whole order at its max price into escrow.
+func (a *BatchAuction) PlaceOrder(currentHeight int64, bidder sdk.AccAddress, amount sdk.Int, maxPrice sdk.Dec) ([]bankOutput, []bankInput, sdk.Error) {
+	if currentHeight > a.EndHeight {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction is not accepting orders")
+	}
+	if bidder.Equals(a.Initiator) {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("auction initiator cannot bid")
+	}
+	if !amount.IsPositive() || !maxPrice.IsPositive() {
+		return []bankOutput{}, []bankInput{}, sdk.ErrInternal("order amount and max price must be positive")
+	}
+
+	order := BatchOrder{Bidder: bidder, Amount: amount, MaxPrice: maxPrice}
+	a.Orders = append(a.Orders, order)
+	a.Bidder = bidder
+	escrow := order.escrow(a.Bid.Denom)
+	return []bankOutput{{bidder, sdk.NewCoins(escrow)}}, []bankInput{{EscrowAccountAddress, sdk.NewCoins(escrow)}}, nil
+}
+
+// AddLot adds coins to the pooled lot while the auction is open.
+func (a *BatchAuction) AddLot(currentHeight int64, lot sdk.Coin) sdk.Error {
+	if currentHeight > a.EndHeight {
+		return sdk.ErrInternal("auction is not accepting lots")
+	}
+	if lot.Denom != a.Lot.Denom || !lot.IsPositive() {
+		return sdk.ErrInternal(fmt.Sprintf("lot must be a positive amount of %s", a.Lot.Denom))
+	}
+	a.Lot = a.Lot.Add(lot)
+	return nil
+}
+
+// Clear works out the uniform clearing price of the auction and how much of each order is filled at it.
+// Orders are filled from the highest max price down until the lot runs out. The clearing price is the max price of the last orders filled (the marginal orders).
+// The marginal orders share the rest of the lot in proportion to their amounts, with any units left over from rounding going to the earliest of them.
+// Payments are rounded up to the nearest unit, which an order's escrow always covers.
+func (a BatchAuction) Clear() BatchClearing {
+	lotDenom, bidDenom := a.Lot.Denom, a.Bid.Denom
+	clearing := BatchClearing{
+		Price:  sdk.ZeroDec(),
+		Fills:  make([]BatchFill, len(a.Orders)),
+		Sold:   sdk.NewInt64Coin(lotDenom, 0),
+		Unsold: a.Lot,
+		Raised: sdk.NewInt64Coin(bidDenom, 0),
+	}
+
+	// sort the orders by max price, keeping orders with the same price in the order they were placed
+	sorted := make([]int, len(a.Orders))
+	for i := range sorted {
+		sorted[i] = i
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return a.Orders[sorted[i]].MaxPrice.GT(a.Orders[sorted[j]].MaxPrice)
+	})
+
+	// fill each price level in turn, sharing the lot between the orders of the level where it runs out
+	filled := make([]sdk.Int, len(a.Orders))
+	for i := range filled {
+		filled[i] = sdk.ZeroInt()
+	}
+	remaining := a.Lot.Amount
+	for start := 0; start < len(sorted) && remaining.IsPositive(); {
+		price := a.Orders[sorted[start]].MaxPrice
+		end := start
+		demand := sdk.ZeroInt()
+		for ; end < len(sorted) && a.Orders[sorted[end]].MaxPrice.Equal(price); end++ {
+			demand = demand.Add(a.Orders[sorted[end]].Amount)
+		}
+		level := sorted[start:end]
+		clearing.Price = price
+		if !remaining.LT(demand) {
+			for _, i := range level {
+				filled[i] = a.Orders[i].Amount
+			}
+			remaining = remaining.Sub(demand)
+		} else {
+			shared := sdk.ZeroInt()
+			for _, i := range level {
+				filled[i] = remaining.Mul(a.Orders[i].Amount).Quo(demand)
+				shared = shared.Add(filled[i])
+			}
+			leftover := remaining.Sub(shared)
+			for _, i := range level { // sorted by placement within the level
+				if !leftover.IsPositive() {
+					break
+				}
+				if filled[i].LT(a.Orders[i].Amount) {
+					filled[i] = filled[i].Add(sdk.OneInt())
+					leftover = leftover.Sub(sdk.OneInt())
+				}
+			}
+			remaining = sdk.ZeroInt()
+		}
+		start = end
+	}
+
+	// pay for the fills at the clearing price
+	for i, order := range a.Orders {
+		payment := sdk.NewCoin(bidDenom, sdk.NewDecFromInt(filled[i]).Mul(clearing.Price).Ceil().TruncateInt())
+		clearing.Fills[i] = BatchFill{
+			Bidder:  order.Bidder,
+			Lot:     sdk.NewCoin(lotDenom, filled[i]),
+			Payment: payment,
+			Refund:  order.escrow(bidDenom).Sub(payment),
+		}
+		clearing.Sold = clearing.Sold.Add(clearing.Fills[i].Lot)
+		clearing.Raised = clearing.Raised.Add(payment)
+	}
+	clearing.Unsold = sdk.NewCoin(lotDenom, remaining)
+	return clearing
+}
+
+// GetPayout implements Auction. Orders are paid out at the clearing price, refunding the rest of their escrow. The amount raised and any unsold lot go to the initiator.
+func (a BatchAuction) GetPayout() ([]bankOutput, []bankInput) {
+	clearing := a.Clear()
+	outputs := []bankOutput{}
+	inputs := []bankInput{}
+	for _, fill := range clearing.Fills {
+		if fill.Lot.IsPositive() {
+			inputs = append(inputs, bankInput{fill.Bidder, sdk.NewCoins(fill.Lot)})
+		}
+		if fill.Refund.IsPositive() {
+			outputs = append(outputs, bankOutput{EscrowAccountAddress, sdk.NewCoins(fill.Refund)})
+			inputs = append(inputs, bankInput{fill.Bidder, sdk.NewCoins(fill.Refund)})
+		}
+	}
+	if clearing.Raised.IsPositive() {
+		outputs = append(outputs, bankOutput{EscrowAccountAddress, sdk.NewCoins(clearing.Raised)})
+		inputs = append(inputs, bankInput{a.Initiator, sdk.NewCoins(clearing.Raised)})
+	}
+	if clearing.Unsold.IsPositive() {
+		inputs = append(inputs, bankInput{a.Initiator, sdk.NewCoins(clearing.Unsold)})
+	}
+	return outputs, inputs
+}
+
+// cancel implements Auction. Every order's escrow is refunded and the pooled lot is returned to the initiator.
+func (a BatchAuction) cancel() ([]bankOutput, []bankInput) {
+	outputs := []bankOutput{}
+	inputs := []bankInput{{a.Initiator, sdk.NewCoins(a.Lot)}}
+	for _, order := range a.Orders {
+		escrow := order.escrow(a.Bid.Denom)
+		outputs = append(outputs, bankOutput{EscrowAccountAddress, sdk.NewCoins(escrow)})
+		inputs = append(inputs, bankInput{order.Bidder, sdk.NewCoins(escrow)})
+	}
+	return outputs, inputs
+}
+
+// minNextBid returns the smallest bid that beats the current one by the minimum increment. It is always at least one unit more than the current bid.
+func minNextBid(currentBid sdk.Coin, minIncrement sdk.Dec) sdk.Coin {
+	increment := sdk.NewDecFromInt(currentBid.Amount).Mul(minIncrement).Ceil().TruncateInt()
+	return sdk.NewCoin(currentBid.Denom, currentBid.Amount.Add(sdk.MaxInt(increment, sdk.OneInt())))
+}
+
+// maxNextLot returns the largest lot that beats the current one by the minimum decrement. It is always at least one unit less than the current lot, unless the lot is zero.
+func maxNextLot(currentLot sdk.Coin, minDecrement sdk.Dec) sdk.Coin {
+	decrement := sdk.NewDecFromInt(currentLot.Amount).Mul(minDecrement).Ceil().TruncateInt()
+	return sdk.NewCoin(currentLot.Denom, sdk.MaxInt(currentLot.Amount.Sub(sdk.MaxInt(decrement, sdk.OneInt())), sdk.ZeroInt()))
+}
+
+// ProxyBid is a hidden limit a bidder sets on an auction. The keeper bids on their behalf, outbidding rivals by the minimum increment up to the limit.
+// The whole MaxBid is held in escrow while the proxy bid is active, including the part of it currently bid on the auction.
+type ProxyBid struct {
+	Bidder sdk.AccAddress `json:"bidder"`
+	MaxBid sdk.Coin       `json:"max_bid"` // most the bidder will bid, for reverse auctions this is the auction's fixed bid
+	MinLot sdk.Coin       `json:"min_lot"` // smallest lot the bidder will accept when bidding down the lot (reverse auctions and the reverse phase of forward reverse auctions)
+}
+
+// ProxyBids is a slice of proxy bids, in the order they were placed
+type ProxyBids []ProxyBid
+
+// find returns the index of a bidder's proxy bid
+func (ps ProxyBids) find(bidder sdk.AccAddress) (int, bool) {
+	for i, p := range ps {
+		if p.Bidder.Equals(bidder) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// holdsInEscrow returns whether coins moving to or from an address are part of a proxy bid, and so should stay in escrow.
+func (ps ProxyBids) holdsInEscrow(address sdk.AccAddress, coins sdk.Coins) bool {
+	i, found := ps.find(address)
+	return found && len(coins) == 1 && coins[0].Denom == ps[i].MaxBid.Denom
+}
+
+// strongest returns the proxy bid that would win if they all bid up to their limits, choosing the one placed first out of any that can't outbid each other.
+func (ps ProxyBids) strongest(auction Auction, params AuctionParams) (ProxyBid, bool) {
+	if len(ps) == 0 {
+		return ProxyBid{}, false
+	}
+	strongest := ps[0]
+	for _, p := range ps[1:] {
+		if p.beats(strongest, auction, params) && !strongest.beats(p, auction, params) {
+			strongest = p
+		}
+	}
+	return strongest, true
+}
+
+// canAfford returns whether a bid is within the proxy bid's limit. The lot is only checked for bids that bid down the lot.
+func (p ProxyBid) canAfford(bid sdk.Coin, lot sdk.Coin, lotBid bool) bool {
+	if p.MaxBid.Denom != bid.Denom || p.MaxBid.IsLT(bid) {
+		return false
+	}
+	return !lotBid || (p.MinLot.Denom == lot.Denom && !lot.IsLT(p.MinLot))
+}
+
+// beats returns whether another proxy bid can't outbid this one once it has bid up to its limit.
+func (p ProxyBid) beats(other ProxyBid, auction Auction, params AuctionParams) bool {
+	limit, _, _ := proxyBidLimit(auction, p)
+	bid, lot, lotBid, ok := nextProxyBid(limit, params)
+	return !ok || !other.canAfford(bid, lot, lotBid)
+}
+
+// supportsProxyBids returns whether proxy bids can be placed on an auction. Dutch and sealed bid auctions don't have bids for a proxy to outbid.
+func supportsProxyBids(auction Auction) bool {
+	switch auction.(type) {
+	case *ForwardAuction, *BasketAuction, *ReverseAuction, *ForwardReverseAuction:
+		return true
+	default:
+		return false
+	}
+}
+
+// currentBidAndLot returns the current bid and lot of an auction that supports proxy bids. Basket auction lots are several coins, so their lot is left empty.
+func currentBidAndLot(auction Auction) (sdk.Coin, sdk.Coin) {
+	switch a := auction.(type) {
+	case *ForwardAuction:
+		return a.Bid, a.Lot
+	case *BasketAuction:
+		return a.Bid, sdk.Coin{}
+	case *ReverseAuction:
+		return a.Bid, a.Lot
+	case *ForwardReverseAuction:
+		return a.Bid, a.Lot
+	default:
+		return sdk.Coin{}, sdk.Coin{}
+	}
+}
+
+// nextProxyBid returns the smallest bid that beats the current bid on an auction that supports proxy bids, using the same rules as PlaceBid.
+// lotBid is true if the bid bids down the lot. ok is false if the auction can't be outbid any further (eg its lot is zero).
+func nextProxyBid(auction Auction, params AuctionParams) (bid sdk.Coin, lot sdk.Coin, lotBid bool, ok bool) {
+	switch a := auction.(type) {
+	case *ForwardAuction:
+		return minForwardBid(a.BaseAuction, params), a.Lot, false, true
+	case *BasketAuction:
+		return minForwardBid(BaseAuction{Initiator: a.Initiator, Bidder: a.Bidder, Bid: a.Bid}, params), sdk.Coin{}, false, true
+	case *ReverseAuction:
+		if a.Bidder.Equals(a.Initiator) {
+			return a.Bid, a.Lot, true, true // the first bid only needs to reach the reserve price
+		}
+		lot := maxNextLot(a.Lot, params.MinLotDecrement)
+		return a.Bid, lot, true, lot.IsLT(a.Lot)
+	case *ForwardReverseAuction:
+		if a.Bid.IsLT(a.MaxBid) {
+			bid := minForwardBid(a.BaseAuction, params)
+			if a.MaxBid.IsLT(bid) {
+				bid = a.MaxBid // a bid of MaxBid is always big enough
+			}
+			return bid, a.Lot, false, true
+		}
+		lot := maxNextLot(a.Lot, params.MinLotDecrement)
+		return a.Bid, lot, true, lot.IsLT(a.Lot)
+	default:
+		return sdk.Coin{}, sdk.Coin{}, false, false
+	}
+}
+
+// minForwardBid returns the smallest bid in a forward auction, which is the reserve price if there are no bids yet.
+func minForwardBid(a BaseAuction, params AuctionParams) sdk.Coin {
+	if a.Bidder.Equals(a.Initiator) && a.Bid.IsPositive() {
+		return a.Bid
+	}
+	return minNextBid(a.Bid, params.MinBidIncrement)
+}
+
+// proxyBidLimit returns the bid and lot a proxy bid places when bidding up to its limit, along with a copy of the auction as it would be after that bid.
+func proxyBidLimit(auction Auction, p ProxyBid) (Auction, sdk.Coin, sdk.Coin) {
+	switch a := auction.(type) {
+	case *ForwardAuction:
+		limit := *a
+		limit.Bidder, limit.Bid = p.Bidder, p.MaxBid
+		return &limit, p.MaxBid, a.Lot
+	case *BasketAuction:
+		limit := *a
+		limit.Bidder, limit.Bid = p.Bidder, p.MaxBid
+		return &limit, p.MaxBid, sdk.Coin{}
+	case *ReverseAuction:
+		limit := *a
+		limit.Bidder, limit.Lot = p.Bidder, p.lotLimit(a.Lot)
+		return &limit, a.Bid, limit.Lot
+	case *ForwardReverseAuction:
+		limit := *a
+		limit.Bidder = p.Bidder
+		if p.MaxBid.IsLT(a.MaxBid) {
+			limit.Bid = p.MaxBid
+			return &limit, p.MaxBid, a.Lot
+		}
+		limit.Bid, limit.Lot = a.MaxBid, p.lotLimit(a.Lot)
+		return &limit, a.MaxBid, limit.Lot
+	default:
+		return auction, sdk.Coin{}, sdk.Coin{}
+	}
+}
+
+// lotLimit returns the smallest lot the proxy bid will bid down to from the current lot.
+func (p ProxyBid) lotLimit(currentLot sdk.Coin) sdk.Coin {
+	if p.MinLot.Denom == currentLot.Denom && p.MinLot.IsLT(currentLot) {
+		return p.MinLot
+	}
+	return currentLot
+}
+
+// Auctions is a slice of auctions
+type Auctions []Auction
+
+// implement fmt.Stringer
+func (as Auctions) String() string {
+	out := ""
+	for _, a := range as {
+		out += a.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+// Phases of a forward reverse auction, recorded in the bid history
+const (
+	ForwardPhase = "forward"
+	ReversePhase = "reverse"
+)
+
+// BidRecord is an entry in the bid history of an auction, recording a bid as it was placed.
+type BidRecord struct {
+	AuctionID ID             `json:"auction_id"`
+	Bidder    sdk.AccAddress `json:"bidder"`
+	Bid       sdk.Coin       `json:"bid"`
+	Lot       sdk.Coins      `json:"lot"`
+	Height    int64          `json:"height"`
+	Time      time.Time      `json:"time"`
+	Phase     string         `json:"phase"` // phase of the auction the bid was placed in, empty for auction types without phases
+	Proxy     bool           `json:"proxy"` // whether the bid was placed automatically by a proxy bid
+}
+
+func (b BidRecord) String() string {
+	phase := ""
+	if b.Phase != "" {
+		phase = fmt.Sprintf(" (%s phase)", b.Phase)
+	}
+	if b.Proxy {
+		phase += " (proxy)"
+	}
+	return fmt.Sprintf("Auction %d: %s bid %s for %s at height %d, %s%s", b.AuctionID, b.Bidder, b.Bid, b.Lot, b.Height, b.Time, phase)
+}
+
+// BidRecords is a slice of bid records
+type BidRecords []BidRecord
+
+// implement fmt.Stringer
+func (bs BidRecords) String() string {
+	out := ""
+	for _, b := range bs {
+		out += b.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+// bidPhase returns the phase an auction is in when a bid is placed, for auction types that have phases.
+func bidPhase(auction Auction) string {
+	switch a := auction.(type) {
+	case *ForwardReverseAuction:
+		if a.Bid.IsLT(a.MaxBid) {
+			return ForwardPhase
+		}
+		return ReversePhase
+	case *SealedBidAuction:
+		return a.Phase
+	default:
+		return ""
+	}
+}
+
+// FailedAuction records an auction that closed without any bids, and what happened to its lot.
+type FailedAuction struct {
+	Auction  Auction   `json:"auction"`  // the auction as it was when it closed, with the backstop as the bidder if the backstop bought the lot
+	Outcome  string    `json:"outcome"`  // ReturnOutcome or BackstopOutcome, restarts are counted in Restarts
+	Restarts int       `json:"restarts"` // number of times the auction restarted before it failed
+	Height   int64     `json:"height"`
+	Time     time.Time `json:"time"`
+}
+
+func (f FailedAuction) String() string {
+	return fmt.Sprintf("Auction %d (%s) failed at height %d, %s after %d restarts, outcome: %s", f.Auction.GetID(), f.Auction.GetType(), f.Height, f.Time, f.Restarts, f.Outcome)
+}
+
+// FailedAuctions is a slice of failed auctions
+type FailedAuctions []FailedAuction
+
+// implement fmt.Stringer
+func (fs FailedAuctions) String() string {
+	out := ""
+	for _, f := range fs {
+		out += f.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
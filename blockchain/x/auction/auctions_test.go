@@ -2,6 +2,7 @@ package auction
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
@@ -13,14 +14,14 @@ func TestForwardAuction_PlaceBid(t *testing.T) {
 	seller := sdk.AccAddress([]byte("a_seller"))
 	buyer1 := sdk.AccAddress([]byte("buyer1"))
 	buyer2 := sdk.AccAddress([]byte("buyer2"))
-	end := endTime(10000)
-	now := endTime(10)
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := now.Add(DefaultMaxAuctionDuration)
 
 	type args struct {
-		currentBlockHeight endTime
-		bidder             sdk.AccAddress
-		lot                sdk.Coin
-		bid                sdk.Coin
+		currentTime time.Time
+		bidder      sdk.AccAddress
+		lot         sdk.Coin
+		bid         sdk.Coin
 	}
 	tests := []struct {
 		name            string
@@ -28,7 +29,7 @@ func TestForwardAuction_PlaceBid(t *testing.T) {
 		args            args
 		expectedOutputs []bankOutput
 		expectedInputs  []bankInput
-		expectedEndTime endTime
+		expectedEndTime time.Time
 		expectedBidder  sdk.AccAddress
 		expectedBid     sdk.Coin
 		expectpass      bool
@@ -44,13 +45,103 @@ func TestForwardAuction_PlaceBid(t *testing.T) {
 				MaxEndTime: end,
 			}},
 			args{now, buyer2, c("usdx", 100), c("kava", 10)},
-			[]bankOutput{{buyer2, c("kava", 10)}},
-			[]bankInput{{buyer1, c("kava", 6)}, {seller, c("kava", 4)}},
-			now + BidDuration,
+			[]bankOutput{{buyer2, cs(c("kava", 10))}, {EscrowAccountAddress, cs(c("kava", 6))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("kava", 10))}, {buyer1, cs(c("kava", 6))}},
+			now.Add(DefaultBidDuration),
 			buyer2,
 			c("kava", 10),
 			true,
 		},
+		{
+			"firstBid",
+			ForwardAuction{BaseAuction{
+				Initiator:  seller,
+				Lot:        c("usdx", 100),
+				Bidder:     seller,
+				Bid:        c("kava", 0),
+				EndTime:    end,
+				MaxEndTime: end,
+			}},
+			args{now, buyer1, c("usdx", 100), c("kava", 10)},
+			[]bankOutput{{buyer1, cs(c("kava", 10))}}, // the starting bid is not refunded
+			[]bankInput{{EscrowAccountAddress, cs(c("kava", 10))}},
+			now.Add(DefaultBidDuration),
+			buyer1,
+			c("kava", 10),
+			true,
+		},
+		{
+			"firstBidAtReservePrice",
+			ForwardAuction{BaseAuction{
+				Initiator:  seller,
+				Lot:        c("usdx", 100),
+				Bidder:     seller,
+				Bid:        c("kava", 10),
+				EndTime:    end,
+				MaxEndTime: end,
+			}},
+			args{now, buyer1, c("usdx", 100), c("kava", 10)},
+			[]bankOutput{{buyer1, cs(c("kava", 10))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("kava", 10))}},
+			now.Add(DefaultBidDuration),
+			buyer1,
+			c("kava", 10),
+			true,
+		},
+		{
+			"firstBidBelowReservePrice",
+			ForwardAuction{BaseAuction{
+				Initiator:  seller,
+				Lot:        c("usdx", 100),
+				Bidder:     seller,
+				Bid:        c("kava", 10),
+				EndTime:    end,
+				MaxEndTime: end,
+			}},
+			args{now, buyer1, c("usdx", 100), c("kava", 9)},
+			[]bankOutput{},
+			[]bankInput{},
+			end,
+			seller,
+			c("kava", 10),
+			false,
+		},
+		{
+			"sameBidderRaisesBid",
+			ForwardAuction{BaseAuction{
+				Initiator:  seller,
+				Lot:        c("usdx", 100),
+				Bidder:     buyer1,
+				Bid:        c("kava", 6),
+				EndTime:    end,
+				MaxEndTime: end,
+			}},
+			args{now, buyer1, c("usdx", 100), c("kava", 10)},
+			[]bankOutput{{buyer1, cs(c("kava", 4))}}, // only the difference is paid
+			[]bankInput{{EscrowAccountAddress, cs(c("kava", 4))}},
+			now.Add(DefaultBidDuration),
+			buyer1,
+			c("kava", 10),
+			true,
+		},
+		{
+			"initiatorBid",
+			ForwardAuction{BaseAuction{
+				Initiator:  seller,
+				Lot:        c("usdx", 100),
+				Bidder:     buyer1,
+				Bid:        c("kava", 6),
+				EndTime:    end,
+				MaxEndTime: end,
+			}},
+			args{now, seller, c("usdx", 100), c("kava", 10)},
+			[]bankOutput{},
+			[]bankInput{},
+			end,
+			buyer1,
+			c("kava", 6),
+			false,
+		},
 		{
 			"lowBid",
 			ForwardAuction{BaseAuction{
@@ -97,7 +188,7 @@ func TestForwardAuction_PlaceBid(t *testing.T) {
 				EndTime:    end,
 				MaxEndTime: end,
 			}},
-			args{end + 1, buyer2, c("usdx", 100), c("kava", 10)},
+			args{end.Add(time.Second), buyer2, c("usdx", 100), c("kava", 10)},
 			[]bankOutput{},
 			[]bankInput{},
 			end,
@@ -105,6 +196,42 @@ func TestForwardAuction_PlaceBid(t *testing.T) {
 			c("kava", 6),
 			false,
 		},
+		{
+			"belowMinIncrement",
+			ForwardAuction{BaseAuction{
+				Initiator:  seller,
+				Lot:        c("usdx", 100),
+				Bidder:     buyer1,
+				Bid:        c("kava", 100),
+				EndTime:    end,
+				MaxEndTime: end,
+			}},
+			args{now, buyer2, c("usdx", 100), c("kava", 102)},
+			[]bankOutput{},
+			[]bankInput{},
+			end,
+			buyer1,
+			c("kava", 100),
+			false,
+		},
+		{
+			"atMinIncrement",
+			ForwardAuction{BaseAuction{
+				Initiator:  seller,
+				Lot:        c("usdx", 100),
+				Bidder:     buyer1,
+				Bid:        c("kava", 100),
+				EndTime:    end,
+				MaxEndTime: end,
+			}},
+			args{now, buyer2, c("usdx", 100), c("kava", 103)},
+			[]bankOutput{{buyer2, cs(c("kava", 103))}, {EscrowAccountAddress, cs(c("kava", 100))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("kava", 103))}, {buyer1, cs(c("kava", 100))}},
+			now.Add(DefaultBidDuration),
+			buyer2,
+			c("kava", 103),
+			true,
+		},
 		{
 			"hitMaxEndTime",
 			ForwardAuction{BaseAuction{
@@ -115,9 +242,9 @@ func TestForwardAuction_PlaceBid(t *testing.T) {
 				EndTime:    end,
 				MaxEndTime: end,
 			}},
-			args{end - 1, buyer2, c("usdx", 100), c("kava", 10)},
-			[]bankOutput{{buyer2, c("kava", 10)}},
-			[]bankInput{{buyer1, c("kava", 6)}, {seller, c("kava", 4)}},
+			args{end.Add(-time.Second), buyer2, c("usdx", 100), c("kava", 10)},
+			[]bankOutput{{buyer2, cs(c("kava", 10))}, {EscrowAccountAddress, cs(c("kava", 6))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("kava", 10))}, {buyer1, cs(c("kava", 6))}},
 			end, // end time should be capped at MaxEndTime
 			buyer2,
 			c("kava", 10),
@@ -127,7 +254,7 @@ func TestForwardAuction_PlaceBid(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// update auction and return in/outputs
-			outputs, inputs, err := tc.auction.PlaceBid(tc.args.currentBlockHeight, tc.args.bidder, tc.args.lot, tc.args.bid)
+			outputs, inputs, err := tc.auction.PlaceBid(tc.args.currentTime, tc.args.bidder, tc.args.lot, tc.args.bid, DefaultAuctionParams()) // bids must move by at least 3%
 
 			// check for err
 			if tc.expectpass {
@@ -146,18 +273,112 @@ func TestForwardAuction_PlaceBid(t *testing.T) {
 	}
 }
 
+func TestBasketAuction_PlaceBid(t *testing.T) {
+	seller := sdk.AccAddress([]byte("a_seller"))
+	buyer1 := sdk.AccAddress([]byte("buyer1"))
+	buyer2 := sdk.AccAddress([]byte("buyer2"))
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := now.Add(DefaultMaxAuctionDuration)
+	lot := cs(c("btc", 1), c("xrp", 500))
+
+	tests := []struct {
+		name            string
+		auction         BasketAuction
+		bidder          sdk.AccAddress
+		bid             sdk.Coin
+		expectedOutputs []bankOutput
+		expectedInputs  []bankInput
+		expectedBidder  sdk.AccAddress
+		expectpass      bool
+	}{
+		{
+			"firstBid",
+			BasketAuction{Initiator: seller, Lot: lot, Bidder: seller, Bid: c("usdx", 10), EndTime: end, MaxEndTime: end},
+			buyer1,
+			c("usdx", 10),
+			[]bankOutput{{buyer1, cs(c("usdx", 10))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("usdx", 10))}},
+			buyer1,
+			true,
+		},
+		{
+			"normal",
+			BasketAuction{Initiator: seller, Lot: lot, Bidder: buyer1, Bid: c("usdx", 100), EndTime: end, MaxEndTime: end},
+			buyer2,
+			c("usdx", 103),
+			[]bankOutput{{buyer2, cs(c("usdx", 103))}, {EscrowAccountAddress, cs(c("usdx", 100))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("usdx", 103))}, {buyer1, cs(c("usdx", 100))}},
+			buyer2,
+			true,
+		},
+		{
+			"belowMinIncrement",
+			BasketAuction{Initiator: seller, Lot: lot, Bidder: buyer1, Bid: c("usdx", 100), EndTime: end, MaxEndTime: end},
+			buyer2,
+			c("usdx", 102),
+			[]bankOutput{},
+			[]bankInput{},
+			buyer1,
+			false,
+		},
+		{
+			"wrongDenom",
+			BasketAuction{Initiator: seller, Lot: lot, Bidder: buyer1, Bid: c("usdx", 100), EndTime: end, MaxEndTime: end},
+			buyer2,
+			c("kava", 200),
+			[]bankOutput{},
+			[]bankInput{},
+			buyer1,
+			false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			outputs, inputs, err := tc.auction.PlaceBid(now, tc.bidder, c("btc", 1), tc.bid, DefaultAuctionParams())
+
+			if tc.expectpass {
+				require.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+			}
+			require.Equal(t, tc.expectedOutputs, outputs)
+			require.Equal(t, tc.expectedInputs, inputs)
+			require.Equal(t, tc.expectedBidder, tc.auction.Bidder)
+			require.Equal(t, lot, tc.auction.Lot)
+		})
+	}
+}
+
+func TestBasketAuction_GetPayout(t *testing.T) {
+	seller := sdk.AccAddress([]byte("a_seller"))
+	buyer := sdk.AccAddress([]byte("buyer1"))
+	lot := cs(c("btc", 1), c("xrp", 500))
+
+	// the whole lot goes to the winning bidder
+	auction := BasketAuction{Initiator: seller, Lot: lot, Bidder: buyer, Bid: c("usdx", 100)}
+	outputs, inputs := auction.GetPayout()
+	require.Equal(t, []bankOutput{{EscrowAccountAddress, cs(c("usdx", 100))}}, outputs)
+	require.Equal(t, []bankInput{{buyer, lot}, {seller, cs(c("usdx", 100))}}, inputs)
+
+	// or back to the seller if there were no bids
+	auction = BasketAuction{Initiator: seller, Lot: lot, Bidder: seller, Bid: c("usdx", 100)}
+	outputs, inputs = auction.GetPayout()
+	require.Equal(t, []bankOutput{}, outputs)
+	require.Equal(t, []bankInput{{seller, lot}}, inputs)
+}
+
 func TestReverseAuction_PlaceBid(t *testing.T) {
 	buyer := sdk.AccAddress([]byte("a_buyer"))
 	seller1 := sdk.AccAddress([]byte("seller1"))
 	seller2 := sdk.AccAddress([]byte("seller2"))
-	end := endTime(10000)
-	now := endTime(10)
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := now.Add(DefaultMaxAuctionDuration)
 
 	type args struct {
-		currentBlockHeight endTime
-		bidder             sdk.AccAddress
-		lot                sdk.Coin
-		bid                sdk.Coin
+		currentTime time.Time
+		bidder      sdk.AccAddress
+		lot         sdk.Coin
+		bid         sdk.Coin
 	}
 	tests := []struct {
 		name            string
@@ -165,7 +386,7 @@ func TestReverseAuction_PlaceBid(t *testing.T) {
 		args            args
 		expectedOutputs []bankOutput
 		expectedInputs  []bankInput
-		expectedEndTime endTime
+		expectedEndTime time.Time
 		expectedBidder  sdk.AccAddress
 		expectedLot     sdk.Coin
 		expectpass      bool
@@ -181,13 +402,67 @@ func TestReverseAuction_PlaceBid(t *testing.T) {
 				MaxEndTime: end,
 			}},
 			args{now, seller2, c("kava", 9), c("usdx", 100)},
-			[]bankOutput{{seller2, c("usdx", 100)}},
-			[]bankInput{{seller1, c("usdx", 100)}, {buyer, c("kava", 1)}},
-			now + BidDuration,
+			[]bankOutput{{seller2, cs(c("usdx", 100))}, {EscrowAccountAddress, cs(c("usdx", 100))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("usdx", 100))}, {seller1, cs(c("usdx", 100))}, {buyer, cs(c("kava", 1))}},
+			now.Add(DefaultBidDuration),
 			seller2,
 			c("kava", 9),
 			true,
 		},
+		{
+			"firstBidAtReservePrice",
+			ReverseAuction{BaseAuction{
+				Initiator:  buyer,
+				Lot:        c("kava", 10),
+				Bidder:     buyer,
+				Bid:        c("usdx", 100),
+				EndTime:    end,
+				MaxEndTime: end,
+			}},
+			args{now, seller1, c("kava", 10), c("usdx", 100)},
+			[]bankOutput{{seller1, cs(c("usdx", 100))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("usdx", 100))}},
+			now.Add(DefaultBidDuration),
+			seller1,
+			c("kava", 10),
+			true,
+		},
+		{
+			"firstBidAboveReservePrice",
+			ReverseAuction{BaseAuction{
+				Initiator:  buyer,
+				Lot:        c("kava", 10),
+				Bidder:     buyer,
+				Bid:        c("usdx", 100),
+				EndTime:    end,
+				MaxEndTime: end,
+			}},
+			args{now, seller1, c("kava", 11), c("usdx", 100)},
+			[]bankOutput{},
+			[]bankInput{},
+			end,
+			buyer,
+			c("kava", 10),
+			false,
+		},
+		{
+			"sameBidderLowersLot",
+			ReverseAuction{BaseAuction{
+				Initiator:  buyer,
+				Lot:        c("kava", 10),
+				Bidder:     seller1,
+				Bid:        c("usdx", 100),
+				EndTime:    end,
+				MaxEndTime: end,
+			}},
+			args{now, seller1, c("kava", 9), c("usdx", 100)},
+			[]bankOutput{}, // bid is already in escrow
+			[]bankInput{{buyer, cs(c("kava", 1))}},
+			now.Add(DefaultBidDuration),
+			seller1,
+			c("kava", 9),
+			true,
+		},
 		{
 			"highBid",
 			ReverseAuction{BaseAuction{
@@ -234,7 +509,7 @@ func TestReverseAuction_PlaceBid(t *testing.T) {
 				EndTime:    end,
 				MaxEndTime: end,
 			}},
-			args{end + 1, seller2, c("kava", 9), c("usdx", 100)},
+			args{end.Add(time.Second), seller2, c("kava", 9), c("usdx", 100)},
 			[]bankOutput{},
 			[]bankInput{},
 			end,
@@ -242,6 +517,42 @@ func TestReverseAuction_PlaceBid(t *testing.T) {
 			c("kava", 10),
 			false,
 		},
+		{
+			"aboveMinDecrement",
+			ReverseAuction{BaseAuction{
+				Initiator:  buyer,
+				Lot:        c("kava", 100),
+				Bidder:     seller1,
+				Bid:        c("usdx", 100),
+				EndTime:    end,
+				MaxEndTime: end,
+			}},
+			args{now, seller2, c("kava", 98), c("usdx", 100)},
+			[]bankOutput{},
+			[]bankInput{},
+			end,
+			seller1,
+			c("kava", 100),
+			false,
+		},
+		{
+			"atMinDecrement",
+			ReverseAuction{BaseAuction{
+				Initiator:  buyer,
+				Lot:        c("kava", 100),
+				Bidder:     seller1,
+				Bid:        c("usdx", 100),
+				EndTime:    end,
+				MaxEndTime: end,
+			}},
+			args{now, seller2, c("kava", 97), c("usdx", 100)},
+			[]bankOutput{{seller2, cs(c("usdx", 100))}, {EscrowAccountAddress, cs(c("usdx", 100))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("usdx", 100))}, {seller1, cs(c("usdx", 100))}, {buyer, cs(c("kava", 3))}},
+			now.Add(DefaultBidDuration),
+			seller2,
+			c("kava", 97),
+			true,
+		},
 		{
 			"hitMaxEndTime",
 			ReverseAuction{BaseAuction{
@@ -252,9 +563,9 @@ func TestReverseAuction_PlaceBid(t *testing.T) {
 				EndTime:    end,
 				MaxEndTime: end,
 			}},
-			args{end - 1, seller2, c("kava", 9), c("usdx", 100)},
-			[]bankOutput{{seller2, c("usdx", 100)}},
-			[]bankInput{{seller1, c("usdx", 100)}, {buyer, c("kava", 1)}},
+			args{end.Add(-time.Second), seller2, c("kava", 9), c("usdx", 100)},
+			[]bankOutput{{seller2, cs(c("usdx", 100))}, {EscrowAccountAddress, cs(c("usdx", 100))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("usdx", 100))}, {seller1, cs(c("usdx", 100))}, {buyer, cs(c("kava", 1))}},
 			end, // end time should be capped at MaxEndTime
 			seller2,
 			c("kava", 9),
@@ -264,7 +575,7 @@ func TestReverseAuction_PlaceBid(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			// update auction and return in/outputs
-			outputs, inputs, err := tc.auction.PlaceBid(tc.args.currentBlockHeight, tc.args.bidder, tc.args.lot, tc.args.bid)
+			outputs, inputs, err := tc.auction.PlaceBid(tc.args.currentTime, tc.args.bidder, tc.args.lot, tc.args.bid, DefaultAuctionParams()) // bids must move by at least 3%
 
 			// check for err
 			if tc.expectpass {
@@ -288,14 +599,14 @@ func TestForwardReverseAuction_PlaceBid(t *testing.T) {
 	seller := sdk.AccAddress([]byte("a_seller"))
 	buyer1 := sdk.AccAddress([]byte("buyer1"))
 	buyer2 := sdk.AccAddress([]byte("buyer2"))
-	end := endTime(10000)
-	now := endTime(10)
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := now.Add(DefaultMaxAuctionDuration)
 
 	type args struct {
-		currentBlockHeight endTime
-		bidder             sdk.AccAddress
-		lot                sdk.Coin
-		bid                sdk.Coin
+		currentTime time.Time
+		bidder      sdk.AccAddress
+		lot         sdk.Coin
+		bid         sdk.Coin
 	}
 	tests := []struct {
 		name            string
@@ -303,12 +614,54 @@ func TestForwardReverseAuction_PlaceBid(t *testing.T) {
 		args            args
 		expectedOutputs []bankOutput
 		expectedInputs  []bankInput
-		expectedEndTime endTime
+		expectedEndTime time.Time
 		expectedBidder  sdk.AccAddress
 		expectedLot     sdk.Coin
 		expectedBid     sdk.Coin
 		expectpass      bool
 	}{
+		{
+			"firstBidAtReservePrice",
+			ForwardReverseAuction{BaseAuction: BaseAuction{
+				Initiator:  seller,
+				Lot:        c("xrp", 100),
+				Bidder:     seller,
+				Bid:        c("usdx", 5),
+				EndTime:    end,
+				MaxEndTime: end},
+				MaxBid:      c("usdx", 10),
+				OtherPerson: cdpOwner,
+			},
+			args{now, buyer1, c("xrp", 100), c("usdx", 5)},
+			[]bankOutput{{buyer1, cs(c("usdx", 5))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("usdx", 5))}},
+			now.Add(DefaultBidDuration),
+			buyer1,
+			c("xrp", 100),
+			c("usdx", 5),
+			true,
+		},
+		{
+			"firstBidBelowReservePrice",
+			ForwardReverseAuction{BaseAuction: BaseAuction{
+				Initiator:  seller,
+				Lot:        c("xrp", 100),
+				Bidder:     seller,
+				Bid:        c("usdx", 5),
+				EndTime:    end,
+				MaxEndTime: end},
+				MaxBid:      c("usdx", 10),
+				OtherPerson: cdpOwner,
+			},
+			args{now, buyer1, c("xrp", 100), c("usdx", 4)},
+			[]bankOutput{},
+			[]bankInput{},
+			end,
+			seller,
+			c("xrp", 100),
+			c("usdx", 5),
+			false,
+		},
 		{
 			"normalForwardBid",
 			ForwardReverseAuction{BaseAuction: BaseAuction{
@@ -322,9 +675,9 @@ func TestForwardReverseAuction_PlaceBid(t *testing.T) {
 				OtherPerson: cdpOwner,
 			},
 			args{now, buyer2, c("xrp", 100), c("usdx", 6)},
-			[]bankOutput{{buyer2, c("usdx", 6)}},
-			[]bankInput{{buyer1, c("usdx", 5)}, {seller, c("usdx", 1)}},
-			now + BidDuration,
+			[]bankOutput{{buyer2, cs(c("usdx", 6))}, {EscrowAccountAddress, cs(c("usdx", 5))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("usdx", 6))}, {buyer1, cs(c("usdx", 5))}},
+			now.Add(DefaultBidDuration),
 			buyer2,
 			c("xrp", 100),
 			c("usdx", 6),
@@ -343,9 +696,9 @@ func TestForwardReverseAuction_PlaceBid(t *testing.T) {
 				OtherPerson: cdpOwner,
 			},
 			args{now, buyer2, c("xrp", 99), c("usdx", 10)},
-			[]bankOutput{{buyer2, c("usdx", 10)}},
-			[]bankInput{{buyer1, c("usdx", 5)}, {seller, c("usdx", 5)}, {cdpOwner, c("xrp", 1)}},
-			now + BidDuration,
+			[]bankOutput{{buyer2, cs(c("usdx", 10))}, {EscrowAccountAddress, cs(c("usdx", 5))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("usdx", 10))}, {buyer1, cs(c("usdx", 5))}, {cdpOwner, cs(c("xrp", 1))}},
+			now.Add(DefaultBidDuration),
 			buyer2,
 			c("xrp", 99),
 			c("usdx", 10),
@@ -364,30 +717,114 @@ func TestForwardReverseAuction_PlaceBid(t *testing.T) {
 				OtherPerson: cdpOwner,
 			},
 			args{now, buyer2, c("xrp", 90), c("usdx", 10)},
-			[]bankOutput{{buyer2, c("usdx", 10)}},
-			[]bankInput{{buyer1, c("usdx", 10)}, {cdpOwner, c("xrp", 9)}},
-			now + BidDuration,
+			[]bankOutput{{buyer2, cs(c("usdx", 10))}, {EscrowAccountAddress, cs(c("usdx", 10))}},
+			[]bankInput{{EscrowAccountAddress, cs(c("usdx", 10))}, {buyer1, cs(c("usdx", 10))}, {cdpOwner, cs(c("xrp", 9))}},
+			now.Add(DefaultBidDuration),
 			buyer2,
 			c("xrp", 90),
 			c("usdx", 10),
 			true,
 		},
-		// TODO more test cases
-	}
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// update auction and return in/outputs
-			outputs, inputs, err := tc.auction.PlaceBid(tc.args.currentBlockHeight, tc.args.bidder, tc.args.lot, tc.args.bid)
-
-			// check for err
-			if tc.expectpass {
-				require.Nil(t, err)
-			} else {
-				require.NotNil(t, err)
-			}
-			// check for correct in/outputs
-			require.Equal(t, tc.expectedOutputs, outputs)
-			require.Equal(t, tc.expectedInputs, inputs)
+		{
+			"forwardBelowMinIncrement",
+			ForwardReverseAuction{BaseAuction: BaseAuction{
+				Initiator:  seller,
+				Lot:        c("xrp", 100),
+				Bidder:     buyer1,
+				Bid:        c("usdx", 50),
+				EndTime:    end,
+				MaxEndTime: end},
+				MaxBid:      c("usdx", 100),
+				OtherPerson: cdpOwner,
+			},
+			args{now, buyer2, c("xrp", 100), c("usdx", 51)},
+			[]bankOutput{},
+			[]bankInput{},
+			end,
+			buyer1,
+			c("xrp", 100),
+			c("usdx", 50),
+			false,
+		},
+		{
+			"forwardMinIncrementCappedAtMaxBid",
+			ForwardReverseAuction{BaseAuction: BaseAuction{
+				Initiator:  seller,
+				Lot:        c("xrp", 100),
+				Bidder:     buyer1,
+				Bid:        c("usdx", 98),
+				EndTime:    end,
+				MaxEndTime: end},
+				MaxBid:      c("usdx", 100),
+				OtherPerson: cdpOwner,
+			},
+			args{now, buyer2, c("xrp", 100), c("usdx", 99)}, // min increment would be 101, so only a bid of MaxBid is accepted
+			[]bankOutput{},
+			[]bankInput{},
+			end,
+			buyer1,
+			c("xrp", 100),
+			c("usdx", 98),
+			false,
+		},
+		{
+			"switchOverLargerLot",
+			ForwardReverseAuction{BaseAuction: BaseAuction{
+				Initiator:  seller,
+				Lot:        c("xrp", 100),
+				Bidder:     buyer1,
+				Bid:        c("usdx", 5),
+				EndTime:    end,
+				MaxEndTime: end},
+				MaxBid:      c("usdx", 10),
+				OtherPerson: cdpOwner,
+			},
+			args{now, buyer2, c("xrp", 101), c("usdx", 10)},
+			[]bankOutput{},
+			[]bankInput{},
+			end,
+			buyer1,
+			c("xrp", 100),
+			c("usdx", 5),
+			false,
+		},
+		{
+			"reverseAboveMinDecrement",
+			ForwardReverseAuction{BaseAuction: BaseAuction{
+				Initiator:  seller,
+				Lot:        c("xrp", 99),
+				Bidder:     buyer1,
+				Bid:        c("usdx", 10),
+				EndTime:    end,
+				MaxEndTime: end},
+				MaxBid:      c("usdx", 10),
+				OtherPerson: cdpOwner,
+			},
+			args{now, buyer2, c("xrp", 97), c("usdx", 10)}, // max lot is 96
+			[]bankOutput{},
+			[]bankInput{},
+			end,
+			buyer1,
+			c("xrp", 99),
+			c("usdx", 10),
+			false,
+		},
+		// TODO more test cases
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// update auction and return in/outputs
+			outputs, inputs, err := tc.auction.PlaceBid(tc.args.currentTime, tc.args.bidder, tc.args.lot, tc.args.bid, DefaultAuctionParams()) // bids must move by at least 3%
+
+			// check for err
+			if tc.expectpass {
+				require.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+			}
+			// check for correct in/outputs
+			require.Equal(t, tc.expectedOutputs, outputs)
+			require.Equal(t, tc.expectedInputs, inputs)
 			// check for correct endTime, bidder, bid
 			require.Equal(t, tc.expectedEndTime, tc.auction.EndTime)
 			require.Equal(t, tc.expectedBidder, tc.auction.Bidder)
@@ -397,7 +834,574 @@ func TestForwardReverseAuction_PlaceBid(t *testing.T) {
 	}
 }
 
+func TestForwardReverseAuction_split(t *testing.T) {
+	cdpOwner := sdk.AccAddress([]byte("a_cdp_owner"))
+	seller := sdk.AccAddress([]byte("a_seller"))
+	buyer := sdk.AccAddress([]byte("buyer"))
+	end := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC).Add(DefaultMaxAuctionDuration)
+	auction := func(lot, bid, maxBid sdk.Coin) ForwardReverseAuction {
+		return ForwardReverseAuction{BaseAuction: BaseAuction{
+			Initiator:  seller,
+			Lot:        lot,
+			Bidder:     buyer,
+			Bid:        bid,
+			EndTime:    end,
+			MaxEndTime: end},
+			MaxBid:      maxBid,
+			OtherPerson: cdpOwner,
+		}
+	}
+	tests := []struct {
+		name           string
+		auction        ForwardReverseAuction
+		lot            sdk.Coin
+		expectedParent ForwardReverseAuction
+		expectedChild  ForwardReverseAuction
+		expectpass     bool
+	}{
+		{
+			"normal",
+			auction(c("xrp", 20), c("usdx", 10), c("usdx", 50)),
+			c("xrp", 5),
+			auction(c("xrp", 15), c("usdx", 8), c("usdx", 38)),
+			auction(c("xrp", 5), c("usdx", 2), c("usdx", 12)),
+			true,
+		},
+		{
+			"noBids",
+			auction(c("xrp", 20), c("usdx", 0), c("usdx", 50)),
+			c("xrp", 10),
+			auction(c("xrp", 10), c("usdx", 0), c("usdx", 25)),
+			auction(c("xrp", 10), c("usdx", 0), c("usdx", 25)),
+			true,
+		},
+		{
+			"reversePhase",
+			auction(c("xrp", 20), c("usdx", 50), c("usdx", 50)),
+			c("xrp", 5),
+			auction(c("xrp", 20), c("usdx", 50), c("usdx", 50)),
+			ForwardReverseAuction{},
+			false,
+		},
+		{
+			"wholeLot",
+			auction(c("xrp", 20), c("usdx", 10), c("usdx", 50)),
+			c("xrp", 20),
+			auction(c("xrp", 20), c("usdx", 10), c("usdx", 50)),
+			ForwardReverseAuction{},
+			false,
+		},
+		{
+			"wrongDenom",
+			auction(c("xrp", 20), c("usdx", 10), c("usdx", 50)),
+			c("btc", 5),
+			auction(c("xrp", 20), c("usdx", 10), c("usdx", 50)),
+			ForwardReverseAuction{},
+			false,
+		},
+		{
+			"zeroMaxBidShare",
+			auction(c("xrp", 100), c("usdx", 1), c("usdx", 50)),
+			c("xrp", 1),
+			auction(c("xrp", 100), c("usdx", 1), c("usdx", 50)),
+			ForwardReverseAuction{},
+			false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			child, err := tc.auction.split(tc.lot)
+
+			if !tc.expectpass {
+				require.NotNil(t, err)
+				require.Equal(t, tc.expectedParent, tc.auction)
+				require.Equal(t, tc.expectedChild, child)
+				return
+			}
+			require.Nil(t, err)
+			// compare coins with IsEqual as zero amounts can be represented differently
+			for _, pair := range [][2]ForwardReverseAuction{{tc.expectedParent, tc.auction}, {tc.expectedChild, child}} {
+				expected, actual := pair[0], pair[1]
+				require.Equal(t, expected.Bidder, actual.Bidder)
+				require.Equal(t, expected.OtherPerson, actual.OtherPerson)
+				require.Equal(t, expected.EndTime, actual.EndTime)
+				require.True(t, expected.Lot.IsEqual(actual.Lot), "lot %s != %s", expected.Lot, actual.Lot)
+				require.True(t, expected.Bid.IsEqual(actual.Bid), "bid %s != %s", expected.Bid, actual.Bid)
+				require.True(t, expected.MaxBid.IsEqual(actual.MaxBid), "max bid %s != %s", expected.MaxBid, actual.MaxBid)
+			}
+		})
+	}
+}
+
+func TestDutchAuction_PlaceBid(t *testing.T) {
+	seller := sdk.AccAddress([]byte("a_seller"))
+	buyer := sdk.AccAddress([]byte("buyer1"))
+	cdpOwner := sdk.AccAddress([]byte("a_cdp_owner"))
+	start := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	now := start.Add(time.Hour) // price has dropped from 10 to 9
+	resetTime := start.Add(5 * time.Hour)
+
+	// price starts at 10 usdx per xrp and drops by 1 every hour, restarting once it is below 5
+	newAuction := func(lot sdk.Coin, raised sdk.Coin) DutchAuction {
+		curve := NewPriceCurve(LinearCurve, time.Hour, sdk.MustNewDecFromStr("0.1"))
+		auction, _ := NewDutchAuction(seller, lot, c("usdx", 500), cdpOwner, start, start.Add(DefaultMaxAuctionDuration), sdk.NewDec(10), sdk.NewDec(5), curve)
+		auction.Bid = raised
+		return auction
+	}
+	require.Equal(t, resetTime.Add(time.Second), newAuction(c("xrp", 100), c("usdx", 0)).EndTime)
+
+	type args struct {
+		currentTime time.Time
+		bidder      sdk.AccAddress
+		lot         sdk.Coin
+		bid         sdk.Coin
+	}
+	tests := []struct {
+		name            string
+		auction         DutchAuction
+		args            args
+		expectedOutputs []bankOutput
+		expectedInputs  []bankInput
+		expectedEndTime time.Time
+		expectedLot     sdk.Coin
+		expectedBid     sdk.Coin
+		expectpass      bool
+	}{
+		{
+			"normal",
+			newAuction(c("xrp", 100), c("usdx", 0)),
+			args{now, buyer, c("xrp", 10), c("usdx", 100)}, // willing to pay up to 100
+			[]bankOutput{{buyer, cs(c("usdx", 90))}},
+			[]bankInput{{seller, cs(c("usdx", 90))}, {buyer, cs(c("xrp", 10))}},
+			resetTime.Add(time.Second),
+			c("xrp", 90),
+			c("usdx", 90),
+			true,
+		},
+		{
+			"buyWholeLot",
+			newAuction(c("xrp", 10), c("usdx", 0)),
+			args{now, buyer, c("xrp", 10), c("usdx", 90)},
+			[]bankOutput{{buyer, cs(c("usdx", 90))}},
+			[]bankInput{{seller, cs(c("usdx", 90))}, {buyer, cs(c("xrp", 10))}},
+			now, // closes at the end of this block
+			c("xrp", 0),
+			c("usdx", 90),
+			true,
+		},
+		{
+			"maxBidReached",
+			newAuction(c("xrp", 100), c("usdx", 450)),
+			args{now, buyer, c("xrp", 10), c("usdx", 100)}, // only 50 left to raise, so only 6 xrp are sold
+			[]bankOutput{{buyer, cs(c("usdx", 50))}},
+			[]bankInput{{seller, cs(c("usdx", 50))}, {buyer, cs(c("xrp", 6))}, {cdpOwner, cs(c("xrp", 94))}},
+			now,
+			c("xrp", 0),
+			c("usdx", 500),
+			true,
+		},
+		{
+			"bidTooSmall",
+			newAuction(c("xrp", 100), c("usdx", 0)),
+			args{now, buyer, c("xrp", 10), c("usdx", 89)},
+			[]bankOutput{},
+			[]bankInput{},
+			resetTime.Add(time.Second),
+			c("xrp", 100),
+			c("usdx", 0),
+			false,
+		},
+		{
+			"lotTooLarge",
+			newAuction(c("xrp", 100), c("usdx", 0)),
+			args{now, buyer, c("xrp", 101), c("usdx", 1000)},
+			[]bankOutput{},
+			[]bankInput{},
+			resetTime.Add(time.Second),
+			c("xrp", 100),
+			c("usdx", 0),
+			false,
+		},
+		{
+			"initiatorBid",
+			newAuction(c("xrp", 100), c("usdx", 0)),
+			args{now, seller, c("xrp", 10), c("usdx", 100)},
+			[]bankOutput{},
+			[]bankInput{},
+			resetTime.Add(time.Second),
+			c("xrp", 100),
+			c("usdx", 0),
+			false,
+		},
+		{
+			"belowResetPrice",
+			newAuction(c("xrp", 100), c("usdx", 0)),
+			args{resetTime.Add(2 * time.Second), buyer, c("xrp", 10), c("usdx", 100)},
+			[]bankOutput{},
+			[]bankInput{},
+			resetTime.Add(time.Second),
+			c("xrp", 100),
+			c("usdx", 0),
+			false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// update auction and return in/outputs
+			outputs, inputs, err := tc.auction.PlaceBid(tc.args.currentTime, tc.args.bidder, tc.args.lot, tc.args.bid, DefaultAuctionParams())
+
+			// check for err
+			if tc.expectpass {
+				require.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+			}
+			// check for correct in/outputs
+			require.Equal(t, tc.expectedOutputs, outputs)
+			require.Equal(t, tc.expectedInputs, inputs)
+			// check for correct endTime, lot, bid
+			require.Equal(t, tc.expectedEndTime, tc.auction.EndTime)
+			require.True(t, tc.expectedLot.IsEqual(tc.auction.Lot), "expected lot %s, got %s", tc.expectedLot, tc.auction.Lot)
+			require.True(t, tc.expectedBid.IsEqual(tc.auction.Bid), "expected bid %s, got %s", tc.expectedBid, tc.auction.Bid)
+		})
+	}
+}
+
+func TestDutchAuction_backstop(t *testing.T) {
+	seller := sdk.AccAddress([]byte("a_seller"))
+	cdpOwner := sdk.AccAddress([]byte("a_cdp_owner"))
+	backstop := sdk.AccAddress([]byte("a_backstop"))
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	d := sdk.MustNewDecFromStr
+	curve := NewPriceCurve(LinearCurve, time.Hour, d("0.1"))
+
+	tests := []struct {
+		name            string
+		resetPrice      sdk.Dec
+		expectedOutputs []bankOutput
+		expectedInputs  []bankInput
+		expectedBid     sdk.Coin
+	}{
+		{
+			"wholeLot",
+			d("4"),
+			[]bankOutput{{backstop, cs(c("usdx", 40))}},
+			[]bankInput{{seller, cs(c("usdx", 40))}, {backstop, cs(c("xrp", 10))}},
+			c("usdx", 40),
+		},
+		{
+			"maxBidRaised",
+			d("20"),
+			[]bankOutput{{backstop, cs(c("usdx", 100))}},
+			[]bankInput{{seller, cs(c("usdx", 100))}, {backstop, cs(c("xrp", 5))}, {cdpOwner, cs(c("xrp", 5))}},
+			c("usdx", 100),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			auction, _ := NewDutchAuction(seller, c("xrp", 10), c("usdx", 100), cdpOwner, now, now.Add(DefaultMaxAuctionDuration), d("50"), tc.resetPrice, curve)
+
+			outputs, inputs := auction.backstop(backstop)
+
+			require.Equal(t, tc.expectedOutputs, outputs)
+			require.Equal(t, tc.expectedInputs, inputs)
+			require.Equal(t, backstop, auction.Bidder)
+			require.Equal(t, tc.expectedBid, auction.Bid)
+			require.False(t, auction.Lot.IsPositive())
+		})
+	}
+}
+
+func TestSealedBidAuction_CommitRevealBid(t *testing.T) {
+	seller := sdk.AccAddress([]byte("a_seller"))
+	buyer1 := sdk.AccAddress([]byte("buyer1"))
+	buyer2 := sdk.AccAddress([]byte("buyer2"))
+	buyer3 := sdk.AccAddress([]byte("buyer3"))
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	revealTime := now.Add(DefaultCommitDuration)
+	auction, _ := NewSealedBidAuction(seller, c("usdx", 100), c("kava", 10), c("kava", 5), revealTime, revealTime.Add(DefaultRevealDuration))
+
+	// commit phase
+	outputs, inputs, err := auction.CommitBid(now, buyer1, SealedBidHash(c("kava", 20), "salt1"))
+	require.NoError(t, err)
+	require.Equal(t, []bankOutput{{buyer1, cs(c("kava", 5))}}, outputs)
+	require.Equal(t, []bankInput{{EscrowAccountAddress, cs(c("kava", 5))}}, inputs)
+	_, _, err = auction.CommitBid(now, buyer1, SealedBidHash(c("kava", 30), "salt1"))
+	require.Error(t, err, "bidders can only commit once")
+	_, _, err = auction.CommitBid(now, seller, SealedBidHash(c("kava", 30), "salt"))
+	require.Error(t, err, "the initiator can't bid")
+	_, _, err = auction.CommitBid(now, buyer2, SealedBidHash(c("kava", 3), "salt2"))
+	require.NoError(t, err)
+	_, _, err = auction.CommitBid(now, buyer3, SealedBidHash(c("kava", 20), "salt3"))
+	require.NoError(t, err)
+	_, _, err = auction.RevealBid(now, buyer1, c("kava", 20), "salt1")
+	require.Error(t, err, "bids can't be revealed in the commit phase")
+
+	// reveal phase
+	auction.startRevealPhase()
+	_, _, err = auction.CommitBid(revealTime, buyer2, SealedBidHash(c("kava", 30), "salt2"))
+	require.Error(t, err, "bids can't be committed in the reveal phase")
+	_, _, err = auction.RevealBid(revealTime, buyer1, c("kava", 21), "salt1")
+	require.Error(t, err, "revealed bid must match the hash")
+
+	// the first winning bid replaces the deposit in escrow
+	outputs, inputs, err = auction.RevealBid(revealTime, buyer1, c("kava", 20), "salt1")
+	require.NoError(t, err)
+	require.Equal(t, []bankOutput{{buyer1, cs(c("kava", 15))}}, outputs)
+	require.Equal(t, []bankInput{{EscrowAccountAddress, cs(c("kava", 15))}}, inputs)
+	_, _, err = auction.RevealBid(revealTime, buyer1, c("kava", 20), "salt1")
+	require.Error(t, err, "bids can only be revealed once")
+
+	// bids below the minimum, and ties with the best bid, just get their deposit back
+	outputs, inputs, err = auction.RevealBid(revealTime, buyer2, c("kava", 3), "salt2")
+	require.NoError(t, err)
+	require.Equal(t, []bankOutput{{EscrowAccountAddress, cs(c("kava", 5))}}, outputs)
+	require.Equal(t, []bankInput{{buyer2, cs(c("kava", 5))}}, inputs)
+	_, _, err = auction.RevealBid(revealTime, buyer3, c("kava", 20), "salt3")
+	require.NoError(t, err)
+	require.Equal(t, buyer1, auction.Bidder)
+	require.Equal(t, c("kava", 20), auction.Bid)
+
+	// the winner gets the lot, and the seller gets the winning bid
+	outputs, inputs = auction.GetPayout()
+	require.Equal(t, []bankOutput{{EscrowAccountAddress, cs(c("kava", 20))}}, outputs)
+	require.Equal(t, []bankInput{{buyer1, cs(c("usdx", 100))}, {seller, cs(c("kava", 20))}}, inputs)
+}
+
+func TestSealedBidAuction_restart(t *testing.T) {
+	seller := sdk.AccAddress([]byte("a_seller"))
+	buyer1 := sdk.AccAddress([]byte("buyer1"))
+	buyer2 := sdk.AccAddress([]byte("buyer2"))
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	revealTime := now.Add(DefaultCommitDuration)
+	endTime := revealTime.Add(DefaultRevealDuration)
+	auction, _ := NewSealedBidAuction(seller, c("usdx", 100), c("kava", 10), c("kava", 5), revealTime, endTime)
+
+	// one losing bid is revealed, one is never revealed
+	_, _, err := auction.CommitBid(now, buyer1, SealedBidHash(c("kava", 3), "salt1"))
+	require.NoError(t, err)
+	_, _, err = auction.CommitBid(now, buyer2, SealedBidHash(c("kava", 20), "salt2"))
+	require.NoError(t, err)
+	auction.startRevealPhase()
+	_, _, err = auction.RevealBid(revealTime, buyer1, c("kava", 3), "salt1")
+	require.NoError(t, err)
+
+	// restarting pays the unrevealed deposit to the initiator and starts a new commit phase
+	outputs, inputs := auction.restart(endTime, DefaultAuctionParams())
+	require.Equal(t, []bankOutput{{EscrowAccountAddress, cs(c("kava", 5))}}, outputs)
+	require.Equal(t, []bankInput{{seller, cs(c("kava", 5))}}, inputs)
+	require.Equal(t, CommitPhase, auction.Phase)
+	require.Empty(t, auction.SealedBids)
+	require.Equal(t, endTime.Add(DefaultCommitDuration), auction.EndTime)
+	require.Equal(t, endTime.Add(DefaultCommitDuration).Add(DefaultRevealDuration), auction.MaxEndTime)
+	_, _, err = auction.CommitBid(endTime, buyer2, SealedBidHash(c("kava", 20), "salt2"))
+	require.NoError(t, err)
+}
+
+func TestAuction_cancel(t *testing.T) {
+	seller := sdk.AccAddress([]byte("a_seller"))
+	buyer1 := sdk.AccAddress([]byte("buyer1"))
+	buyer2 := sdk.AccAddress([]byte("buyer2"))
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	noBids, _ := NewForwardAuction(seller, c("usdx", 100), c("kava", 0), now)
+	forward, _ := NewForwardAuction(seller, c("usdx", 100), c("kava", 0), now)
+	forward.Bidder, forward.Bid = buyer1, c("kava", 10)
+	dutch, _ := NewDutchAuction(seller, c("btc", 10), c("usdx", 100), buyer2, now, now, sdk.OneDec(), sdk.ZeroDec(), NewPriceCurve(LinearCurve, time.Minute, sdk.ZeroDec()))
+	dutch.Bidder, dutch.Lot, dutch.Bid = buyer1, c("btc", 4), c("usdx", 60) // purchases have already settled
+	sealedBid, _ := NewSealedBidAuction(seller, c("usdx", 100), c("kava", 10), c("kava", 5), now, now)
+	sealedBid.Bidder, sealedBid.Bid = buyer1, c("kava", 20)
+	sealedBid.SealedBids = []SealedBid{{buyer1, nil, true}, {buyer2, nil, false}}
+	batch, _ := NewBatchAuction(seller, c("btc", 10), "usdx", now, 1)
+	batch.Orders = BatchOrders{{buyer1, sdk.NewInt(4), sdk.MustNewDecFromStr("2.5")}, {buyer2, sdk.NewInt(3), sdk.MustNewDecFromStr("1")}}
+
+	tests := []struct {
+		name            string
+		auction         Auction
+		expectedOutputs []bankOutput
+		expectedInputs  []bankInput
+	}{
+		{"noBids", &noBids, []bankOutput{}, []bankInput{{seller, cs(c("usdx", 100))}}},
+		{"forward", &forward, []bankOutput{{EscrowAccountAddress, cs(c("kava", 10))}}, []bankInput{{seller, cs(c("usdx", 100))}, {buyer1, cs(c("kava", 10))}}},
+		{"dutch", &dutch, []bankOutput{}, []bankInput{{seller, cs(c("btc", 4))}}},
+		{
+			"sealedBidRefundsUnrevealedDeposits",
+			&sealedBid,
+			[]bankOutput{{EscrowAccountAddress, cs(c("kava", 20))}, {EscrowAccountAddress, cs(c("kava", 5))}},
+			[]bankInput{{seller, cs(c("usdx", 100))}, {buyer1, cs(c("kava", 20))}, {buyer2, cs(c("kava", 5))}},
+		},
+		{
+			"batch",
+			&batch,
+			[]bankOutput{{EscrowAccountAddress, cs(c("usdx", 10))}, {EscrowAccountAddress, cs(c("usdx", 3))}},
+			[]bankInput{{seller, cs(c("btc", 10))}, {buyer1, cs(c("usdx", 10))}, {buyer2, cs(c("usdx", 3))}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			outputs, inputs := tc.auction.cancel()
+			require.Equal(t, tc.expectedOutputs, outputs)
+			require.Equal(t, tc.expectedInputs, inputs)
+		})
+	}
+}
+
+func TestBatchAuction_PlaceOrder(t *testing.T) {
+	seller := sdk.AccAddress([]byte("a_seller"))
+	buyer1 := sdk.AccAddress([]byte("buyer1"))
+	buyer2 := sdk.AccAddress([]byte("buyer2"))
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	auction, _ := NewBatchAuction(seller, c("btc", 10), "usdx", now, 5)
+
+	// orders are paid for in full at their max price, rounded up
+	outputs, inputs, err := auction.PlaceOrder(5, buyer1, sdk.NewInt(6), sdk.MustNewDecFromStr("2.5"))
+	require.NoError(t, err)
+	require.Equal(t, []bankOutput{{buyer1, cs(c("usdx", 15))}}, outputs)
+	require.Equal(t, []bankInput{{EscrowAccountAddress, cs(c("usdx", 15))}}, inputs)
+	_, _, err = auction.PlaceOrder(5, buyer2, sdk.NewInt(7), sdk.MustNewDecFromStr("0.5"))
+	require.NoError(t, err)
+	require.Equal(t, buyer2, auction.Bidder)
+	_, _, err = auction.PlaceOrder(5, seller, sdk.NewInt(1), sdk.OneDec())
+	require.Error(t, err, "the initiator can't bid")
+	_, _, err = auction.PlaceOrder(5, buyer2, sdk.NewInt(0), sdk.OneDec())
+	require.Error(t, err, "orders must be for a positive amount")
+	_, _, err = auction.PlaceOrder(6, buyer2, sdk.NewInt(1), sdk.OneDec())
+	require.Error(t, err, "orders can't be placed after the end height")
+	_, _, err = auction.PlaceBid(now, buyer2, c("btc", 10), c("usdx", 10), DefaultAuctionParams())
+	require.Error(t, err, "batch auctions don't take bids")
+
+	// lots can be added while the auction is open
+	require.NoError(t, auction.AddLot(5, c("btc", 2)))
+	require.Error(t, auction.AddLot(5, c("xrp", 2)), "lots must be of the same coin")
+	require.Error(t, auction.AddLot(6, c("btc", 2)), "lots can't be added after the end height")
+
+	// buyer1 is filled in full at buyer2's price, buyer2 gets what is left, and the seller gets the amount raised
+	outputs, inputs = auction.GetPayout()
+	require.Equal(t, []bankOutput{
+		{EscrowAccountAddress, cs(c("usdx", 12))},
+		{EscrowAccountAddress, cs(c("usdx", 1))},
+		{EscrowAccountAddress, cs(c("usdx", 6))},
+	}, outputs)
+	require.Equal(t, []bankInput{
+		{buyer1, cs(c("btc", 6))},
+		{buyer1, cs(c("usdx", 12))},
+		{buyer2, cs(c("btc", 6))},
+		{buyer2, cs(c("usdx", 1))},
+		{seller, cs(c("usdx", 6))},
+	}, inputs)
+}
+
+func TestBatchAuction_Clear(t *testing.T) {
+	seller := sdk.AccAddress([]byte("a_seller"))
+	buyers := []sdk.AccAddress{sdk.AccAddress([]byte("buyer1")), sdk.AccAddress([]byte("buyer2")), sdk.AccAddress([]byte("buyer3")), sdk.AccAddress([]byte("buyer4"))}
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	type order struct {
+		amount   int64
+		maxPrice string
+	}
+	tests := []struct {
+		name           string
+		lot            int64
+		orders         []order
+		expectedPrice  string
+		expectedLots   []int64 // amount of the lot each order gets
+		expectedRaised int64
+	}{
+		{"noOrders", 100, []order{}, "0", []int64{}, 0},
+		{"undersubscribed", 100, []order{{30, "2"}, {20, "1.5"}}, "1.5", []int64{30, 20}, 75},
+		{"exactlySubscribed", 50, []order{{30, "2"}, {20, "1.5"}}, "1.5", []int64{30, 20}, 75},
+		{"marginalOrdersShareProRata", 100, []order{{60, "3"}, {60, "2"}, {20, "2"}, {50, "1"}}, "2", []int64{60, 30, 10, 0}, 200},
+		{"roundingGoesToEarliestOrders", 10, []order{{3, "1"}, {3, "1"}, {3, "1"}, {3, "1"}}, "1", []int64{3, 3, 2, 2}, 10},
+		{"paymentsRoundUp", 3, []order{{2, "0.5"}, {1, "0.5"}}, "0.5", []int64{2, 1}, 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			auction, _ := NewBatchAuction(seller, c("btc", tc.lot), "usdx", now, 5)
+			for i, o := range tc.orders {
+				_, _, err := auction.PlaceOrder(5, buyers[i], sdk.NewInt(o.amount), sdk.MustNewDecFromStr(o.maxPrice))
+				require.NoError(t, err)
+			}
+
+			clearing := auction.Clear()
+
+			require.Equal(t, sdk.MustNewDecFromStr(tc.expectedPrice), clearing.Price)
+			sold := int64(0)
+			for i, fill := range clearing.Fills {
+				require.True(t, c("btc", tc.expectedLots[i]).IsEqual(fill.Lot))
+				require.Equal(t, auction.Orders[i].escrow("usdx"), fill.Payment.Add(fill.Refund))
+				sold += tc.expectedLots[i]
+			}
+			require.True(t, c("btc", sold).IsEqual(clearing.Sold))
+			require.True(t, c("btc", tc.lot-sold).IsEqual(clearing.Unsold))
+			require.True(t, c("usdx", tc.expectedRaised).IsEqual(clearing.Raised))
+		})
+	}
+}
+
+func TestPriceCurve_PriceAt(t *testing.T) {
+	d := sdk.MustNewDecFromStr
+	tests := []struct {
+		name     string
+		curve    PriceCurve
+		elapsed  time.Duration
+		expected sdk.Dec
+	}{
+		{"linearStart", NewPriceCurve(LinearCurve, time.Hour, d("0.1")), 0, d("100")},
+		{"linear", NewPriceCurve(LinearCurve, time.Hour, d("0.1")), 90 * time.Minute, d("85")},
+		{"linearFloor", NewPriceCurve(LinearCurve, time.Hour, d("0.1")), 11 * time.Hour, d("0")},
+		{"step", NewPriceCurve(StepCurve, time.Hour, d("0.1")), 90 * time.Minute, d("90")},
+		{"stepFloor", NewPriceCurve(StepCurve, time.Hour, d("0.3")), 4 * time.Hour, d("0")},
+		{"exponential", NewPriceCurve(ExponentialCurve, time.Hour, d("0.1")), 2 * time.Hour, d("81")},
+		{"exponentialInterpolated", NewPriceCurve(ExponentialCurve, time.Hour, d("0.1")), 150 * time.Minute, d("76.95")},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.curve.Validate())
+			require.Equal(t, tc.expected, tc.curve.PriceAt(d("100"), tc.elapsed))
+		})
+	}
+	require.Error(t, NewPriceCurve("unknown", time.Hour, d("0.1")).Validate())
+	require.Error(t, NewPriceCurve(LinearCurve, time.Hour, d("1.1")).Validate())
+	require.Error(t, NewPriceCurve(LinearCurve, 0, d("0.1")).Validate())
+}
+
+func TestMinNextBid(t *testing.T) {
+	tests := []struct {
+		currentBid   sdk.Coin
+		minIncrement string
+		expected     sdk.Coin
+	}{
+		{c("usdx", 100), "0.03", c("usdx", 103)},
+		{c("usdx", 101), "0.03", c("usdx", 105)}, // increment is rounded up
+		{c("usdx", 0), "0.03", c("usdx", 1)},     // bids always increase by at least 1
+		{c("usdx", 100), "0", c("usdx", 101)},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.expected, minNextBid(tc.currentBid, sdk.MustNewDecFromStr(tc.minIncrement)))
+	}
+}
+
+func TestMaxNextLot(t *testing.T) {
+	tests := []struct {
+		currentLot   sdk.Coin
+		minDecrement string
+		expected     sdk.Coin
+	}{
+		{c("kava", 100), "0.03", c("kava", 97)},
+		{c("kava", 101), "0.03", c("kava", 97)}, // decrement is rounded up
+		{c("kava", 10), "0", c("kava", 9)},      // lots always decrease by at least 1
+		{c("kava", 0), "0.03", c("kava", 0)},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.expected, maxNextLot(tc.currentLot, sdk.MustNewDecFromStr(tc.minDecrement)))
+	}
+}
+
 // defined to avoid cluttering test cases with long function name
 func c(denom string, amount int64) sdk.Coin {
 	return sdk.NewInt64Coin(denom, amount)
 }
+func cs(coins ...sdk.Coin) sdk.Coins {
+	return sdk.NewCoins(coins...)
+}
@@ -5,27 +5,186 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/kava-labs/kava-devnet/blockchain/x/auction"
 	"github.com/spf13/cobra"
 )
 
+const (
+	flagType      = "type"
+	flagBidder    = "bidder"
+	flagInitiator = "initiator"
+	flagPage      = "page"
+	flagLimit     = "limit"
+)
+
 // GetCmdGetAuctions queries the auctions in the store
 func GetCmdGetAuctions(queryRoute string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
-		Use:   "getauctions",
-		Short: "get a list of active auctions",
+	cmd := &cobra.Command{
+		Use:     "auctions",
+		Aliases: []string{"getauctions"},
+		Short:   "get a list of active auctions",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/getauctions", queryRoute), nil)
+
+			// Prepare params for querier
+			params, err := queryAuctionsParamsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			// Query
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, auction.QueryGetAuctions), bz)
 			if err != nil {
 				fmt.Printf("error when getting auctions - %s", err)
 				return nil
 			}
-			var out auction.QueryResAuctions
+
+			// Decode and print results
+			var out auction.Auctions
 			cdc.MustUnmarshalJSON(res, &out)
 			if len(out) == 0 {
-				out = append(out, "There are currently no auctions")
+				fmt.Println("There are currently no auctions")
+				return nil
+			}
+			return cliCtx.PrintOutput(out)
+		},
+	}
+	cmd.Flags().String(flagType, "", fmt.Sprintf("only return auctions of this type (%s, %s, %s, %s, %s, %s or %s)", auction.ForwardAuctionType, auction.ReverseAuctionType, auction.ForwardReverseAuctionType, auction.DutchAuctionType, auction.SealedBidAuctionType, auction.BasketAuctionType, auction.BatchAuctionType))
+	cmd.Flags().String(flagBidder, "", "only return auctions where this address is the current bidder")
+	cmd.Flags().String(flagInitiator, "", "only return auctions started by this address")
+	cmd.Flags().Int(flagPage, 1, "page of results to return")
+	cmd.Flags().Int(flagLimit, 0, "number of auctions per page, 0 returns all auctions")
+	return cmd
+}
+
+func queryAuctionsParamsFromFlags(cmd *cobra.Command) (auction.QueryAuctionsParams, error) {
+	var params auction.QueryAuctionsParams
+	var err error
+	if params.Type, err = cmd.Flags().GetString(flagType); err != nil {
+		return params, err
+	}
+	if params.Bidder, err = addressFromFlag(cmd, flagBidder); err != nil {
+		return params, err
+	}
+	if params.Initiator, err = addressFromFlag(cmd, flagInitiator); err != nil {
+		return params, err
+	}
+	if params.Page, err = cmd.Flags().GetInt(flagPage); err != nil {
+		return params, err
+	}
+	if params.Limit, err = cmd.Flags().GetInt(flagLimit); err != nil {
+		return params, err
+	}
+	return params, nil
+}
+
+func addressFromFlag(cmd *cobra.Command, flag string) (sdk.AccAddress, error) {
+	bech32, err := cmd.Flags().GetString(flag)
+	if err != nil || bech32 == "" {
+		return nil, err
+	}
+	return sdk.AccAddressFromBech32(bech32)
+}
+
+// GetCmdGetAuction queries a single auction by ID
+func GetCmdGetAuction(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "auction [auctionID]",
+		Short: "get info about an auction",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			id, err := auction.NewIDFromString(args[0])
+			if err != nil {
+				fmt.Printf("invalid auction id - %s \n", args[0])
+				return err
+			}
+
+			// Query
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%d", queryRoute, auction.QueryGetAuction, id), nil)
+			if err != nil {
+				fmt.Printf("could not get auction %d - %s \n", id, err)
+				return nil
 			}
+
+			// Decode and print results
+			var out auction.Auction
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdGetBids queries the bid history of an auction
+func GetCmdGetBids(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "bids [auctionID]",
+		Short: "get the bid history of an auction",
+		Long:  "Get every bid placed on an auction, oldest first. Bid histories are kept for a while after auctions close, set by the bid history retention param.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			id, err := auction.NewIDFromString(args[0])
+			if err != nil {
+				fmt.Printf("invalid auction id - %s \n", args[0])
+				return err
+			}
+
+			// Query
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%d", queryRoute, auction.QueryGetBids, id), nil)
+			if err != nil {
+				fmt.Printf("could not get bids for auction %d - %s \n", id, err)
+				return nil
+			}
+
+			// Decode and print results
+			var out auction.BidRecords
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdGetFailedAuctions queries auctions that closed without bids
+func GetCmdGetFailedAuctions(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "failed [auctionID]",
+		Short: "get auctions that closed without bids",
+		Long:  "Get the auctions that closed without any bids and what happened to their lots, or just one auction if an ID is given. Records are kept for as long as bid histories.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			if len(args) == 0 {
+				res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, auction.QueryGetFailedAuctions), nil)
+				if err != nil {
+					fmt.Printf("could not get failed auctions - %s \n", err)
+					return nil
+				}
+				var out auction.FailedAuctions
+				cdc.MustUnmarshalJSON(res, &out)
+				return cliCtx.PrintOutput(out)
+			}
+
+			id, err := auction.NewIDFromString(args[0])
+			if err != nil {
+				fmt.Printf("invalid auction id - %s \n", args[0])
+				return err
+			}
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%d", queryRoute, auction.QueryGetFailedAuctions, id), nil)
+			if err != nil {
+				fmt.Printf("could not get failed auction %d - %s \n", id, err)
+				return nil
+			}
+			var out auction.FailedAuction
+			cdc.MustUnmarshalJSON(res, &out)
 			return cliCtx.PrintOutput(out)
 		},
 	}
@@ -2,6 +2,8 @@ package cli
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/utils"
@@ -51,3 +53,336 @@ func GetCmdPlaceBid(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdPlacePartialBid cli command for bidding on part of the lot of a forward reverse auction.
+func GetCmdPlacePartialBid(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "placepartialbid [AuctionID] [Bid] [Lot]",
+		Short: "bid on part of the lot of a forward reverse auction, splitting it off into a new auction",
+		Long:  "Bid on part of the lot of a forward reverse auction. The part is split off into a new auction with a proportional share of the max bid, and the bid is placed on that. Only possible before the max bid is reached.",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+			id, err := auction.NewIDFromString(args[0])
+			if err != nil {
+				fmt.Printf("invalid auction id - %s \n", args[0])
+				return err
+			}
+
+			bid, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				fmt.Printf("invalid bid amount - %s \n", args[1])
+				return err
+			}
+
+			lot, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				fmt.Printf("invalid lot - %s \n", args[2])
+				return err
+			}
+
+			msg := auction.NewMsgPlacePartialBid(id, cliCtx.GetFromAddress(), bid, lot)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			cliCtx.PrintResponse = true
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdPlaceProxyBid cli command for setting a limit that the auction module bids up to automatically.
+func GetCmdPlaceProxyBid(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "placeproxybid [AuctionID] [MaxBid] [MinLot]",
+		Short: "bid automatically on an auction, outbidding others by the minimum increment up to a limit",
+		Long: strings.TrimSpace(`Set a hidden limit on an auction. Whenever someone else bids, a bid is placed on your behalf that beats theirs by the minimum increment, up to the max bid.
+In reverse auctions, and once the max bid is reached in forward reverse auctions, the lot is bid down instead, as far as the min lot. Other auctions ignore the min lot.
+The whole max bid is taken into escrow straight away, and what isn't spent is refunded when the proxy bid is outbid or the auction closes.
+Placing a normal bid on the auction cancels the proxy bid.
+
+$ kavacli tx auction placeproxybid 3 500usdx 0btc --from mykey`),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+			id, err := auction.NewIDFromString(args[0])
+			if err != nil {
+				fmt.Printf("invalid auction id - %s \n", args[0])
+				return err
+			}
+
+			maxBid, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				fmt.Printf("invalid max bid - %s \n", args[1])
+				return err
+			}
+
+			minLot, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				fmt.Printf("invalid min lot - %s \n", args[2])
+				return err
+			}
+
+			msg := auction.NewMsgPlaceProxyBid(id, cliCtx.GetFromAddress(), maxBid, minLot)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			cliCtx.PrintResponse = true
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdPlaceBatchOrder cli command for placing a limit order on a batch auction.
+func GetCmdPlaceBatchOrder(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "placebatchorder [AuctionID] [Amount] [MaxPrice]",
+		Short: "order an amount of the lot of a batch auction, paying at most a max price for each unit",
+		Long: strings.TrimSpace(`Place a limit order on a batch auction. When the auction closes every filled order pays the same clearing price, which is never more than the max price.
+The order is paid for in full at the max price straight away, and what isn't spent is refunded when the auction closes.
+
+$ kavacli tx auction placebatchorder 3 1000 0.5 --from mykey`),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+			id, err := auction.NewIDFromString(args[0])
+			if err != nil {
+				fmt.Printf("invalid auction id - %s \n", args[0])
+				return err
+			}
+
+			amount, ok := sdk.NewIntFromString(args[1])
+			if !ok {
+				fmt.Printf("invalid amount - %s \n", args[1])
+				return fmt.Errorf("invalid amount - %s", args[1])
+			}
+
+			maxPrice, err := sdk.NewDecFromStr(args[2])
+			if err != nil {
+				fmt.Printf("invalid max price - %s \n", args[2])
+				return err
+			}
+
+			msg := auction.NewMsgPlaceBatchOrder(id, cliCtx.GetFromAddress(), amount, maxPrice)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			cliCtx.PrintResponse = true
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdCommitBid cli command for placing a sealed bid on a sealed bid auction. Only the hash of the bid and salt is sent.
+func GetCmdCommitBid(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "commitbid [AuctionID] [Bid] [Salt]",
+		Short: "place a sealed bid on a sealed bid auction, keep the salt secret until the bid is revealed",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+			id, err := auction.NewIDFromString(args[0])
+			if err != nil {
+				fmt.Printf("invalid auction id - %s \n", args[0])
+				return err
+			}
+
+			bid, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				fmt.Printf("invalid bid amount - %s \n", args[1])
+				return err
+			}
+
+			msg := auction.NewMsgCommitBid(id, cliCtx.GetFromAddress(), auction.SealedBidHash(bid, args[2]))
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			cliCtx.PrintResponse = true
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdRevealBid cli command for revealing a sealed bid.
+func GetCmdRevealBid(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revealbid [AuctionID] [Bid] [Salt]",
+		Short: "reveal a sealed bid on a sealed bid auction",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+			id, err := auction.NewIDFromString(args[0])
+			if err != nil {
+				fmt.Printf("invalid auction id - %s \n", args[0])
+				return err
+			}
+
+			bid, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				fmt.Printf("invalid bid amount - %s \n", args[1])
+				return err
+			}
+
+			msg := auction.NewMsgRevealBid(id, cliCtx.GetFromAddress(), bid, args[2])
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			cliCtx.PrintResponse = true
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdStartForwardAuction cli command for selling coins in a forward auction.
+func GetCmdStartForwardAuction(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "startforwardauction [Lot] [BidDenom] [ReservePrice] [Duration]",
+		Short: "start an auction selling a lot to the highest bidder, bids must be at least the reserve price",
+		Long: strings.TrimSpace(`Start a forward auction selling the lot for coins of the bid denom, lasting for a duration such as 24h.
+The lot can be several coins separated by commas, eg 10btc,500xrp, which are sold together.
+The auction creation deposit is taken from the seller, and is returned when the auction closes if anyone bid on it.
+
+$ kavacli tx auction startforwardauction 1000kava usdx 500 24h --from mykey`),
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+			lot, err := sdk.ParseCoins(args[0])
+			if err != nil {
+				fmt.Printf("invalid lot - %s \n", args[0])
+				return err
+			}
+			reservePrice, ok := sdk.NewIntFromString(args[2])
+			if !ok {
+				return fmt.Errorf("invalid reserve price - %s", args[2])
+			}
+			duration, err := time.ParseDuration(args[3])
+			if err != nil {
+				fmt.Printf("invalid duration - %s \n", args[3])
+				return err
+			}
+
+			msg := auction.NewMsgStartForwardAuction(cliCtx.GetFromAddress(), lot, args[1], reservePrice, duration)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			cliCtx.PrintResponse = true
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdStartReverseAuction cli command for buying coins in a reverse auction.
+func GetCmdStartReverseAuction(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "startreverseauction [Bid] [LotDenom] [ReservePrice] [Duration]",
+		Short: "start an auction buying coins from whoever will accept the smallest lot, paying at most the reserve price",
+		Long: strings.TrimSpace(`Start a reverse auction buying the bid with coins of the lot denom, lasting for a duration such as 24h.
+The auction creation deposit is taken from the buyer, and is returned when the auction closes if anyone bid on it.
+
+$ kavacli tx auction startreverseauction 500usdx kava 1000 24h --from mykey`),
+		Args: cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+			bid, err := sdk.ParseCoin(args[0])
+			if err != nil {
+				fmt.Printf("invalid bid - %s \n", args[0])
+				return err
+			}
+			reservePrice, ok := sdk.NewIntFromString(args[2])
+			if !ok {
+				return fmt.Errorf("invalid reserve price - %s", args[2])
+			}
+			duration, err := time.ParseDuration(args[3])
+			if err != nil {
+				fmt.Printf("invalid duration - %s \n", args[3])
+				return err
+			}
+
+			msg := auction.NewMsgStartReverseAuction(cliCtx.GetFromAddress(), bid, args[1], reservePrice, duration)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			cliCtx.PrintResponse = true
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdStartSealedBidAuction cli command for selling coins in a sealed bid auction.
+func GetCmdStartSealedBidAuction(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "startsealedbidauction [Lot] [MinBid] [Deposit]",
+		Short: "start an auction selling a lot to the highest sealed bid, bids must be at least the min bid",
+		Long: strings.TrimSpace(`Start a sealed bid auction selling the lot. Bidders commit to hidden bids with a deposit, then reveal them once the commit phase is over.
+The min bid and deposit must be in the same denom. The phases last for the commit and reveal durations in the auction params.
+The auction creation deposit is taken from the seller, and is returned when the auction closes if anyone bid on it.
+
+$ kavacli tx auction startsealedbidauction 1000kava 500usdx 50usdx --from mykey`),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(cdc)
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+			lot, err := sdk.ParseCoin(args[0])
+			if err != nil {
+				fmt.Printf("invalid lot - %s \n", args[0])
+				return err
+			}
+			minBid, err := sdk.ParseCoin(args[1])
+			if err != nil {
+				fmt.Printf("invalid min bid - %s \n", args[1])
+				return err
+			}
+			deposit, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				fmt.Printf("invalid deposit - %s \n", args[2])
+				return err
+			}
+
+			msg := auction.NewMsgStartSealedBidAuction(cliCtx.GetFromAddress(), lot, minBid, deposit)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			cliCtx.PrintResponse = true
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
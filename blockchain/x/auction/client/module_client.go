@@ -28,6 +28,9 @@ func (mc ModuleClient) GetQueryCmd() *cobra.Command {
 
 	auctionQueryCmd.AddCommand(client.GetCommands(
 		auctioncmd.GetCmdGetAuctions(mc.storeKey, mc.cdc),
+		auctioncmd.GetCmdGetAuction(mc.storeKey, mc.cdc),
+		auctioncmd.GetCmdGetBids(mc.storeKey, mc.cdc),
+		auctioncmd.GetCmdGetFailedAuctions(mc.storeKey, mc.cdc),
 	)...)
 
 	return auctionQueryCmd
@@ -42,6 +45,14 @@ func (mc ModuleClient) GetTxCmd() *cobra.Command {
 
 	auctionTxCmd.AddCommand(client.PostCommands(
 		auctioncmd.GetCmdPlaceBid(mc.cdc),
+		auctioncmd.GetCmdPlacePartialBid(mc.cdc),
+		auctioncmd.GetCmdPlaceProxyBid(mc.cdc),
+		auctioncmd.GetCmdPlaceBatchOrder(mc.cdc),
+		auctioncmd.GetCmdCommitBid(mc.cdc),
+		auctioncmd.GetCmdRevealBid(mc.cdc),
+		auctioncmd.GetCmdStartForwardAuction(mc.cdc),
+		auctioncmd.GetCmdStartReverseAuction(mc.cdc),
+		auctioncmd.GetCmdStartSealedBidAuction(mc.cdc),
 	)...)
 
 	return auctionTxCmd
@@ -1,8 +1,11 @@
 package rest
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -15,42 +18,224 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-type placeBidReq struct {
-	BaseReq   rest.BaseReq `json:"base_req"`
-	AuctionID string       `json:"auction_id"`
-	Bidder    string       `json:"bidder"`
-	Bid       string       `json:"bid"`
-	Lot       string       `json:"lot"`
-}
-
 const (
 	restAuctionID = "auction_id"
 	restBidder    = "bidder"
 	restBid       = "bid"
 	restLot       = "lot"
+	restType      = "type"
+	restInitiator = "initiator"
+	restPage      = "page"
+	restLimit     = "limit"
 )
 
+/*
+API Design:
+
+Get auctions, optionally filtered by type, current bidder or initiator, and paginated
+	GET /auction/auctions?type={type}&bidder={address}&initiator={address}&page={page}&limit={limit}
+	responds with a list of auctions (auction.Auctions), ordered by ID
+Get one auction
+	GET /auction/auctions/{auction_id}
+	responds with the auction (auction.Auction)
+Get the bid history of an auction
+	GET /auction/auctions/{auction_id}/bids
+	responds with a list of bids (auction.BidRecords), oldest first
+Place a bid on an auction, the body is a PlaceBidRequest
+	POST /auction/auctions/{auction_id}/bids
+	responds with an unsigned tx (auth.StdTx) containing a MsgPlaceBid
+Get auctions that closed without bids, or one of them
+	GET /auction/failed
+	GET /auction/failed/{auction_id}
+	responds with a list of failed auctions (auction.FailedAuctions), or one failed auction
+Start a forward auction, selling a lot for at least a reserve price
+	POST /auction/auctions/forward
+Start a reverse auction, buying a bid for at most a reserve price
+	POST /auction/auctions/reverse
+Start a sealed bid auction, selling a lot to the highest revealed bid of at least a min bid
+	POST /auction/auctions/sealed
+	all respond with an unsigned tx (auth.StdTx)
+
+Errors respond with a rest.ErrorResponse and status:
+	400 Bad Request for an invalid auction ID, filter or request body
+	404 Not Found for an auction (or failed auction record) that doesn't exist
+	500 Internal Server Error if the query fails for any other reason
+
+Deprecated, responding with a "Warning: 299" header:
+	GET /auction/getauctions
+	use GET /auction/auctions instead
+	PUT /auction/bid/{auction_id}/{bidder}/{bid}/{lot}
+	use POST /auction/auctions/{auction_id}/bids instead, as the bidder and amounts end up in access logs
+*/
+
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
-	r.HandleFunc(fmt.Sprintf("/auction/getauctions"), queryGetAuctionsHandlerFn(cdc, cliCtx)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/auction/bid/{%s}/{%s}/{%s}/{%s}", restAuctionID, restBidder, restBid, restLot), bidHandlerFn(cdc, cliCtx)).Methods("PUT")
+	r.HandleFunc("/auction/auctions", queryGetAuctionsHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/auction/auctions/{%s}", restAuctionID), queryGetAuctionHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/auction/auctions/{%s}/bids", restAuctionID), queryGetBidsHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/auction/auctions/{%s}/bids", restAuctionID), placeBidHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/auction/failed", queryGetFailedAuctionsHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/auction/failed/{%s}", restAuctionID), queryGetFailedAuctionHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/auction/auctions/forward", startForwardAuctionHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/auction/auctions/reverse", startReverseAuctionHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/auction/auctions/sealed", startSealedBidAuctionHandlerFn(cdc, cliCtx)).Methods("POST")
+	// deprecated routes, kept for existing clients
+	r.HandleFunc("/auction/getauctions", deprecatedHandlerFn("GET /auction/auctions", queryGetAuctionsHandlerFn(cdc, cliCtx))).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/auction/bid/{%s}/{%s}/{%s}/{%s}", restAuctionID, restBidder, restBid, restLot), deprecatedHandlerFn("POST /auction/auctions/{auction_id}/bids", bidHandlerFn(cdc, cliCtx))).Methods("PUT")
 }
 
 func queryGetAuctionsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		res, err := cliCtx.QueryWithData("/custom/auction/getauctions", nil)
+		// get parameters from the URL
+		query := r.URL.Query()
+		querierParams := auction.QueryAuctionsParams{Type: query.Get(restType)}
+
+		switch querierParams.Type {
+		case "", auction.ForwardAuctionType, auction.ReverseAuctionType, auction.ForwardReverseAuctionType, auction.DutchAuctionType, auction.SealedBidAuctionType, auction.BasketAuctionType, auction.BatchAuctionType:
+		default:
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid auction type: %s", querierParams.Type))
+			return
+		}
+
+		if bidder := query.Get(restBidder); len(bidder) != 0 {
+			addr, err := sdk.AccAddressFromBech32(bidder)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			querierParams.Bidder = addr
+		}
+		if initiator := query.Get(restInitiator); len(initiator) != 0 {
+			addr, err := sdk.AccAddressFromBech32(initiator)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			querierParams.Initiator = addr
+		}
+		if page := query.Get(restPage); len(page) != 0 {
+			n, err := strconv.Atoi(page)
+			if err != nil || n < 0 {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid page: %s, must be a non negative integer", page))
+				return
+			}
+			querierParams.Page = n
+		}
+		if limit := query.Get(restLimit); len(limit) != 0 {
+			n, err := strconv.Atoi(limit)
+			if err != nil || n < 0 {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid limit: %s, must be a non negative integer", limit))
+				return
+			}
+			querierParams.Limit = n
+		}
+
+		querierParamsBz, err := cdc.MarshalJSON(querierParams)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("/custom/auction/%s", auction.QueryGetAuctions), querierParamsBz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+func queryGetAuctionHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		auctionID, err := auction.NewIDFromString(mux.Vars(r)[restAuctionID])
 		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("/custom/auction/%s/%d", auction.QueryGetAuction, auctionID), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, queryErrorStatus(err), err.Error())
 			return
 		}
 		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
 	}
 }
 
-func bidHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+func queryGetBidsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		auctionID, err := auction.NewIDFromString(mux.Vars(r)[restAuctionID])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("/custom/auction/%s/%d", auction.QueryGetBids, auctionID), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, queryErrorStatus(err), err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+func queryGetFailedAuctionsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("/custom/auction/%s", auction.QueryGetFailedAuctions), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+func queryGetFailedAuctionHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		auctionID, err := auction.NewIDFromString(mux.Vars(r)[restAuctionID])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("/custom/auction/%s/%d", auction.QueryGetFailedAuctions, auctionID), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, queryErrorStatus(err), err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+// queryErrorStatus returns the status to respond with when a query for one auction fails: 404 if the querier couldn't find the auction, otherwise 500.
+func queryErrorStatus(err error) int {
+	var log struct {
+		Codespace sdk.CodespaceType `json:"codespace"`
+		Code      sdk.CodeType      `json:"code"`
+	}
+	// failed queries return the ABCI log of the querier's error, falling back to a 500 for errors that didn't come from the querier
+	if json.Unmarshal([]byte(err.Error()), &log) != nil || log.Codespace != auction.DefaultCodespace {
+		return http.StatusInternalServerError
+	}
+	switch log.Code {
+	case auction.CodeAuctionNotFound, auction.CodeFailedAuctionNotFound:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// deprecatedHandlerFn serves a deprecated route, warning clients to use its replacement instead.
+func deprecatedHandlerFn(replacement string, handler http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Warning", fmt.Sprintf(`299 - "deprecated, use %s"`, replacement))
+		handler(w, r)
+	}
+}
 
-		var req placeBidReq
+// bidHandlerFn serves the deprecated PUT route, which takes every argument in the URL path.
+func bidHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		strAuctionID := vars[restAuctionID]
 		bechBidder := vars[restBidder]
@@ -82,7 +267,153 @@ func bidHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc
 		}
 
 		msg := auction.NewMsgPlaceBid(auctionID, bidder, bid, lot)
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, rest.BaseReq{}, []sdk.Msg{msg})
+
+	}
+}
+
+// PlaceBidRequest is the body of a POST to /auction/auctions/{auction_id}/bids, the auction ID is taken from the path.
+type PlaceBidRequest struct {
+	BaseReq rest.BaseReq   `json:"base_req"`
+	Bidder  sdk.AccAddress `json:"bidder"`
+	Bid     sdk.Coin       `json:"bid"`
+	Lot     sdk.Coin       `json:"lot"`
+}
+
+func placeBidHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		auctionID, err := auction.NewIDFromString(mux.Vars(r)[restAuctionID])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Get args from post body
+		var req PlaceBidRequest
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		// Create msg
+		msg := auction.NewMsgPlaceBid(auctionID, req.Bidder, req.Bid, req.Lot)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Generate tx and write response
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type StartForwardAuctionRequest struct {
+	BaseReq      rest.BaseReq   `json:"base_req"`
+	Seller       sdk.AccAddress `json:"seller"`
+	Lot          sdk.Coins      `json:"lot"`
+	BidDenom     string         `json:"bid_denom"`
+	ReservePrice sdk.Int        `json:"reserve_price"`
+	Duration     string         `json:"duration"` // eg "24h"
+}
+
+func startForwardAuctionHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get args from post body
+		var req StartForwardAuctionRequest
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Create msg
+		msg := auction.NewMsgStartForwardAuction(req.Seller, req.Lot, req.BidDenom, req.ReservePrice, duration)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Generate tx and write response
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type StartReverseAuctionRequest struct {
+	BaseReq      rest.BaseReq   `json:"base_req"`
+	Buyer        sdk.AccAddress `json:"buyer"`
+	Bid          sdk.Coin       `json:"bid"`
+	LotDenom     string         `json:"lot_denom"`
+	ReservePrice sdk.Int        `json:"reserve_price"`
+	Duration     string         `json:"duration"` // eg "24h"
+}
+
+func startReverseAuctionHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get args from post body
+		var req StartReverseAuctionRequest
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+		duration, err := time.ParseDuration(req.Duration)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Create msg
+		msg := auction.NewMsgStartReverseAuction(req.Buyer, req.Bid, req.LotDenom, req.ReservePrice, duration)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Generate tx and write response
 		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+type StartSealedBidAuctionRequest struct {
+	BaseReq rest.BaseReq   `json:"base_req"`
+	Seller  sdk.AccAddress `json:"seller"`
+	Lot     sdk.Coin       `json:"lot"`
+	MinBid  sdk.Coin       `json:"min_bid"`
+	Deposit sdk.Coin       `json:"deposit"`
+}
 
+func startSealedBidAuctionHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Get args from post body
+		var req StartSealedBidAuctionRequest
+		if !rest.ReadRESTReq(w, r, cdc, &req) {
+			return
+		}
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		// Create msg
+		msg := auction.NewMsgStartSealedBidAuction(req.Seller, req.Lot, req.MinBid, req.Deposit)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Generate tx and write response
+		clientrest.WriteGenerateStdTxResponse(w, cdc, cliCtx, req.BaseReq, []sdk.Msg{msg})
 	}
 }
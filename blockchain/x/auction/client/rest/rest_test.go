@@ -0,0 +1,116 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kava-labs/kava-devnet/blockchain/x/auction"
+)
+
+func newTestRouter() (*mux.Router, *codec.Codec) {
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	auction.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	r := mux.NewRouter()
+	RegisterRoutes(context.CLIContext{}, r, cdc)
+	return r, cdc
+}
+
+func TestPlaceBidHandler(t *testing.T) {
+	_, addrs := mock.GeneratePrivKeyAddressPairs(1)
+	bidder := addrs[0]
+	baseReq := fmt.Sprintf(`{"from":"%s","chain_id":"testchain"}`, bidder)
+	validBody := fmt.Sprintf(`{"base_req":%s,"bidder":"%s","bid":{"denom":"usdx","amount":"10"},"lot":{"denom":"btc","amount":"1"}}`, baseReq, bidder)
+
+	tests := []struct {
+		name           string
+		auctionID      string
+		body           string
+		expectedStatus int
+	}{
+		{"valid", "1", validBody, http.StatusOK},
+		{"invalidAuctionID", "notAnID", validBody, http.StatusBadRequest},
+		{"malformedBody", "1", `{"base_req":`, http.StatusBadRequest},
+		{"emptyBidder", "1", fmt.Sprintf(`{"base_req":%s,"bid":{"denom":"usdx","amount":"10"},"lot":{"denom":"btc","amount":"1"}}`, baseReq), http.StatusBadRequest},
+		{"negativeBid", "1", fmt.Sprintf(`{"base_req":%s,"bidder":"%s","bid":{"denom":"usdx","amount":"-10"},"lot":{"denom":"btc","amount":"1"}}`, baseReq, bidder), http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			router, cdc := newTestRouter()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/auction/auctions/%s/bids", tc.auctionID), strings.NewReader(tc.body))
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedStatus, w.Code, w.Body.String())
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var tx auth.StdTx
+			require.NoError(t, cdc.UnmarshalJSON(w.Body.Bytes(), &tx))
+			require.Equal(t, []sdk.Msg{auction.NewMsgPlaceBid(1, bidder, sdk.NewInt64Coin("usdx", 10), sdk.NewInt64Coin("btc", 1))}, tx.GetMsgs())
+		})
+	}
+}
+
+func TestDeprecatedRoutes(t *testing.T) {
+	_, addrs := mock.GeneratePrivKeyAddressPairs(1)
+	bidder := addrs[0]
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+	}{
+		{"invalidAuctionID", "PUT", fmt.Sprintf("/auction/bid/notAnID/%s/10usdx/1btc", bidder)},
+		{"invalidBidder", "PUT", "/auction/bid/1/notAnAddress/10usdx/1btc"},
+		{"invalidBid", "PUT", fmt.Sprintf("/auction/bid/1/%s/notACoin/1btc", bidder)},
+		{"invalidLot", "PUT", fmt.Sprintf("/auction/bid/1/%s/10usdx/notACoin", bidder)},
+		{"getAuctionsInvalidType", "GET", "/auction/getauctions?type=unknown"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			router, _ := newTestRouter()
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code)
+			require.Contains(t, w.Header().Get("Warning"), "deprecated")
+		})
+	}
+}
+
+func TestQueryErrorStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"auctionNotFound", errors.New(auction.ErrAuctionNotFound(auction.DefaultCodespace, 1).ABCILog()), http.StatusNotFound},
+		{"failedAuctionNotFound", errors.New(auction.ErrFailedAuctionNotFound(auction.DefaultCodespace, 1).ABCILog()), http.StatusNotFound},
+		{"unknownEndpoint", errors.New(sdk.ErrUnknownRequest("unknown auction query endpoint").ABCILog()), http.StatusInternalServerError},
+		{"querierError", errors.New(sdk.ErrInternal("could not marshal result to JSON").ABCILog()), http.StatusInternalServerError},
+		{"clientError", errors.New("no RPC client defined"), http.StatusInternalServerError},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedStatus, queryErrorStatus(tc.err))
+		})
+	}
+}
@@ -17,10 +17,22 @@ func init() {
 // RegisterCodec registers concrete types on the codec.
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgPlaceBid{}, "auction/MsgPlaceBid", nil)
+	cdc.RegisterConcrete(MsgPlacePartialBid{}, "auction/MsgPlacePartialBid", nil)
+	cdc.RegisterConcrete(MsgPlaceProxyBid{}, "auction/MsgPlaceProxyBid", nil)
+	cdc.RegisterConcrete(MsgPlaceBatchOrder{}, "auction/MsgPlaceBatchOrder", nil)
+	cdc.RegisterConcrete(MsgCommitBid{}, "auction/MsgCommitBid", nil)
+	cdc.RegisterConcrete(MsgRevealBid{}, "auction/MsgRevealBid", nil)
+	cdc.RegisterConcrete(MsgStartForwardAuction{}, "auction/MsgStartForwardAuction", nil)
+	cdc.RegisterConcrete(MsgStartReverseAuction{}, "auction/MsgStartReverseAuction", nil)
+	cdc.RegisterConcrete(MsgStartSealedBidAuction{}, "auction/MsgStartSealedBidAuction", nil)
 
 	// Register the Auction interface and concrete types
 	cdc.RegisterInterface((*Auction)(nil), nil)
 	cdc.RegisterConcrete(&ForwardAuction{}, "auction/ForwardAuction", nil)
 	cdc.RegisterConcrete(&ReverseAuction{}, "auction/ReverseAuction", nil)
 	cdc.RegisterConcrete(&ForwardReverseAuction{}, "auction/ForwardReverseAuction", nil)
+	cdc.RegisterConcrete(&DutchAuction{}, "auction/DutchAuction", nil)
+	cdc.RegisterConcrete(&SealedBidAuction{}, "auction/SealedBidAuction", nil)
+	cdc.RegisterConcrete(&BasketAuction{}, "auction/BasketAuction", nil)
+	cdc.RegisterConcrete(&BatchAuction{}, "auction/BatchAuction", nil)
 }
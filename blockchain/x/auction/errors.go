@@ -0,0 +1,27 @@
+package auction
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// DefaultCodespace codespace for the module
+	DefaultCodespace sdk.CodespaceType = ModuleName
+
+	// CodeAuctionNotFound error code for querying an auction that doesn't exist
+	CodeAuctionNotFound sdk.CodeType = 1
+	// CodeFailedAuctionNotFound error code for querying a failed auction record that doesn't exist
+	CodeFailedAuctionNotFound sdk.CodeType = 2
+)
+
+// ErrAuctionNotFound Error constructor for queries of auctions that don't exist, or have closed
+func ErrAuctionNotFound(codespace sdk.CodespaceType, id ID) sdk.Error {
+	return sdk.NewError(codespace, CodeAuctionNotFound, fmt.Sprintf("auction %d not found", id))
+}
+
+// ErrFailedAuctionNotFound Error constructor for queries of failed auctions that don't have a record
+func ErrFailedAuctionNotFound(codespace sdk.CodespaceType, id ID) sdk.Error {
+	return sdk.NewError(codespace, CodeFailedAuctionNotFound, fmt.Sprintf("no failed auction %d found", id))
+}
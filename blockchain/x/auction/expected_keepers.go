@@ -7,4 +7,5 @@ import (
 type bankKeeper interface {
 	SubtractCoins(sdk.Context, sdk.AccAddress, sdk.Coins) (sdk.Coins, sdk.Error)
 	AddCoins(sdk.Context, sdk.AccAddress, sdk.Coins) (sdk.Coins, sdk.Error)
+	GetCoins(sdk.Context, sdk.AccAddress) sdk.Coins
 }
@@ -4,26 +4,34 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// GenesisState - crisis genesis state
+// GenesisState is the state that must be provided at genesis.
 type GenesisState struct {
+	AuctionParams AuctionParams `json:"params"`
 }
 
 // NewGenesisState creates a new GenesisState object
-func NewGenesisState() GenesisState {
-	return GenesisState{}
+func NewGenesisState(auctionParams AuctionParams) GenesisState {
+	return GenesisState{
+		AuctionParams: auctionParams,
+	}
 }
 
 // DefaultGenesisState creates a default GenesisState object
 func DefaultGenesisState() GenesisState {
-	return GenesisState{}
+	return NewGenesisState(DefaultAuctionParams())
+}
+
+// InitGenesis sets the genesis state in the keeper.
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	keeper.setParams(ctx, data.AuctionParams)
 }
 
 // ValidateGenesis validates genesis state
 func ValidateGenesis(data GenesisState) error {
-	return nil
+	return data.AuctionParams.Validate()
 }
 
 // ExportGenesis returns a GenesisState for a given context and keeper.
 func ExportGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
-	return NewGenesisState()
+	return NewGenesisState(keeper.GetParams(ctx))
 }
@@ -12,6 +12,22 @@ func NewHandler(keeper Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case MsgPlaceBid:
 			return handleMsgPlaceBid(ctx, keeper, msg)
+		case MsgPlacePartialBid:
+			return handleMsgPlacePartialBid(ctx, keeper, msg)
+		case MsgPlaceProxyBid:
+			return handleMsgPlaceProxyBid(ctx, keeper, msg)
+		case MsgPlaceBatchOrder:
+			return handleMsgPlaceBatchOrder(ctx, keeper, msg)
+		case MsgCommitBid:
+			return handleMsgCommitBid(ctx, keeper, msg)
+		case MsgRevealBid:
+			return handleMsgRevealBid(ctx, keeper, msg)
+		case MsgStartForwardAuction:
+			return handleMsgStartForwardAuction(ctx, keeper, msg)
+		case MsgStartReverseAuction:
+			return handleMsgStartReverseAuction(ctx, keeper, msg)
+		case MsgStartSealedBidAuction:
+			return handleMsgStartSealedBidAuction(ctx, keeper, msg)
 		default:
 			errMsg := fmt.Sprintf("Unrecognized auction msg type: %T", msg)
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -28,3 +44,83 @@ func handleMsgPlaceBid(ctx sdk.Context, keeper Keeper, msg MsgPlaceBid) sdk.Resu
 
 	return sdk.Result{}
 }
+
+func handleMsgPlacePartialBid(ctx sdk.Context, keeper Keeper, msg MsgPlacePartialBid) sdk.Result {
+
+	auctionID, err := keeper.PlacePartialBid(ctx, msg.AuctionID, msg.Bidder, msg.Bid, msg.Lot)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{Data: keeper.cdc.MustMarshalBinaryLengthPrefixed(auctionID)}
+}
+
+func handleMsgPlaceProxyBid(ctx sdk.Context, keeper Keeper, msg MsgPlaceProxyBid) sdk.Result {
+
+	err := keeper.PlaceProxyBid(ctx, msg.AuctionID, msg.Bidder, msg.MaxBid, msg.MinLot)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+func handleMsgPlaceBatchOrder(ctx sdk.Context, keeper Keeper, msg MsgPlaceBatchOrder) sdk.Result {
+
+	err := keeper.PlaceBatchOrder(ctx, msg.AuctionID, msg.Bidder, msg.Amount, msg.MaxPrice)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+func handleMsgCommitBid(ctx sdk.Context, keeper Keeper, msg MsgCommitBid) sdk.Result {
+
+	err := keeper.CommitBid(ctx, msg.AuctionID, msg.Bidder, msg.Hash)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+func handleMsgRevealBid(ctx sdk.Context, keeper Keeper, msg MsgRevealBid) sdk.Result {
+
+	err := keeper.RevealBid(ctx, msg.AuctionID, msg.Bidder, msg.Bid, msg.Salt)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{}
+}
+
+func handleMsgStartForwardAuction(ctx sdk.Context, keeper Keeper, msg MsgStartForwardAuction) sdk.Result {
+
+	auctionID, err := keeper.StartUserForwardAuction(ctx, msg.Seller, msg.Lot, msg.BidDenom, msg.ReservePrice, msg.Duration)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{Data: keeper.cdc.MustMarshalBinaryLengthPrefixed(auctionID)}
+}
+
+func handleMsgStartReverseAuction(ctx sdk.Context, keeper Keeper, msg MsgStartReverseAuction) sdk.Result {
+
+	auctionID, err := keeper.StartUserReverseAuction(ctx, msg.Buyer, msg.Bid, msg.LotDenom, msg.ReservePrice, msg.Duration)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{Data: keeper.cdc.MustMarshalBinaryLengthPrefixed(auctionID)}
+}
+
+func handleMsgStartSealedBidAuction(ctx sdk.Context, keeper Keeper, msg MsgStartSealedBidAuction) sdk.Result {
+
+	auctionID, err := keeper.StartUserSealedBidAuction(ctx, msg.Seller, msg.Lot, msg.MinBid, msg.Deposit)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{Data: keeper.cdc.MustMarshalBinaryLengthPrefixed(auctionID)}
+}
@@ -0,0 +1,11 @@
+package auction
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AuctionHooks are called by the auction keeper so that the module that started an auction can react to it finishing.
+type AuctionHooks interface {
+	AfterAuctionClosed(ctx sdk.Context, auction Auction)              // called after the payout, once the auction has been deleted from the store
+	AfterAuctionSplit(ctx sdk.Context, parent Auction, child Auction) // called after part of an auction's lot is split off into a new auction, before any bid is placed on it
+}
@@ -3,33 +3,62 @@ package auction
 import (
 	"bytes"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 type Keeper struct {
-	bankKeeper bankKeeper
-	storeKey   sdk.StoreKey
-	cdc        *codec.Codec
+	bankKeeper     bankKeeper
+	storeKey       sdk.StoreKey
+	paramsSubspace params.Subspace
+	cdc            *codec.Codec
+	hooks          AuctionHooks
 	// TODO codespace
 }
 
 // NewKeeper returns a new auction keeper.
-func NewKeeper(cdc *codec.Codec, bankKeeper bankKeeper, storeKey sdk.StoreKey) Keeper {
+func NewKeeper(cdc *codec.Codec, bankKeeper bankKeeper, storeKey sdk.StoreKey, subspace params.Subspace) Keeper {
+	subspace = subspace.WithKeyTable(createParamsKeyTable())
 	return Keeper{
-		bankKeeper: bankKeeper,
-		storeKey:   storeKey,
-		cdc:        cdc,
+		bankKeeper:     bankKeeper,
+		storeKey:       storeKey,
+		paramsSubspace: subspace,
+		cdc:            cdc,
+		hooks:          nil,
 	}
 }
 
+// SetHooks sets the hooks that are called when auctions change. It can only be called once.
+func (k *Keeper) SetHooks(hooks AuctionHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set auction hooks twice")
+	}
+	k.hooks = hooks
+	return k
+}
+
 // TODO these 3 start functions be combined or abstracted away?
 
 // StartForwardAuction starts a normal auction. Known as flap in maker.
 func (k Keeper) StartForwardAuction(ctx sdk.Context, seller sdk.AccAddress, lot sdk.Coin, initialBid sdk.Coin) (ID, sdk.Error) {
 	// create auction
-	auction, initiatorOutput := NewForwardAuction(seller, lot, initialBid, endTime(ctx.BlockHeight())+MaxAuctionDuration)
+	auction, initiatorOutput := NewForwardAuction(seller, lot, initialBid, ctx.BlockHeader().Time.Add(k.GetParams(ctx).MaxAuctionDuration))
+	// start the auction
+	auctionID, err := k.startAuction(ctx, &auction, initiatorOutput)
+	if err != nil {
+		return 0, err
+	}
+	return auctionID, nil
+}
+
+// StartBasketAuction starts a forward auction selling several coins together.
+func (k Keeper) StartBasketAuction(ctx sdk.Context, seller sdk.AccAddress, lot sdk.Coins, initialBid sdk.Coin) (ID, sdk.Error) {
+	// create auction
+	auction, initiatorOutput := NewBasketAuction(seller, lot, initialBid, ctx.BlockHeader().Time.Add(k.GetParams(ctx).MaxAuctionDuration))
 	// start the auction
 	auctionID, err := k.startAuction(ctx, &auction, initiatorOutput)
 	if err != nil {
@@ -41,7 +70,7 @@ func (k Keeper) StartForwardAuction(ctx sdk.Context, seller sdk.AccAddress, lot
 // StartReverseAuction starts an auction where sellers compete by offering decreasing prices. Known as flop in maker.
 func (k Keeper) StartReverseAuction(ctx sdk.Context, buyer sdk.AccAddress, bid sdk.Coin, initialLot sdk.Coin) (ID, sdk.Error) {
 	// create auction
-	auction, initiatorOutput := NewReverseAuction(buyer, bid, initialLot, endTime(ctx.BlockHeight())+MaxAuctionDuration)
+	auction, initiatorOutput := NewReverseAuction(buyer, bid, initialLot, ctx.BlockHeader().Time.Add(k.GetParams(ctx).MaxAuctionDuration))
 	// start the auction
 	auctionID, err := k.startAuction(ctx, &auction, initiatorOutput)
 	if err != nil {
@@ -51,10 +80,37 @@ func (k Keeper) StartReverseAuction(ctx sdk.Context, buyer sdk.AccAddress, bid s
 }
 
 // StartForwardReverseAuction starts an auction where bidders bid up to a maxBid, then switch to bidding down on price. Known as flip in maker.
-func (k Keeper) StartForwardReverseAuction(ctx sdk.Context, seller sdk.AccAddress, lot sdk.Coin, maxBid sdk.Coin, otherPerson sdk.AccAddress) (ID, sdk.Error) {
+// The first bid must be at least the reserve price, which can be zero.
+func (k Keeper) StartForwardReverseAuction(ctx sdk.Context, seller sdk.AccAddress, lot sdk.Coin, maxBid sdk.Coin, reservePrice sdk.Int, otherPerson sdk.AccAddress) (ID, sdk.Error) {
+	if reservePrice.IsNegative() || reservePrice.IsPositive() && !reservePrice.LT(maxBid.Amount) {
+		return 0, sdk.ErrInternal(fmt.Sprintf("reserve price must be at least zero and below the max bid (%s)", maxBid))
+	}
+	// create auction
+	initialBid := sdk.NewCoin(maxBid.Denom, reservePrice) // set the bidding coin denomination from the specified max bid
+	auction, initiatorOutput := NewForwardReverseAuction(seller, lot, initialBid, ctx.BlockHeader().Time.Add(k.GetParams(ctx).MaxAuctionDuration), maxBid, otherPerson)
+	// start the auction
+	auctionID, err := k.startAuction(ctx, &auction, initiatorOutput)
+	if err != nil {
+		return 0, err
+	}
+	return auctionID, nil
+}
+
+// StartDutchAuction starts an auction where the price of the lot decays over time from startPrice, restarting if it falls below resetPrice. Known as a clip in maker.
+func (k Keeper) StartDutchAuction(ctx sdk.Context, seller sdk.AccAddress, lot sdk.Coin, maxBid sdk.Coin, otherPerson sdk.AccAddress, startPrice sdk.Dec, resetPrice sdk.Dec, curve PriceCurve) (ID, sdk.Error) {
+	// check the price will start above the reset price and decay properly
+	if err := curve.Validate(); err != nil {
+		return 0, sdk.ErrInternal(err.Error())
+	}
+	if !startPrice.IsPositive() || resetPrice.IsNegative() || !resetPrice.LT(startPrice) {
+		return 0, sdk.ErrInternal(fmt.Sprintf("start price (%s) must be positive and above the reset price (%s)", startPrice, resetPrice))
+	}
+	if !maxBid.IsPositive() {
+		return 0, sdk.ErrInternal("max bid must be positive")
+	}
 	// create auction
-	initialBid := sdk.NewInt64Coin(maxBid.Denom, 0) // set the bidding coin denomination from the specified max bid
-	auction, initiatorOutput := NewForwardReverseAuction(seller, lot, initialBid, endTime(ctx.BlockHeight())+MaxAuctionDuration, maxBid, otherPerson)
+	now := ctx.BlockHeader().Time
+	auction, initiatorOutput := NewDutchAuction(seller, lot, maxBid, otherPerson, now, now.Add(k.GetParams(ctx).MaxAuctionDuration), startPrice, resetPrice, curve)
 	// start the auction
 	auctionID, err := k.startAuction(ctx, &auction, initiatorOutput)
 	if err != nil {
@@ -63,6 +119,130 @@ func (k Keeper) StartForwardReverseAuction(ctx sdk.Context, seller sdk.AccAddres
 	return auctionID, nil
 }
 
+// StartSealedBidAuction starts a forward auction where bidders commit to hidden bids, then reveal them once bidding has closed.
+func (k Keeper) StartSealedBidAuction(ctx sdk.Context, seller sdk.AccAddress, lot sdk.Coin, minBid sdk.Coin, deposit sdk.Coin) (ID, sdk.Error) {
+	auction, initiatorOutput, err := k.newSealedBidAuction(ctx, seller, lot, minBid, deposit)
+	if err != nil {
+		return 0, err
+	}
+	// start the auction
+	auctionID, err := k.startAuction(ctx, &auction, initiatorOutput)
+	if err != nil {
+		return 0, err
+	}
+	return auctionID, nil
+}
+
+// StartUserSealedBidAuction starts a sealed bid auction for a user, taking the creation deposit from them. The phases last CommitDuration and RevealDuration.
+func (k Keeper) StartUserSealedBidAuction(ctx sdk.Context, seller sdk.AccAddress, lot sdk.Coin, minBid sdk.Coin, deposit sdk.Coin) (ID, sdk.Error) {
+	auction, initiatorOutput, err := k.newSealedBidAuction(ctx, seller, lot, minBid, deposit)
+	if err != nil {
+		return 0, err
+	}
+	return k.startUserAuction(ctx, &auction, initiatorOutput)
+}
+
+func (k Keeper) newSealedBidAuction(ctx sdk.Context, seller sdk.AccAddress, lot sdk.Coin, minBid sdk.Coin, deposit sdk.Coin) (SealedBidAuction, bankOutput, sdk.Error) {
+	if deposit.Denom != minBid.Denom {
+		return SealedBidAuction{}, bankOutput{}, sdk.ErrInternal("deposit must be in the same denom as bids")
+	}
+	params := k.GetParams(ctx)
+	revealTime := ctx.BlockHeader().Time.Add(params.CommitDuration)
+	auction, initiatorOutput := NewSealedBidAuction(seller, lot, minBid, deposit, revealTime, revealTime.Add(params.RevealDuration))
+	return auction, initiatorOutput, nil
+}
+
+// StartBatchAuction starts an auction that pools lots for window blocks, then sells them to orders at a single clearing price.
+func (k Keeper) StartBatchAuction(ctx sdk.Context, seller sdk.AccAddress, lot sdk.Coin, bidDenom string, window int64) (ID, sdk.Error) {
+	if window <= 0 {
+		return 0, sdk.ErrInternal("batch window must be positive")
+	}
+	if !lot.IsPositive() {
+		return 0, sdk.ErrInternal("lot must be positive")
+	}
+	// create auction
+	auction, initiatorOutput := NewBatchAuction(seller, lot, bidDenom, ctx.BlockHeader().Time, ctx.BlockHeight()+window-1)
+	// start the auction
+	auctionID, err := k.startAuction(ctx, &auction, initiatorOutput)
+	if err != nil {
+		return 0, err
+	}
+	return auctionID, nil
+}
+
+// AddToBatchAuction adds coins from the auction's initiator to the lot of a batch auction that is still open.
+func (k Keeper) AddToBatchAuction(ctx sdk.Context, auctionID ID, seller sdk.AccAddress, lot sdk.Coin) sdk.Error {
+	auction, err := k.getBatchAuction(ctx, auctionID)
+	if err != nil {
+		return err
+	}
+	if !seller.Equals(auction.Initiator) {
+		return sdk.ErrInternal("only the auction initiator can add to the lot")
+	}
+	err = auction.AddLot(ctx.BlockHeight(), lot)
+	if err != nil {
+		return err
+	}
+	_, err = k.bankKeeper.SubtractCoins(ctx, seller, sdk.NewCoins(lot))
+	if err != nil {
+		return err
+	}
+	k.setAuction(ctx, auction)
+	return nil
+}
+
+// StartUserForwardAuction starts a forward auction for a user, taking the creation deposit from them. Bids must be at least the reserve price.
+// Lots of more than one coin are sold in a basket auction.
+func (k Keeper) StartUserForwardAuction(ctx sdk.Context, seller sdk.AccAddress, lot sdk.Coins, bidDenom string, reservePrice sdk.Int, duration time.Duration) (ID, sdk.Error) {
+	endTime, err := k.userAuctionEndTime(ctx, duration)
+	if err != nil {
+		return 0, err
+	}
+	if len(lot) == 1 {
+		auction, initiatorOutput := NewForwardAuction(seller, lot[0], sdk.NewCoin(bidDenom, reservePrice), endTime)
+		return k.startUserAuction(ctx, &auction, initiatorOutput)
+	}
+	auction, initiatorOutput := NewBasketAuction(seller, lot, sdk.NewCoin(bidDenom, reservePrice), endTime)
+	return k.startUserAuction(ctx, &auction, initiatorOutput)
+}
+
+// StartUserReverseAuction starts a reverse auction for a user, taking the creation deposit from them. The buyer pays at most the reserve price for the bid.
+func (k Keeper) StartUserReverseAuction(ctx sdk.Context, buyer sdk.AccAddress, bid sdk.Coin, lotDenom string, reservePrice sdk.Int, duration time.Duration) (ID, sdk.Error) {
+	endTime, err := k.userAuctionEndTime(ctx, duration)
+	if err != nil {
+		return 0, err
+	}
+	auction, initiatorOutput := NewReverseAuction(buyer, bid, sdk.NewCoin(lotDenom, reservePrice), endTime)
+	return k.startUserAuction(ctx, &auction, initiatorOutput)
+}
+
+func (k Keeper) userAuctionEndTime(ctx sdk.Context, duration time.Duration) (time.Time, sdk.Error) {
+	maxDuration := k.GetParams(ctx).MaxAuctionDuration
+	if duration <= 0 || duration > maxDuration {
+		return time.Time{}, sdk.ErrInternal(fmt.Sprintf("auction duration must be positive and at most %s", maxDuration))
+	}
+	return ctx.BlockHeader().Time.Add(duration), nil
+}
+
+// startUserAuction starts an auction and takes the creation deposit into escrow, only changing the state if both succeed.
+func (k Keeper) startUserAuction(ctx sdk.Context, auction Auction, initiatorOutput bankOutput) (ID, sdk.Error) {
+	cacheCtx, write := ctx.CacheContext()
+	auctionID, err := k.startAuction(cacheCtx, auction, initiatorOutput)
+	if err != nil {
+		return 0, err
+	}
+	deposit := k.GetParams(ctx).CreationDeposit
+	if deposit.IsPositive() {
+		err = k.transferCoins(cacheCtx, []bankOutput{{auction.GetInitiator(), sdk.NewCoins(deposit)}}, []bankInput{{EscrowAccountAddress, sdk.NewCoins(deposit)}})
+		if err != nil {
+			return 0, err
+		}
+		k.setCreationDeposit(cacheCtx, auctionID, deposit)
+	}
+	write()
+	return auctionID, nil
+}
+
 func (k Keeper) startAuction(ctx sdk.Context, auction Auction, initiatorOutput bankOutput) (ID, sdk.Error) {
 	// get ID
 	newAuctionID, err := k.getNextAuctionID(ctx)
@@ -73,7 +253,7 @@ func (k Keeper) startAuction(ctx sdk.Context, auction Auction, initiatorOutput b
 	auction.SetID(newAuctionID)
 
 	// subtract coins from initiator
-	_, err = k.bankKeeper.SubtractCoins(ctx, initiatorOutput.Address, sdk.NewCoins(initiatorOutput.Coin))
+	_, err = k.bankKeeper.SubtractCoins(ctx, initiatorOutput.Address, initiatorOutput.Coins)
 	if err != nil {
 		return 0, err
 	}
@@ -85,6 +265,7 @@ func (k Keeper) startAuction(ctx sdk.Context, auction Auction, initiatorOutput b
 }
 
 // PlaceBid places a bid on any auction.
+// A bid replaces any proxy bid the bidder has on the auction. Other bidders' proxy bids then respond to it.
 func (k Keeper) PlaceBid(ctx sdk.Context, auctionID ID, bidder sdk.AccAddress, bid sdk.Coin, lot sdk.Coin) sdk.Error {
 
 	// get auction from store
@@ -92,36 +273,437 @@ func (k Keeper) PlaceBid(ctx sdk.Context, auctionID ID, bidder sdk.AccAddress, b
 	if !found {
 		return sdk.ErrInternal("auction doesn't exist")
 	}
+	if k.IsAuctionFrozen(ctx, auctionID) {
+		return sdk.ErrInternal("auction is frozen")
+	}
 
-	// place bid
-	coinOutputs, coinInputs, err := auction.PlaceBid(endTime(ctx.BlockHeight()), bidder, lot, bid) // update auction according to what type of auction it is // TODO should this return updated Auction to be more immutable?
+	// place the bid and any proxy bids, only writing the changes if they all succeed
+	cacheCtx, write := ctx.CacheContext()
+	err := k.cancelProxyBid(cacheCtx, auction, bidder)
 	if err != nil {
 		return err
 	}
-	// TODO this will fail if someone tries to update their bid without the full bid amount sitting in their account
-	// sub outputs
-	for _, output := range coinOutputs {
-		_, err = k.bankKeeper.SubtractCoins(ctx, output.Address, sdk.NewCoins(output.Coin)) // TODO handle errors properly here. All coin transfers should be atomic. InputOutputCoins may work
-		if err != nil {
-			panic(err)
+	err = k.placeBid(cacheCtx, auction, bidder, bid, lot, false)
+	if err != nil {
+		return err
+	}
+	err = k.resolveProxyBids(cacheCtx, auctionID)
+	if err != nil {
+		return err
+	}
+	write()
+	return nil
+}
+
+// placeBid places a bid on an auction, stores the updated auction and records the bid in its history.
+// Bidders with proxy bids on the auction already have their whole max bid in escrow, so their bids are paid from escrow and their refunds stay there.
+func (k Keeper) placeBid(ctx sdk.Context, auction Auction, bidder sdk.AccAddress, bid sdk.Coin, lot sdk.Coin, proxy bool) sdk.Error {
+	phase := bidPhase(auction)
+	coinOutputs, coinInputs, err := auction.PlaceBid(ctx.BlockHeader().Time, bidder, lot, bid, k.GetParams(ctx)) // update auction according to what type of auction it is // TODO should this return updated Auction to be more immutable?
+	if err != nil {
+		return err
+	}
+	proxyBids := k.getProxyBids(ctx, auction.GetID())
+	for i, output := range coinOutputs {
+		if proxyBids.holdsInEscrow(output.Address, output.Coins) {
+			coinOutputs[i].Address = EscrowAccountAddress
 		}
 	}
-	// add inputs
-	for _, input := range coinInputs {
-		_, err = k.bankKeeper.AddCoins(ctx, input.Address, sdk.NewCoins(input.Coin)) // TODO errors
+	for i, input := range coinInputs {
+		if proxyBids.holdsInEscrow(input.Address, input.Coins) {
+			coinInputs[i].Address = EscrowAccountAddress
+		}
+	}
+	// move coins, the auction is only updated if they all succeed
+	err = k.transferCoins(ctx, coinOutputs, coinInputs)
+	if err != nil {
+		return err
+	}
+
+	// store updated auction, and record the bid in its history
+	k.setAuction(ctx, auction)
+	recordedLot := sdk.Coins{lot}
+	if basketAuction, ok := auction.(*BasketAuction); ok {
+		recordedLot = basketAuction.Lot // bids on basket auctions are always for the whole lot
+	}
+	record := k.newBidRecord(ctx, auction.GetID(), bidder, bid, recordedLot, phase)
+	record.Proxy = proxy
+	k.appendBid(ctx, record)
+
+	return nil
+}
+
+// PlaceProxyBid sets a hidden limit for the keeper to bid up to on the bidder's behalf, outbidding other bids by the minimum increment.
+// In reverse auctions (and the reverse phase of forward reverse auctions) the limit is the smallest lot the bidder will accept.
+// The whole max bid is taken into escrow straight away, and any proxy bid the bidder already has on the auction is replaced.
+func (k Keeper) PlaceProxyBid(ctx sdk.Context, auctionID ID, bidder sdk.AccAddress, maxBid sdk.Coin, minLot sdk.Coin) sdk.Error {
+	auction, found := k.GetAuction(ctx, auctionID)
+	if !found {
+		return sdk.ErrInternal("auction doesn't exist")
+	}
+	if k.IsAuctionFrozen(ctx, auctionID) {
+		return sdk.ErrInternal("auction is frozen")
+	}
+	if !supportsProxyBids(auction) {
+		return sdk.ErrInternal(fmt.Sprintf("proxy bids can't be placed on %s auctions", auction.GetType()))
+	}
+	if ctx.BlockHeader().Time.After(auction.GetEndTime()) {
+		return sdk.ErrInternal("auction has closed")
+	}
+	if bidder.Equals(auction.GetInitiator()) {
+		return sdk.ErrInternal("auction initiator cannot bid")
+	}
+	currentBid, currentLot := currentBidAndLot(auction)
+	if maxBid.Denom != currentBid.Denom {
+		return sdk.ErrInternal(fmt.Sprintf("max bid must be in %s", currentBid.Denom))
+	}
+	if reverseAuction, ok := auction.(*ReverseAuction); ok && !maxBid.IsEqual(reverseAuction.Bid) {
+		return sdk.ErrInternal(fmt.Sprintf("max bid must be the auction's bid %s, bidders compete on the lot", reverseAuction.Bid))
+	}
+	proxyBid := ProxyBid{Bidder: bidder, MaxBid: maxBid, MinLot: minLot}
+
+	// the new proxy bid must be able to bid straight away, or cover the bid already placed if the bidder is winning
+	escrowed := sdk.NewCoin(currentBid.Denom, sdk.ZeroInt())
+	if bidder.Equals(auction.GetBidder()) {
+		_, _, lotBid, _ := nextProxyBid(auction, k.GetParams(ctx))
+		if !proxyBid.canAfford(currentBid, currentLot, lotBid) {
+			return sdk.ErrInternal(fmt.Sprintf("proxy bid must be at least the current bid of %s for %s", currentBid, currentLot))
+		}
+		escrowed = currentBid // the bid is already in escrow
+	} else {
+		bid, lot, lotBid, ok := nextProxyBid(auction, k.GetParams(ctx))
+		if !ok || !proxyBid.canAfford(bid, lot, lotBid) {
+			return sdk.ErrInternal("proxy bid too small, it must be able to beat the current bid")
+		}
+	}
+	proxyBids := k.getProxyBids(ctx, auctionID)
+	if i, found := proxyBids.find(bidder); found {
+		escrowed = proxyBids[i].MaxBid
+		proxyBids = append(proxyBids[:i], proxyBids[i+1:]...) // the replacement goes to the back of the queue for ties
+	}
+
+	// escrow the whole max bid, taking only the difference from any bid or proxy bid already in escrow
+	var coinOutputs []bankOutput
+	var coinInputs []bankInput
+	switch {
+	case escrowed.IsLT(maxBid):
+		difference := sdk.NewCoins(maxBid.Sub(escrowed))
+		coinOutputs, coinInputs = []bankOutput{{bidder, difference}}, []bankInput{{EscrowAccountAddress, difference}}
+	case maxBid.IsLT(escrowed):
+		difference := sdk.NewCoins(escrowed.Sub(maxBid))
+		coinOutputs, coinInputs = []bankOutput{{EscrowAccountAddress, difference}}, []bankInput{{bidder, difference}}
+	}
+	cacheCtx, write := ctx.CacheContext()
+	err := k.transferCoins(cacheCtx, coinOutputs, coinInputs)
+	if err != nil {
+		return err
+	}
+	k.setProxyBids(cacheCtx, auctionID, append(proxyBids, proxyBid))
+	err = k.resolveProxyBids(cacheCtx, auctionID)
+	if err != nil {
+		return err
+	}
+	write()
+	return nil
+}
+
+// resolveProxyBids bids on behalf of an auction's proxy bids after the auction changes.
+// The strongest proxy bid outbids the rest by the minimum increment, which settles the auction in one bid rather than many small ones.
+// If the strongest can't beat the limit of the next strongest (they have the same limit), it bids its limit, so ties go to the proxy bid placed first.
+// Proxy bids that can no longer win are ended, refunding their escrow.
+func (k Keeper) resolveProxyBids(ctx sdk.Context, auctionID ID) sdk.Error {
+	proxyBids := k.getProxyBids(ctx, auctionID)
+	if len(proxyBids) == 0 {
+		return nil
+	}
+	auction, found := k.GetAuction(ctx, auctionID)
+	if !found {
+		return sdk.ErrInternal("auction doesn't exist")
+	}
+	params := k.GetParams(ctx)
+
+	// find the strongest proxy bid and its strongest rival, out of the ones still able to bid
+	active := k.activeProxyBids(auction, params, proxyBids)
+	strongest, found := active.strongest(auction, params)
+	if found {
+		var rivals ProxyBids
+		for _, p := range active {
+			if !p.Bidder.Equals(strongest.Bidder) {
+				rivals = append(rivals, p)
+			}
+		}
+		rival, hasRival := rivals.strongest(auction, params)
+
+		// bid just enough to beat the rival, or the smallest bid possible if there isn't one. There is nothing to do if the strongest is already winning unopposed.
+		if hasRival || !strongest.Bidder.Equals(auction.GetBidder()) {
+			bid, lot, lotBid, _ := nextProxyBid(auction, params)
+			if hasRival {
+				rivalLimit, _, _ := proxyBidLimit(auction, rival)
+				var ok bool
+				bid, lot, lotBid, ok = nextProxyBid(rivalLimit, params)
+				if !ok || !strongest.canAfford(bid, lot, lotBid) {
+					_, bid, lot = proxyBidLimit(auction, strongest)
+				}
+			}
+			err := k.placeBid(ctx, auction, strongest.Bidder, bid, lot, true)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// end the proxy bids that have been outbid for good
+	active = k.activeProxyBids(auction, params, proxyBids)
+	var coinOutputs []bankOutput
+	var coinInputs []bankInput
+	for _, p := range proxyBids {
+		if _, found := active.find(p.Bidder); !found {
+			coinOutputs = append(coinOutputs, bankOutput{EscrowAccountAddress, sdk.NewCoins(p.MaxBid)})
+			coinInputs = append(coinInputs, bankInput{p.Bidder, sdk.NewCoins(p.MaxBid)})
+		}
+	}
+	err := k.transferCoins(ctx, coinOutputs, coinInputs)
+	if err != nil {
+		return err
+	}
+	k.setProxyBids(ctx, auctionID, active)
+	return nil
+}
+
+// activeProxyBids returns the proxy bids that can still bid on an auction, which are the current bidder's and any that can beat the current bid.
+func (k Keeper) activeProxyBids(auction Auction, params AuctionParams, proxyBids ProxyBids) ProxyBids {
+	bid, lot, lotBid, ok := nextProxyBid(auction, params)
+	active := ProxyBids{}
+	for _, p := range proxyBids {
+		if p.Bidder.Equals(auction.GetBidder()) || (ok && p.canAfford(bid, lot, lotBid)) {
+			active = append(active, p)
+		}
+	}
+	return active
+}
+
+// cancelProxyBid ends a bidder's proxy bid on an auction, refunding the part of their max bid not currently bid on the auction.
+func (k Keeper) cancelProxyBid(ctx sdk.Context, auction Auction, bidder sdk.AccAddress) sdk.Error {
+	proxyBids := k.getProxyBids(ctx, auction.GetID())
+	i, found := proxyBids.find(bidder)
+	if !found {
+		return nil
+	}
+	refund := proxyBidRefund(auction, proxyBids[i])
+	if refund.IsPositive() {
+		err := k.transferCoins(ctx, []bankOutput{{EscrowAccountAddress, sdk.NewCoins(refund)}}, []bankInput{{bidder, sdk.NewCoins(refund)}})
 		if err != nil {
-			panic(err)
+			return err
 		}
 	}
+	k.setProxyBids(ctx, auction.GetID(), append(proxyBids[:i], proxyBids[i+1:]...))
+	return nil
+}
+
+// proxyBidRefund returns the part of a proxy bid's max bid that is held in escrow but not bid on the auction.
+func proxyBidRefund(auction Auction, p ProxyBid) sdk.Coin {
+	if p.Bidder.Equals(auction.GetBidder()) {
+		currentBid, _ := currentBidAndLot(auction)
+		return p.MaxBid.Sub(currentBid)
+	}
+	return p.MaxBid
+}
+
+// PlacePartialBid bids on part of the lot of a forward reverse auction. The part is split off into a new auction, which the bid is placed on.
+// The new auction's ID is returned. Nothing is split if the bid fails.
+func (k Keeper) PlacePartialBid(ctx sdk.Context, auctionID ID, bidder sdk.AccAddress, bid sdk.Coin, lot sdk.Coin) (ID, sdk.Error) {
+	auction, found := k.GetAuction(ctx, auctionID)
+	if !found {
+		return 0, sdk.ErrInternal("auction doesn't exist")
+	}
+	if k.IsAuctionFrozen(ctx, auctionID) {
+		return 0, sdk.ErrInternal("auction is frozen")
+	}
+	parent, ok := auction.(*ForwardReverseAuction)
+	if !ok {
+		return 0, sdk.ErrInternal("partial bids can only be placed on forward reverse auctions")
+	}
+	if ctx.BlockHeader().Time.After(parent.EndTime) {
+		return 0, sdk.ErrInternal("auction has closed")
+	}
+	child, err := parent.split(lot)
+	if err != nil {
+		return 0, err
+	}
+
+	// store both auctions and bid on the child, only writing the changes if the bid succeeds
+	cacheCtx, write := ctx.CacheContext()
+	childID, err := k.getNextAuctionID(cacheCtx)
+	if err != nil {
+		return 0, err
+	}
+	child.SetID(childID)
+	k.incrementNextAuctionID(cacheCtx)
+	k.setAuction(cacheCtx, parent)
+	k.setAuction(cacheCtx, &child)
+	// the current bidder's share of their bid moves to the child, so it no longer counts towards their proxy bid on the parent
+	proxyBids := k.getProxyBids(cacheCtx, auctionID)
+	if i, found := proxyBids.find(child.Bidder); found {
+		proxyBids[i].MaxBid = proxyBids[i].MaxBid.Sub(child.Bid)
+		k.setProxyBids(cacheCtx, auctionID, proxyBids)
+	}
+	if k.hooks != nil {
+		k.hooks.AfterAuctionSplit(cacheCtx, parent, &child)
+	}
+	err = k.PlaceBid(cacheCtx, childID, bidder, bid, lot)
+	if err != nil {
+		return 0, err
+	}
+	write()
+	return childID, nil
+}
+
+// CommitBid places a sealed bid on a sealed bid auction, taking the auction's deposit from the bidder.
+func (k Keeper) CommitBid(ctx sdk.Context, auctionID ID, bidder sdk.AccAddress, hash []byte) sdk.Error {
+	auction, err := k.getSealedBidAuction(ctx, auctionID)
+	if err != nil {
+		return err
+	}
+	coinOutputs, coinInputs, err := auction.CommitBid(ctx.BlockHeader().Time, bidder, hash)
+	if err != nil {
+		return err
+	}
+	// move coins, the auction is only updated if they all succeed
+	err = k.transferCoins(ctx, coinOutputs, coinInputs)
+	if err != nil {
+		return err
+	}
+	k.setAuction(ctx, auction)
+	return nil
+}
 
-	// store updated auction
+// RevealBid reveals a sealed bid, refunding the deposit and taking the bid if it is the best so far.
+func (k Keeper) RevealBid(ctx sdk.Context, auctionID ID, bidder sdk.AccAddress, bid sdk.Coin, salt string) sdk.Error {
+	auction, err := k.getSealedBidAuction(ctx, auctionID)
+	if err != nil {
+		return err
+	}
+	coinOutputs, coinInputs, err := auction.RevealBid(ctx.BlockHeader().Time, bidder, bid, salt)
+	if err != nil {
+		return err
+	}
+	// move coins, the auction is only updated if they all succeed
+	err = k.transferCoins(ctx, coinOutputs, coinInputs)
+	if err != nil {
+		return err
+	}
 	k.setAuction(ctx, auction)
+	// sealed bids only enter the bid history once they are revealed
+	k.appendBid(ctx, k.newBidRecord(ctx, auctionID, bidder, bid, auction.GetLot(), RevealPhase))
+	return nil
+}
+
+// PlaceBatchOrder places an order on a batch auction for an amount of the lot, paying up to maxPrice for each unit.
+// Payment for the whole order at maxPrice is taken into escrow, and what isn't needed is refunded when the auction closes.
+func (k Keeper) PlaceBatchOrder(ctx sdk.Context, auctionID ID, bidder sdk.AccAddress, amount sdk.Int, maxPrice sdk.Dec) sdk.Error {
+	auction, err := k.getBatchAuction(ctx, auctionID)
+	if err != nil {
+		return err
+	}
+	coinOutputs, coinInputs, err := auction.PlaceOrder(ctx.BlockHeight(), bidder, amount, maxPrice)
+	if err != nil {
+		return err
+	}
+	// move coins, the auction is only updated if they all succeed
+	err = k.transferCoins(ctx, coinOutputs, coinInputs)
+	if err != nil {
+		return err
+	}
+	k.setAuction(ctx, auction)
+	// orders are recorded with the escrow paid for them
+	order := auction.Orders[len(auction.Orders)-1]
+	k.appendBid(ctx, k.newBidRecord(ctx, auctionID, bidder, order.escrow(auction.Bid.Denom), sdk.NewCoins(sdk.NewCoin(auction.Lot.Denom, amount)), ""))
+	return nil
+}
+
+func (k Keeper) getBatchAuction(ctx sdk.Context, auctionID ID) (*BatchAuction, sdk.Error) {
+	auction, found := k.GetAuction(ctx, auctionID)
+	if !found {
+		return nil, sdk.ErrInternal("auction doesn't exist")
+	}
+	if k.IsAuctionFrozen(ctx, auctionID) {
+		return nil, sdk.ErrInternal("auction is frozen")
+	}
+	batchAuction, ok := auction.(*BatchAuction)
+	if !ok {
+		return nil, sdk.ErrInternal("auction is not a batch auction")
+	}
+	return batchAuction, nil
+}
+
+func (k Keeper) getSealedBidAuction(ctx sdk.Context, auctionID ID) (*SealedBidAuction, sdk.Error) {
+	auction, found := k.GetAuction(ctx, auctionID)
+	if !found {
+		return nil, sdk.ErrInternal("auction doesn't exist")
+	}
+	if k.IsAuctionFrozen(ctx, auctionID) {
+		return nil, sdk.ErrInternal("auction is frozen")
+	}
+	sealedBidAuction, ok := auction.(*SealedBidAuction)
+	if !ok {
+		return nil, sdk.ErrInternal("auction is not a sealed bid auction")
+	}
+	return sealedBidAuction, nil
+}
+
+// transferCoins moves coins between accounts atomically.
+// It checks every account can fund all its outputs before moving anything, and only writes the changes if every transfer succeeds.
+func (k Keeper) transferCoins(ctx sdk.Context, outputs []bankOutput, inputs []bankInput) sdk.Error {
+	// total up the outputs from each account (in order, to keep this deterministic) and check they can be paid
+	var addresses []sdk.AccAddress
+	totals := make(map[string]sdk.Coins)
+	for _, output := range outputs {
+		key := output.Address.String()
+		if _, found := totals[key]; !found {
+			addresses = append(addresses, output.Address)
+		}
+		totals[key] = totals[key].Add(output.Coins)
+	}
+	for _, address := range addresses {
+		total := totals[address.String()]
+		if coins := k.bankKeeper.GetCoins(ctx, address); !coins.IsAllGTE(total) {
+			return sdk.ErrInsufficientCoins(fmt.Sprintf("insufficient funds in %s; %s < %s", address, coins, total))
+		}
+	}
 
+	// move the coins in a cached context so a failure part way through can't leave coins half moved
+	cacheCtx, write := ctx.CacheContext()
+	for _, output := range outputs {
+		_, err := k.bankKeeper.SubtractCoins(cacheCtx, output.Address, output.Coins)
+		if err != nil {
+			return err
+		}
+	}
+	for _, input := range inputs {
+		_, err := k.bankKeeper.AddCoins(cacheCtx, input.Address, input.Coins)
+		if err != nil {
+			return err
+		}
+	}
+	write()
 	return nil
 }
 
+// ---------- Module Parameters ----------
+
+// GetParams returns the params for auction module
+func (k Keeper) GetParams(ctx sdk.Context) AuctionParams {
+	var params AuctionParams
+	k.paramsSubspace.Get(ctx, moduleParamsKey, &params)
+	return params
+}
+
+func (k Keeper) setParams(ctx sdk.Context, params AuctionParams) {
+	k.paramsSubspace.Set(ctx, moduleParamsKey, &params)
+}
+
 // CloseAuction closes an auction and distributes funds to the seller and highest bidder.
-// TODO because this is called by the end blocker, it has to be valid for the duration of the EndTime block. Should maybe move this to a begin blocker?
+// It is called by the begin blocker in the block after the auction's EndTime, but can be called in any block from EndTime on.
 func (k Keeper) CloseAuction(ctx sdk.Context, auctionID ID) sdk.Error {
 
 	// get the auction from the store
@@ -129,23 +711,172 @@ func (k Keeper) CloseAuction(ctx sdk.Context, auctionID ID) sdk.Error {
 	if !found {
 		return sdk.ErrInternal("auction doesn't exist")
 	}
-	// error if auction has not reached the end time
-	if ctx.BlockHeight() < int64(auction.GetEndTime()) { // auctions close at the end of the block with blockheight == EndTime
-		return sdk.ErrInternal(fmt.Sprintf("auction can't be closed as curent block height (%v) is under auction end time (%v)", ctx.BlockHeight(), auction.GetEndTime()))
+	if k.IsAuctionFrozen(ctx, auctionID) {
+		return sdk.ErrInternal("auction is frozen")
 	}
-	// payout to the last bidder
-	coinInput := auction.GetPayout()
-	_, err := k.bankKeeper.AddCoins(ctx, coinInput.Address, sdk.NewCoins(coinInput.Coin))
+	// error if auction has not reached the end time, or end height for batch auctions
+	if batchAuction, ok := auction.(*BatchAuction); ok {
+		if ctx.BlockHeight() < batchAuction.EndHeight {
+			return sdk.ErrInternal(fmt.Sprintf("auction can't be closed as current block height (%d) is before auction end height (%d)", ctx.BlockHeight(), batchAuction.EndHeight))
+		}
+	} else if ctx.BlockHeader().Time.Before(auction.GetEndTime()) {
+		return sdk.ErrInternal(fmt.Sprintf("auction can't be closed as current block time (%v) is before auction end time (%v)", ctx.BlockHeader().Time, auction.GetEndTime()))
+	}
+	// dutch auctions that haven't finished restart their price instead of closing
+	if dutchAuction, ok := auction.(*DutchAuction); ok && dutchAuction.canReset(ctx.BlockHeader().Time) {
+		dutchAuction.reset(ctx.BlockHeader().Time)
+		k.setAuction(ctx, dutchAuction)
+		return nil
+	}
+	// sealed bid auctions move on to the reveal phase at the end of the commit phase
+	if sealedBidAuction, ok := auction.(*SealedBidAuction); ok && sealedBidAuction.Phase == CommitPhase {
+		sealedBidAuction.startRevealPhase()
+		k.setAuction(ctx, sealedBidAuction)
+		return nil
+	}
+	// auctions without bids restart, return the lot to the initiator, or sell it to the backstop, depending on their type
+	params := k.GetParams(ctx)
+	outcome := ""
+	restarts := k.getRestartCount(ctx, auctionID)
+	if auction.GetBidder().Equals(auction.GetInitiator()) {
+		outcome = params.NoBidOutcomes.Get(auction.GetType())
+		switch {
+		case outcome == RestartOutcome && restarts < params.MaxRestarts:
+			return k.restartAuction(ctx, auction, restarts)
+		case outcome == RestartOutcome: // out of restarts
+			outcome = ReturnOutcome
+		case outcome == BackstopOutcome:
+			backstopped, err := k.sellToBackstop(ctx, auction, params.Backstop)
+			if err != nil {
+				// don't halt the chain, the lot can be returned to the initiator instead
+				ctx.Logger().Error(fmt.Sprintf("could not sell the lot of auction %d to the backstop, returning it to the initiator: %s", auctionID, err))
+				outcome = ReturnOutcome
+			} else {
+				auction = backstopped
+			}
+		}
+	}
+	// payout the lot to the last bidder, and their bid to the initiator
+	coinOutputs, coinInputs := auction.GetPayout()
+	// refund what is left in escrow of any proxy bids
+	for _, p := range k.getProxyBids(ctx, auctionID) {
+		if refund := proxyBidRefund(auction, p); refund.IsPositive() {
+			coinOutputs = append(coinOutputs, bankOutput{EscrowAccountAddress, sdk.NewCoins(refund)})
+			coinInputs = append(coinInputs, bankInput{p.Bidder, sdk.NewCoins(refund)})
+		}
+	}
+	// return the creation deposit if the auction was bid on, otherwise it is forfeited
+	deposit, found := k.getCreationDeposit(ctx, auctionID)
+	if found {
+		coinOutputs = append(coinOutputs, bankOutput{EscrowAccountAddress, sdk.NewCoins(deposit)})
+		if outcome == "" {
+			coinInputs = append(coinInputs, bankInput{auction.GetInitiator(), sdk.NewCoins(deposit)})
+		}
+	}
+	err := k.transferCoins(ctx, coinOutputs, coinInputs)
 	if err != nil {
 		return err
 	}
 
-	// delete auction from store (and queue)
+	// delete auction from store (and queue), keeping its bid history (and failure) until the retention period is over
 	k.deleteAuction(ctx, auctionID)
+	k.deleteCreationDeposit(ctx, auctionID)
+	k.deleteRestartCount(ctx, auctionID)
+	k.setProxyBids(ctx, auctionID, ProxyBids{})
+	if outcome != "" {
+		k.setFailedAuction(ctx, FailedAuction{
+			Auction:  auction,
+			Outcome:  outcome,
+			Restarts: restarts,
+			Height:   ctx.BlockHeight(),
+			Time:     ctx.BlockHeader().Time,
+		})
+	}
+	k.insertIntoBidHistoryQueue(ctx, ctx.BlockHeader().Time.Add(params.BidHistoryRetention), auctionID)
+
+	// notify the module that started the auction
+	if k.hooks != nil {
+		k.hooks.AfterAuctionClosed(ctx, auction)
+	}
 
 	return nil
 }
 
+// restartAuction starts an auction that closed without bids again, counting how many times it has restarted.
+func (k Keeper) restartAuction(ctx sdk.Context, auction Auction, restarts int) sdk.Error {
+	coinOutputs, coinInputs := auction.restart(ctx.BlockHeader().Time, k.GetParams(ctx))
+	err := k.transferCoins(ctx, coinOutputs, coinInputs)
+	if err != nil {
+		return err
+	}
+	k.setAuction(ctx, auction)
+	k.setRestartCount(ctx, auction.GetID(), restarts+1)
+	return nil
+}
+
+// sellToBackstop has the backstop buy the lot of an auction that closed without bids, returning the updated auction.
+// The auction passed in is left unchanged, so it can still be closed normally if the backstop can't pay.
+func (k Keeper) sellToBackstop(ctx sdk.Context, auction Auction, backstop sdk.AccAddress) (Auction, sdk.Error) {
+	backstopped, found := k.GetAuction(ctx, auction.GetID()) // a copy of the auction
+	if !found {
+		return nil, sdk.ErrInternal("auction doesn't exist")
+	}
+	coinOutputs, coinInputs := backstopped.backstop(backstop)
+	err := k.transferCoins(ctx, coinOutputs, coinInputs)
+	if err != nil {
+		return nil, err
+	}
+	return backstopped, nil
+}
+
+// FreezeAuction stops an auction from accepting bids, and from closing, so it can be looked into and cancelled if needed.
+// It is taken out of the queue, and stays frozen until it is cancelled. Freezing an auction that is already frozen does nothing.
+func (k Keeper) FreezeAuction(ctx sdk.Context, auctionID ID) sdk.Error {
+	auction, found := k.GetAuction(ctx, auctionID)
+	if !found {
+		return sdk.ErrInternal("auction doesn't exist")
+	}
+	if k.IsAuctionFrozen(ctx, auctionID) {
+		return nil
+	}
+	k.removeAuctionFromQueue(ctx, auction)
+	k.setFrozenHeight(ctx, auctionID, ctx.BlockHeight())
+	return nil
+}
+
+// CancelAuction ends an auction without a winner. Bids held in escrow (including proxy bids, sealed bid deposits and batch orders) are refunded, and the lot is returned to the initiator along with any creation deposit.
+// Hooks are not called, so the module cancelling an auction must update its own records. The auction is returned as it was when cancelled.
+func (k Keeper) CancelAuction(ctx sdk.Context, auctionID ID) (Auction, sdk.Error) {
+	auction, found := k.GetAuction(ctx, auctionID)
+	if !found {
+		return nil, sdk.ErrInternal("auction doesn't exist")
+	}
+	coinOutputs, coinInputs := auction.cancel()
+	for _, p := range k.getProxyBids(ctx, auctionID) {
+		if refund := proxyBidRefund(auction, p); refund.IsPositive() {
+			coinOutputs = append(coinOutputs, bankOutput{EscrowAccountAddress, sdk.NewCoins(refund)})
+			coinInputs = append(coinInputs, bankInput{p.Bidder, sdk.NewCoins(refund)})
+		}
+	}
+	if deposit, found := k.getCreationDeposit(ctx, auctionID); found {
+		coinOutputs = append(coinOutputs, bankOutput{EscrowAccountAddress, sdk.NewCoins(deposit)})
+		coinInputs = append(coinInputs, bankInput{auction.GetInitiator(), sdk.NewCoins(deposit)})
+	}
+	err := k.transferCoins(ctx, coinOutputs, coinInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	// delete the auction like it had closed, keeping its bid history until the retention period is over
+	k.deleteAuction(ctx, auctionID)
+	k.deleteCreationDeposit(ctx, auctionID)
+	k.deleteRestartCount(ctx, auctionID)
+	k.setProxyBids(ctx, auctionID, ProxyBids{})
+	k.deleteFrozenHeight(ctx, auctionID)
+	k.insertIntoBidHistoryQueue(ctx, ctx.BlockHeader().Time.Add(k.GetParams(ctx).BidHistoryRetention), auctionID)
+	return auction, nil
+}
+
 // ---------- Store methods ----------
 // Use these to add and remove auction from the store.
 
@@ -191,7 +922,7 @@ func (k Keeper) setAuction(ctx sdk.Context, auction Auction) {
 	// remove the auction from the queue if it is already in there
 	existingAuction, found := k.GetAuction(ctx, auction.GetID())
 	if found {
-		k.removeFromQueue(ctx, existingAuction.GetEndTime(), existingAuction.GetID())
+		k.removeAuctionFromQueue(ctx, existingAuction)
 	}
 
 	// store auction
@@ -199,10 +930,26 @@ func (k Keeper) setAuction(ctx sdk.Context, auction Auction) {
 	bz := k.cdc.MustMarshalBinaryLengthPrefixed(auction)
 	store.Set(k.getAuctionKey(auction.GetID()), bz)
 
-	// add to the queue
+	// add to the queue, batch auctions close by block height so they have a queue of their own
+	if k.IsAuctionFrozen(ctx, auction.GetID()) {
+		return // frozen auctions don't close
+	}
+	if batchAuction, ok := auction.(*BatchAuction); ok {
+		k.insertIntoBatchQueue(ctx, batchAuction.EndHeight, auction.GetID())
+		return
+	}
 	k.insertIntoQueue(ctx, auction.GetEndTime(), auction.GetID())
 }
 
+// removeAuctionFromQueue removes an auction from whichever queue it is in
+func (k Keeper) removeAuctionFromQueue(ctx sdk.Context, auction Auction) {
+	if batchAuction, ok := auction.(*BatchAuction); ok {
+		k.removeFromBatchQueue(ctx, batchAuction.EndHeight, auction.GetID())
+		return
+	}
+	k.removeFromQueue(ctx, auction.GetEndTime(), auction.GetID())
+}
+
 // getAuction gets an auction from the store by auctionID
 func (k Keeper) GetAuction(ctx sdk.Context, auctionID ID) (Auction, bool) {
 	var auction Auction
@@ -222,7 +969,7 @@ func (k Keeper) deleteAuction(ctx sdk.Context, auctionID ID) {
 	// remove from queue
 	auction, found := k.GetAuction(ctx, auctionID)
 	if found {
-		k.removeFromQueue(ctx, auction.GetEndTime(), auctionID)
+		k.removeAuctionFromQueue(ctx, auction)
 	}
 
 	// delete auction
@@ -230,6 +977,221 @@ func (k Keeper) deleteAuction(ctx sdk.Context, auctionID ID) {
 	store.Delete(k.getAuctionKey(auctionID))
 }
 
+// setCreationDeposit records the deposit held in escrow for an auction started by a user
+func (k Keeper) setCreationDeposit(ctx sdk.Context, auctionID ID, deposit sdk.Coin) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(k.getCreationDepositKey(auctionID), k.cdc.MustMarshalBinaryLengthPrefixed(deposit))
+}
+
+// getCreationDeposit gets the deposit held in escrow for an auction, auctions not started by users have none
+func (k Keeper) getCreationDeposit(ctx sdk.Context, auctionID ID) (sdk.Coin, bool) {
+	var deposit sdk.Coin
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getCreationDepositKey(auctionID))
+	if bz == nil {
+		return deposit, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &deposit)
+	return deposit, true
+}
+
+func (k Keeper) deleteCreationDeposit(ctx sdk.Context, auctionID ID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(k.getCreationDepositKey(auctionID))
+}
+
+// IsAuctionFrozen returns true if an auction has been frozen, see FreezeAuction
+func (k Keeper) IsAuctionFrozen(ctx sdk.Context, auctionID ID) bool {
+	_, found := k.GetFrozenHeight(ctx, auctionID)
+	return found
+}
+
+// GetFrozenHeight returns the block height an auction was frozen at
+func (k Keeper) GetFrozenHeight(ctx sdk.Context, auctionID ID) (int64, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getFrozenHeightKey(auctionID))
+	if bz == nil {
+		return 0, false
+	}
+	var height int64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &height)
+	return height, true
+}
+
+func (k Keeper) setFrozenHeight(ctx sdk.Context, auctionID ID, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(k.getFrozenHeightKey(auctionID), k.cdc.MustMarshalBinaryLengthPrefixed(height))
+}
+
+func (k Keeper) deleteFrozenHeight(ctx sdk.Context, auctionID ID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(k.getFrozenHeightKey(auctionID))
+}
+
+// getRestartCount gets the number of times an auction has restarted after closing without bids
+func (k Keeper) getRestartCount(ctx sdk.Context, auctionID ID) int {
+	var restarts int
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getRestartCountKey(auctionID))
+	if bz == nil {
+		return 0
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &restarts)
+	return restarts
+}
+
+func (k Keeper) setRestartCount(ctx sdk.Context, auctionID ID, restarts int) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(k.getRestartCountKey(auctionID), k.cdc.MustMarshalBinaryLengthPrefixed(restarts))
+}
+
+func (k Keeper) deleteRestartCount(ctx sdk.Context, auctionID ID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(k.getRestartCountKey(auctionID))
+}
+
+// getProxyBids gets the proxy bids on an auction, in the order they were placed
+func (k Keeper) getProxyBids(ctx sdk.Context, auctionID ID) ProxyBids {
+	proxyBids := ProxyBids{}
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getProxyBidsKey(auctionID))
+	if bz == nil {
+		return proxyBids
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &proxyBids)
+	return proxyBids
+}
+
+// setProxyBids stores the proxy bids on an auction, deleting them if there are none
+func (k Keeper) setProxyBids(ctx sdk.Context, auctionID ID, proxyBids ProxyBids) {
+	store := ctx.KVStore(k.storeKey)
+	if len(proxyBids) == 0 {
+		store.Delete(k.getProxyBidsKey(auctionID))
+		return
+	}
+	store.Set(k.getProxyBidsKey(auctionID), k.cdc.MustMarshalBinaryLengthPrefixed(proxyBids))
+}
+
+// setFailedAuction records an auction that closed without bids
+func (k Keeper) setFailedAuction(ctx sdk.Context, failedAuction FailedAuction) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(k.getFailedAuctionKey(failedAuction.Auction.GetID()), k.cdc.MustMarshalBinaryLengthPrefixed(failedAuction))
+}
+
+// GetFailedAuction gets the record of an auction that closed without bids. Records are deleted along with the auction's bid history.
+func (k Keeper) GetFailedAuction(ctx sdk.Context, auctionID ID) (FailedAuction, bool) {
+	var failedAuction FailedAuction
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getFailedAuctionKey(auctionID))
+	if bz == nil {
+		return failedAuction, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &failedAuction)
+	return failedAuction, true
+}
+
+// GetFailedAuctions returns the records of all auctions that closed without bids, ordered by auction ID.
+func (k Keeper) GetFailedAuctions(ctx sdk.Context) FailedAuctions {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, failedAuctionKeyPrefix)
+	defer iter.Close()
+	failedAuctions := FailedAuctions{}
+	for ; iter.Valid(); iter.Next() {
+		var failedAuction FailedAuction
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &failedAuction)
+		failedAuctions = append(failedAuctions, failedAuction)
+	}
+	// keys are not stored in numerical order
+	sort.Slice(failedAuctions, func(i, j int) bool { return failedAuctions[i].Auction.GetID() < failedAuctions[j].Auction.GetID() })
+	return failedAuctions
+}
+
+func (k Keeper) deleteFailedAuction(ctx sdk.Context, auctionID ID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(k.getFailedAuctionKey(auctionID))
+}
+
+// ---------- Bid history methods ----------
+// Each auction has an append-only log of the bids placed on it, kept until some time after the auction closes.
+
+func (k Keeper) newBidRecord(ctx sdk.Context, auctionID ID, bidder sdk.AccAddress, bid sdk.Coin, lot sdk.Coins, phase string) BidRecord {
+	return BidRecord{
+		AuctionID: auctionID,
+		Bidder:    bidder,
+		Bid:       bid,
+		Lot:       lot,
+		Height:    ctx.BlockHeight(),
+		Time:      ctx.BlockHeader().Time,
+		Phase:     phase,
+	}
+}
+
+// appendBid adds a bid to the end of an auction's bid history
+func (k Keeper) appendBid(ctx sdk.Context, record BidRecord) {
+	store := ctx.KVStore(k.storeKey)
+	var count uint64
+	if bz := store.Get(k.getBidCountKey(record.AuctionID)); bz != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &count)
+	}
+	store.Set(k.getBidKey(record.AuctionID, count), k.cdc.MustMarshalBinaryLengthPrefixed(record))
+	store.Set(k.getBidCountKey(record.AuctionID), k.cdc.MustMarshalBinaryLengthPrefixed(count+1))
+}
+
+// GetBids returns the bid history of an auction, oldest first. It is empty for unknown auctions and auctions whose history has been pruned.
+func (k Keeper) GetBids(ctx sdk.Context, auctionID ID) BidRecords {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, k.getBidKeyPrefix(auctionID))
+	defer iter.Close()
+	bids := BidRecords{}
+	for ; iter.Valid(); iter.Next() {
+		var record BidRecord
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &record)
+		bids = append(bids, record)
+	}
+	return bids
+}
+
+// deleteBids removes the bid history of an auction
+func (k Keeper) deleteBids(ctx sdk.Context, auctionID ID) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, k.getBidKeyPrefix(auctionID))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	store.Delete(k.getBidCountKey(auctionID))
+}
+
+// PruneBidHistories deletes the bid histories (and failure records) of closed auctions that have passed the retention period.
+func (k Keeper) PruneBidHistories(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(bidHistoryQueueKeyPrefix, sdk.PrefixEndBytes(getBidHistoryQueueElementKeyPrefix(ctx.BlockHeader().Time)))
+	var keys [][]byte
+	var auctionIDs []ID
+	for ; iter.Valid(); iter.Next() {
+		var auctionID ID
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &auctionID)
+		keys = append(keys, iter.Key())
+		auctionIDs = append(auctionIDs, auctionID)
+	}
+	iter.Close()
+	for i, auctionID := range auctionIDs {
+		k.deleteBids(ctx, auctionID)
+		k.deleteFailedAuction(ctx, auctionID)
+		store.Delete(keys[i])
+	}
+}
+
+// insertIntoBidHistoryQueue schedules an auction's bid history to be deleted at pruneTime
+func (k Keeper) insertIntoBidHistoryQueue(ctx sdk.Context, pruneTime time.Time, auctionID ID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(getBidHistoryQueueElementKey(pruneTime, auctionID), k.cdc.MustMarshalBinaryLengthPrefixed(auctionID))
+}
+
 // ---------- Queue and key methods ----------
 // These are lower level function used by the store methods above.
 
@@ -237,11 +1199,38 @@ func (k Keeper) getNextAuctionIDKey() []byte {
 	return []byte("nextAuctionID")
 }
 func (k Keeper) getAuctionKey(auctionID ID) []byte {
-	return []byte(fmt.Sprintf("auctions:%d", auctionID))
+	return []byte(fmt.Sprintf("%s%d", auctionKeyPrefix, auctionID))
+}
+
+func (k Keeper) getCreationDepositKey(auctionID ID) []byte {
+	return []byte(fmt.Sprintf("%s%d", creationDepositKeyPrefix, auctionID))
+}
+
+func (k Keeper) getRestartCountKey(auctionID ID) []byte {
+	return []byte(fmt.Sprintf("%s%d", restartCountKeyPrefix, auctionID))
+}
+func (k Keeper) getProxyBidsKey(auctionID ID) []byte {
+	return []byte(fmt.Sprintf("%s%d", proxyBidsKeyPrefix, auctionID))
+}
+func (k Keeper) getFailedAuctionKey(auctionID ID) []byte {
+	return []byte(fmt.Sprintf("%s%d", failedAuctionKeyPrefix, auctionID))
+}
+func (k Keeper) getFrozenHeightKey(auctionID ID) []byte {
+	return []byte(fmt.Sprintf("%s%d", frozenHeightKeyPrefix, auctionID))
+}
+
+func (k Keeper) getBidKeyPrefix(auctionID ID) []byte {
+	return []byte(fmt.Sprintf("%s%d:", bidKeyPrefix, auctionID)) // the trailing delimiter stops the bids of auction 1 matching auction 10
+}
+func (k Keeper) getBidKey(auctionID ID, index uint64) []byte {
+	return append(k.getBidKeyPrefix(auctionID), sdk.Uint64ToBigEndian(index)...)
+}
+func (k Keeper) getBidCountKey(auctionID ID) []byte {
+	return []byte(fmt.Sprintf("%s%d", bidCountKeyPrefix, auctionID))
 }
 
 // Inserts a AuctionID into the queue at endTime
-func (k Keeper) insertIntoQueue(ctx sdk.Context, endTime endTime, auctionID ID) {
+func (k Keeper) insertIntoQueue(ctx sdk.Context, endTime time.Time, auctionID ID) {
 	// get the store
 	store := ctx.KVStore(k.storeKey)
 	// marshal thing to be inserted
@@ -254,13 +1243,58 @@ func (k Keeper) insertIntoQueue(ctx sdk.Context, endTime endTime, auctionID ID)
 }
 
 // removes an auctionID from the queue
-func (k Keeper) removeFromQueue(ctx sdk.Context, endTime endTime, auctionID ID) {
+func (k Keeper) removeFromQueue(ctx sdk.Context, endTime time.Time, auctionID ID) {
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(getQueueElementKey(endTime, auctionID))
 }
 
+// getExpiredQueueIterator returns an iterator for all the auctions in the queue that ended before currentTime, earliest first
+func (k Keeper) getExpiredQueueIterator(ctx sdk.Context, currentTime time.Time) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(
+		queueKeyPrefix,
+		getQueueElementKeyPrefix(currentTime), // exclusive, so auctions ending at currentTime are left in the queue
+	)
+}
+
+// Inserts a batch AuctionID into the batch queue at endHeight
+func (k Keeper) insertIntoBatchQueue(ctx sdk.Context, endHeight int64, auctionID ID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(getBatchQueueElementKey(endHeight, auctionID), k.cdc.MustMarshalBinaryLengthPrefixed(auctionID))
+}
+
+// removes a batch AuctionID from the batch queue
+func (k Keeper) removeFromBatchQueue(ctx sdk.Context, endHeight int64, auctionID ID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(getBatchQueueElementKey(endHeight, auctionID))
+}
+
+// getExpiredBatchQueueIterator returns an iterator for all the batch auctions in the batch queue that ended before currentHeight, earliest first
+func (k Keeper) getExpiredBatchQueueIterator(ctx sdk.Context, currentHeight int64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(
+		batchQueueKeyPrefix,
+		getBatchQueueElementKeyPrefix(currentHeight), // exclusive, so auctions ending at currentHeight are left in the queue
+	)
+}
+
+// Inserts an AuctionID into the retry queue, to try closing it again at retryTime
+func (k Keeper) insertIntoRetryQueue(ctx sdk.Context, retryTime time.Time, auctionID ID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(getRetryQueueElementKey(retryTime, auctionID), k.cdc.MustMarshalBinaryLengthPrefixed(auctionID))
+}
+
+// getExpiredRetryQueueIterator returns an iterator for all the auctions in the retry queue due to be retried before currentTime, earliest first
+func (k Keeper) getExpiredRetryQueueIterator(ctx sdk.Context, currentTime time.Time) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(
+		retryQueueKeyPrefix,
+		getRetryQueueElementKeyPrefix(currentTime),
+	)
+}
+
 // Returns an iterator for all the auctions in the queue that expire by endTime
-func (k Keeper) getQueueIterator(ctx sdk.Context, endTime endTime) sdk.Iterator { // TODO rename to "getAuctionsByExpiry" ?
+func (k Keeper) getQueueIterator(ctx sdk.Context, endTime time.Time) sdk.Iterator { // TODO rename to "getAuctionsByExpiry" ?
 	// get store
 	store := ctx.KVStore(k.storeKey)
 	// get an interator
@@ -270,28 +1304,104 @@ func (k Keeper) getQueueIterator(ctx sdk.Context, endTime endTime) sdk.Iterator
 	)
 }
 
+// IterateAuctions calls cb on each auction in the store, stopping early if cb returns true.
+func (k Keeper) IterateAuctions(ctx sdk.Context, cb func(auction Auction) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, auctionKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var auction Auction
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &auction)
+		if cb(auction) {
+			break
+		}
+	}
+}
+
 // GetAuctionIterator returns an iterator over all auctions in the store
 func (k Keeper) GetAuctionIterator(ctx sdk.Context) sdk.Iterator {
 	store := ctx.KVStore(k.storeKey)
-	return sdk.KVStorePrefixIterator(store, nil)
+	return sdk.KVStorePrefixIterator(store, auctionKeyPrefix)
 }
 
+var auctionKeyPrefix = []byte("auctions:")
 var queueKeyPrefix = []byte("queue")
+var creationDepositKeyPrefix = []byte("creationDeposits:")
+var bidKeyPrefix = []byte("bids:")
+var bidCountKeyPrefix = []byte("bidCounts:")
+var restartCountKeyPrefix = []byte("restartCounts:")
+var proxyBidsKeyPrefix = []byte("proxyBids:")
+var failedAuctionKeyPrefix = []byte("failedAuctions:")
+var bidHistoryQueueKeyPrefix = []byte("bidHistoryQueue")
+var batchQueueKeyPrefix = []byte("batchQueue")
+var retryQueueKeyPrefix = []byte("retryQueue")
+var frozenHeightKeyPrefix = []byte("frozenHeights:")
 var keyDelimiter = []byte(":")
 
 // Returns half a key for an auctionID in the queue, it missed the id off the end
-func getQueueElementKeyPrefix(endTime endTime) []byte {
+func getQueueElementKeyPrefix(endTime time.Time) []byte {
 	return bytes.Join([][]byte{
 		queueKeyPrefix,
-		sdk.Uint64ToBigEndian(uint64(endTime)), // TODO check this gives correct ordering
+		sdk.FormatTimeBytes(endTime),
 	}, keyDelimiter)
 }
 
 // Returns the key for an auctionID in the queue
-func getQueueElementKey(endTime endTime, auctionID ID) []byte {
+func getQueueElementKey(endTime time.Time, auctionID ID) []byte {
 	return bytes.Join([][]byte{
 		queueKeyPrefix,
-		sdk.Uint64ToBigEndian(uint64(endTime)), // TODO check this gives correct ordering
+		sdk.FormatTimeBytes(endTime),
+		sdk.Uint64ToBigEndian(uint64(auctionID)),
+	}, keyDelimiter)
+}
+
+// Returns half a key for an auctionID in the bid history queue, it missed the id off the end
+func getBidHistoryQueueElementKeyPrefix(pruneTime time.Time) []byte {
+	return bytes.Join([][]byte{
+		bidHistoryQueueKeyPrefix,
+		sdk.FormatTimeBytes(pruneTime),
+	}, keyDelimiter)
+}
+
+// Returns the key for an auctionID in the bid history queue
+func getBidHistoryQueueElementKey(pruneTime time.Time, auctionID ID) []byte {
+	return bytes.Join([][]byte{
+		bidHistoryQueueKeyPrefix,
+		sdk.FormatTimeBytes(pruneTime),
+		sdk.Uint64ToBigEndian(uint64(auctionID)),
+	}, keyDelimiter)
+}
+
+// Returns half a key for an auctionID in the batch queue, it missed the id off the end
+func getBatchQueueElementKeyPrefix(endHeight int64) []byte {
+	return bytes.Join([][]byte{
+		batchQueueKeyPrefix,
+		sdk.Uint64ToBigEndian(uint64(endHeight)),
+	}, keyDelimiter)
+}
+
+// Returns the key for an auctionID in the batch queue
+func getBatchQueueElementKey(endHeight int64, auctionID ID) []byte {
+	return bytes.Join([][]byte{
+		batchQueueKeyPrefix,
+		sdk.Uint64ToBigEndian(uint64(endHeight)),
+		sdk.Uint64ToBigEndian(uint64(auctionID)),
+	}, keyDelimiter)
+}
+
+// Returns half a key for an auctionID in the retry queue, it missed the id off the end
+func getRetryQueueElementKeyPrefix(retryTime time.Time) []byte {
+	return bytes.Join([][]byte{
+		retryQueueKeyPrefix,
+		sdk.FormatTimeBytes(retryTime),
+	}, keyDelimiter)
+}
+
+// Returns the key for an auctionID in the retry queue
+func getRetryQueueElementKey(retryTime time.Time, auctionID ID) []byte {
+	return bytes.Join([][]byte{
+		retryQueueKeyPrefix,
+		sdk.FormatTimeBytes(retryTime),
 		sdk.Uint64ToBigEndian(uint64(auctionID)),
 	}, keyDelimiter)
 }
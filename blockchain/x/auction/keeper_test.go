@@ -2,6 +2,7 @@ package auction
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
@@ -14,7 +15,7 @@ func TestKeeper_SetGetDeleteAuction(t *testing.T) {
 	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
 	mapp.BeginBlock(abci.RequestBeginBlock{Header: header}) // Without this it panics about "invalid memory address or nil pointer dereference"
 	ctx := mapp.BaseApp.NewContext(false, header)
-	auction, _ := NewForwardAuction(addresses[0], sdk.NewInt64Coin("usdx", 100), sdk.NewInt64Coin("kava", 0), endTime(1000))
+	auction, _ := NewForwardAuction(addresses[0], sdk.NewInt64Coin("usdx", 100), sdk.NewInt64Coin("kava", 0), time.Unix(1000, 0).UTC())
 	id := ID(5)
 	auction.SetID(id)
 
@@ -28,7 +29,7 @@ func TestKeeper_SetGetDeleteAuction(t *testing.T) {
 	t.Log(auction)
 	t.Log(readAuction.GetID())
 	// check auction is in queue
-	iter := keeper.getQueueIterator(ctx, 100000)
+	iter := keeper.getQueueIterator(ctx, time.Unix(100000, 0))
 	require.Equal(t, 1, len(convertIteratorToSlice(keeper, iter)))
 	iter.Close()
 
@@ -39,7 +40,7 @@ func TestKeeper_SetGetDeleteAuction(t *testing.T) {
 	_, found = keeper.GetAuction(ctx, id)
 	require.False(t, found)
 	// check auction not in queue
-	iter = keeper.getQueueIterator(ctx, 100000)
+	iter = keeper.getQueueIterator(ctx, time.Unix(100000, 0))
 	require.Equal(t, 0, len(convertIteratorToSlice(keeper, iter)))
 	iter.Close()
 
@@ -54,16 +55,16 @@ func TestKeeper_ExpiredAuctionQueue(t *testing.T) {
 	ctx := mapp.BaseApp.NewContext(false, header)
 	// create an example queue
 	type queue []struct {
-		endTime   endTime
+		endTime   time.Time
 		auctionID ID
 	}
-	q := queue{{1000, 0}, {1300, 2}, {5200, 1}}
+	q := queue{{time.Unix(1000, 0), 0}, {time.Unix(1300, 2), 2}, {time.Unix(5200, 0), 1}}
 
 	// write and read queue
 	for _, v := range q {
 		keeper.insertIntoQueue(ctx, v.endTime, v.auctionID)
 	}
-	iter := keeper.getQueueIterator(ctx, 1000)
+	iter := keeper.getQueueIterator(ctx, time.Unix(1000, 0))
 
 	// check before and after match
 	i := 0
@@ -85,3 +86,582 @@ func convertIteratorToSlice(keeper Keeper, iterator sdk.Iterator) []ID {
 	}
 	return queue
 }
+
+func TestKeeper_PlaceBid(t *testing.T) {
+	// setup keeper, create auction
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	seller, buyer1, buyer2 := addresses[0], addresses[1], addresses[2]
+	auctionID, err := keeper.StartForwardAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 0))
+	require.NoError(t, err)
+
+	// bidding more than the bidder has fails without moving any coins
+	err = keeper.PlaceBid(ctx, auctionID, buyer1, sdk.NewInt64Coin("token2", 101), sdk.NewInt64Coin("token1", 20))
+	require.Error(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	auction, _ := keeper.GetAuction(ctx, auctionID)
+	require.Equal(t, seller, auction.(*ForwardAuction).Bidder)
+
+	// a first bid is held in escrow
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer1, sdk.NewInt64Coin("token2", 10), sdk.NewInt64Coin("token1", 20)))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 90)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token2", 10)), keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress))
+
+	// the same bidder raising their bid only pays the difference
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer1, sdk.NewInt64Coin("token2", 95), sdk.NewInt64Coin("token1", 20)))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 5)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token2", 95)), keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress))
+
+	// a failure part way through the transfers leaves all balances and the auction untouched
+	failingKeeper := keeper
+	failingKeeper.bankKeeper = failingBankKeeper{keeper.bankKeeper, buyer1} // refunding buyer1 will fail
+	err = failingKeeper.PlaceBid(ctx, auctionID, buyer2, sdk.NewInt64Coin("token2", 100), sdk.NewInt64Coin("token1", 20))
+	require.Error(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 5)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, buyer2))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token2", 95)), keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress))
+	auction, _ = keeper.GetAuction(ctx, auctionID)
+	require.Equal(t, buyer1, auction.(*ForwardAuction).Bidder)
+
+	// outbidding refunds the previous bidder from escrow
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer2, sdk.NewInt64Coin("token2", 100), sdk.NewInt64Coin("token1", 20)))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100)), keeper.bankKeeper.GetCoins(ctx, buyer2))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress))
+}
+
+func TestKeeper_PlaceBidWrongDenom(t *testing.T) {
+	tests := []struct {
+		name        string
+		auctionType string
+		reverse     bool // move a forward reverse auction into its reverse phase first
+		bid         sdk.Coin
+		lot         sdk.Coin
+	}{
+		{"forwardBid", ForwardAuctionType, false, c("btc", 10), c("token1", 20)},
+		{"reverseLot", ReverseAuctionType, false, c("token2", 50), c("btc", 5)},
+		{"forwardReverseBid", ForwardReverseAuctionType, false, c("btc", 10), c("token1", 20)},
+		{"forwardReverseLot", ForwardReverseAuctionType, false, c("token2", 10), c("btc", 20)},
+		{"reversePhaseLot", ForwardReverseAuctionType, true, c("token2", 50), c("btc", 5)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// setup keeper, create auction
+			mapp, keeper, addresses, _ := setUpMockApp()
+			header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+			mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+			ctx := mapp.BaseApp.NewContext(false, header)
+			seller, buyer := addresses[0], addresses[1]
+			var auctionID ID
+			var err sdk.Error
+			switch tc.auctionType {
+			case ForwardAuctionType:
+				auctionID, err = keeper.StartForwardAuction(ctx, seller, c("token1", 20), c("token2", 0))
+			case ReverseAuctionType:
+				auctionID, err = keeper.StartReverseAuction(ctx, seller, c("token2", 50), c("token1", 10))
+			case ForwardReverseAuctionType:
+				auctionID, err = keeper.StartForwardReverseAuction(ctx, seller, c("token1", 20), c("token2", 50), sdk.ZeroInt(), addresses[2])
+			}
+			require.NoError(t, err)
+			if tc.reverse {
+				require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer, c("token2", 50), c("token1", 20)))
+			}
+
+			// a bid or lot in the wrong denom is rejected rather than panicking, leaving the auction unchanged
+			before, _ := keeper.GetAuction(ctx, auctionID)
+			require.NotPanics(t, func() {
+				require.Error(t, keeper.PlaceBid(ctx, auctionID, buyer, tc.bid, tc.lot))
+			})
+			after, _ := keeper.GetAuction(ctx, auctionID)
+			require.Equal(t, before, after)
+		})
+	}
+}
+
+func TestKeeper_PlacePartialBid(t *testing.T) {
+	// setup keeper, create a forward reverse auction with a bid on the whole lot
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	seller, buyer1, buyer2, otherPerson := addresses[0], addresses[1], addresses[2], addresses[3]
+	auctionID, err := keeper.StartForwardReverseAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 50), sdk.ZeroInt(), otherPerson)
+	require.NoError(t, err)
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer1, sdk.NewInt64Coin("token2", 10), sdk.NewInt64Coin("token1", 20)))
+
+	// a failed partial bid doesn't split the auction
+	_, err = keeper.PlacePartialBid(ctx, auctionID, buyer2, sdk.NewInt64Coin("token2", 2), sdk.NewInt64Coin("token1", 5))
+	require.Error(t, err)
+	auction, _ := keeper.GetAuction(ctx, auctionID)
+	require.Equal(t, sdk.NewInt64Coin("token1", 20), auction.(*ForwardReverseAuction).Lot)
+	_, found := keeper.GetAuction(ctx, auctionID+1)
+	require.False(t, found)
+
+	// a partial bid splits off a child auction with its share of the max bid and the existing bid
+	childID, err := keeper.PlacePartialBid(ctx, auctionID, buyer2, sdk.NewInt64Coin("token2", 3), sdk.NewInt64Coin("token1", 5))
+	require.NoError(t, err)
+	require.Equal(t, auctionID+1, childID)
+	auction, _ = keeper.GetAuction(ctx, auctionID)
+	parent := auction.(*ForwardReverseAuction)
+	require.Equal(t, sdk.NewInt64Coin("token1", 15), parent.Lot)
+	require.Equal(t, sdk.NewInt64Coin("token2", 38), parent.MaxBid)
+	require.Equal(t, sdk.NewInt64Coin("token2", 8), parent.Bid)
+	require.Equal(t, buyer1, parent.Bidder)
+	auction, _ = keeper.GetAuction(ctx, childID)
+	child := auction.(*ForwardReverseAuction)
+	require.Equal(t, sdk.NewInt64Coin("token1", 5), child.Lot)
+	require.Equal(t, sdk.NewInt64Coin("token2", 12), child.MaxBid)
+	require.Equal(t, sdk.NewInt64Coin("token2", 3), child.Bid)
+	require.Equal(t, buyer2, child.Bidder)
+	require.Equal(t, otherPerson, child.OtherPerson)
+
+	// the existing bidder is refunded their share of the bid on the child
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 92)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 97)), keeper.bankKeeper.GetCoins(ctx, buyer2))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token2", 11)), keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress))
+}
+
+func TestKeeper_ProxyBids(t *testing.T) {
+	// setup keeper, create a forward auction
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	seller, buyer1, buyer2, buyer3, buyer4 := addresses[0], addresses[1], addresses[2], addresses[3], addresses[4]
+	auctionID, err := keeper.StartForwardAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 0))
+	require.NoError(t, err)
+	checkAuction := func(bidder sdk.AccAddress, bid int64) {
+		auction, _ := keeper.GetAuction(ctx, auctionID)
+		require.Equal(t, bidder, auction.(*ForwardAuction).Bidder)
+		require.Equal(t, sdk.NewInt64Coin("token2", bid), auction.(*ForwardAuction).Bid)
+	}
+
+	// a proxy bid escrows its whole max bid and places the smallest bid possible
+	require.NoError(t, keeper.PlaceProxyBid(ctx, auctionID, buyer1, sdk.NewInt64Coin("token2", 50), sdk.NewInt64Coin("token1", 0)))
+	checkAuction(buyer1, 1)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 50)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token2", 50)), keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress))
+
+	// explicit bids are outbid by the minimum increment
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer2, sdk.NewInt64Coin("token2", 20), sdk.NewInt64Coin("token1", 20)))
+	checkAuction(buyer1, 21)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, buyer2))
+
+	// a weaker proxy bid is beaten by the minimum increment above its limit in one bid, then refunded
+	require.NoError(t, keeper.PlaceProxyBid(ctx, auctionID, buyer3, sdk.NewInt64Coin("token2", 30), sdk.NewInt64Coin("token1", 0)))
+	checkAuction(buyer1, 31)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, buyer3))
+
+	// an equal proxy bid loses to the one placed first, which bids its whole limit
+	require.NoError(t, keeper.PlaceProxyBid(ctx, auctionID, buyer4, sdk.NewInt64Coin("token2", 50), sdk.NewInt64Coin("token1", 0)))
+	checkAuction(buyer1, 50)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, buyer4))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token2", 50)), keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress))
+
+	// proxy bids that can't beat the current bid, or are on the wrong denom, are rejected
+	require.Error(t, keeper.PlaceProxyBid(ctx, auctionID, buyer4, sdk.NewInt64Coin("token2", 51), sdk.NewInt64Coin("token1", 0)))
+	require.Error(t, keeper.PlaceProxyBid(ctx, auctionID, buyer4, sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token1", 0)))
+	require.Error(t, keeper.PlaceProxyBid(ctx, auctionID, seller, sdk.NewInt64Coin("token2", 80), sdk.NewInt64Coin("token1", 0)))
+
+	// proxy bids are recorded in the bid history
+	bids := keeper.GetBids(ctx, auctionID)
+	require.Len(t, bids, 5)
+	for i, expectedProxy := range []bool{true, false, true, true, true} {
+		require.Equal(t, expectedProxy, bids[i].Proxy)
+	}
+
+	// the winning proxy bid pays its last bid
+	ctx = ctx.WithBlockTime(header.Time.Add(DefaultBidDuration + time.Second))
+	BeginBlocker(ctx, keeper)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 120), sdk.NewInt64Coin("token2", 50)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 150)), keeper.bankKeeper.GetCoins(ctx, seller))
+	require.True(t, keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress).Empty())
+}
+
+func TestKeeper_ProxyBids_ForwardReverse(t *testing.T) {
+	// setup keeper, create a forward reverse auction
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	seller, buyer1, buyer2, buyer3, otherPerson := addresses[0], addresses[1], addresses[2], addresses[3], addresses[4]
+	auctionID, err := keeper.StartForwardReverseAuction(ctx, seller, sdk.NewInt64Coin("token1", 10), sdk.NewInt64Coin("token2", 100), sdk.ZeroInt(), otherPerson)
+	require.NoError(t, err)
+	checkAuction := func(bidder sdk.AccAddress, bid int64, lot int64) {
+		auction, _ := keeper.GetAuction(ctx, auctionID)
+		require.Equal(t, bidder, auction.(*ForwardReverseAuction).Bidder)
+		require.Equal(t, sdk.NewInt64Coin("token2", bid), auction.(*ForwardReverseAuction).Bid)
+		require.Equal(t, sdk.NewInt64Coin("token1", lot), auction.(*ForwardReverseAuction).Lot)
+	}
+
+	// a proxy bid that can reach the reverse phase beats one that can't, in the forward phase
+	require.NoError(t, keeper.PlaceProxyBid(ctx, auctionID, buyer2, sdk.NewInt64Coin("token2", 60), sdk.NewInt64Coin("token1", 0)))
+	checkAuction(buyer2, 1, 10)
+	require.NoError(t, keeper.PlaceProxyBid(ctx, auctionID, buyer1, sdk.NewInt64Coin("token2", 100), sdk.NewInt64Coin("token1", 5)))
+	checkAuction(buyer1, 62, 10)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, buyer2))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress))
+
+	// in the reverse phase the proxy bid bids down the lot
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer3, sdk.NewInt64Coin("token2", 100), sdk.NewInt64Coin("token1", 8)))
+	checkAuction(buyer1, 100, 7)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, buyer3))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+
+	// an explicit bid replaces the bidder's proxy bid
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer1, sdk.NewInt64Coin("token2", 100), sdk.NewInt64Coin("token1", 6)))
+	checkAuction(buyer1, 100, 6)
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer3, sdk.NewInt64Coin("token2", 100), sdk.NewInt64Coin("token1", 5)))
+	checkAuction(buyer3, 100, 5)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+
+	// close the auction
+	ctx = ctx.WithBlockTime(header.Time.Add(DefaultBidDuration + time.Second))
+	BeginBlocker(ctx, keeper)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 105)), keeper.bankKeeper.GetCoins(ctx, buyer3))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 105), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, otherPerson))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 90), sdk.NewInt64Coin("token2", 200)), keeper.bankKeeper.GetCoins(ctx, seller))
+	require.True(t, keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress).Empty())
+
+	// proxy bids can't be placed on dutch auctions, and must bid the whole bid of reverse auctions
+	dutchID, err := keeper.StartDutchAuction(ctx, seller, sdk.NewInt64Coin("token1", 10), sdk.NewInt64Coin("token2", 100), otherPerson, sdk.NewDec(20), sdk.NewDec(5), NewPriceCurve(LinearCurve, time.Hour, sdk.MustNewDecFromStr("0.1")))
+	require.NoError(t, err)
+	require.Error(t, keeper.PlaceProxyBid(ctx, dutchID, buyer1, sdk.NewInt64Coin("token2", 50), sdk.NewInt64Coin("token1", 0)))
+	reverseID, err := keeper.StartReverseAuction(ctx, seller, sdk.NewInt64Coin("token2", 50), sdk.NewInt64Coin("token1", 10))
+	require.NoError(t, err)
+	require.Error(t, keeper.PlaceProxyBid(ctx, reverseID, buyer1, sdk.NewInt64Coin("token2", 40), sdk.NewInt64Coin("token1", 5)))
+	require.NoError(t, keeper.PlaceProxyBid(ctx, reverseID, buyer2, sdk.NewInt64Coin("token2", 50), sdk.NewInt64Coin("token1", 5)))
+}
+
+func TestKeeper_NoBidOutcomes(t *testing.T) {
+	// setup keeper, with forward auctions restarting once, and reverse and forward reverse auctions going to a backstop
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1, Time: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	seller, buyer, backstop := addresses[0], addresses[1], addresses[2]
+	params := DefaultAuctionParams()
+	params.NoBidOutcomes = NoBidOutcomes{
+		{ForwardAuctionType, RestartOutcome},
+		{ReverseAuctionType, BackstopOutcome},
+		{ForwardReverseAuctionType, BackstopOutcome},
+	}
+	params.MaxRestarts = 1
+	params.Backstop = backstop
+	require.NoError(t, params.Validate())
+	keeper.setParams(ctx, params)
+
+	forwardID, err := keeper.StartForwardAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 0))
+	require.NoError(t, err)
+	reverseID, err := keeper.StartReverseAuction(ctx, buyer, sdk.NewInt64Coin("token1", 10), sdk.NewInt64Coin("token2", 30))
+	require.NoError(t, err)
+	forwardReverseID, err := keeper.StartForwardReverseAuction(ctx, seller, sdk.NewInt64Coin("token1", 5), sdk.NewInt64Coin("token2", 300), sdk.NewInt(200), buyer)
+	require.NoError(t, err)
+
+	// the forward auction restarts, the backstop buys the reverse auction's lot at its reserve price,
+	// and the forward reverse auction's lot is returned as the backstop can't pay its reserve price
+	ctx = ctx.WithBlockTime(header.Time.Add(DefaultMaxAuctionDuration + time.Second))
+	BeginBlocker(ctx, keeper)
+	auction, found := keeper.GetAuction(ctx, forwardID)
+	require.True(t, found)
+	require.Equal(t, ctx.BlockHeader().Time.Add(DefaultMaxAuctionDuration), auction.GetEndTime())
+	_, found = keeper.GetFailedAuction(ctx, forwardID)
+	require.False(t, found)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 110), sdk.NewInt64Coin("token2", 70)), keeper.bankKeeper.GetCoins(ctx, buyer))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 90), sdk.NewInt64Coin("token2", 130)), keeper.bankKeeper.GetCoins(ctx, backstop))
+	failedAuction, found := keeper.GetFailedAuction(ctx, reverseID)
+	require.True(t, found)
+	require.Equal(t, BackstopOutcome, failedAuction.Outcome)
+	require.Equal(t, backstop, failedAuction.Auction.GetBidder())
+	failedAuction, found = keeper.GetFailedAuction(ctx, forwardReverseID)
+	require.True(t, found)
+	require.Equal(t, ReturnOutcome, failedAuction.Outcome)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, seller))
+
+	// once out of restarts, the forward auction's lot is returned
+	ctx = ctx.WithBlockTime(auction.GetEndTime().Add(time.Second))
+	BeginBlocker(ctx, keeper)
+	_, found = keeper.GetAuction(ctx, forwardID)
+	require.False(t, found)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, seller))
+	failedAuction, found = keeper.GetFailedAuction(ctx, forwardID)
+	require.True(t, found)
+	require.Equal(t, ReturnOutcome, failedAuction.Outcome)
+	require.Equal(t, 1, failedAuction.Restarts)
+	require.Len(t, keeper.GetFailedAuctions(ctx), 3)
+
+	// failure records are deleted with the bid histories
+	ctx = ctx.WithBlockTime(ctx.BlockHeader().Time.Add(DefaultBidHistoryRetention))
+	BeginBlocker(ctx, keeper)
+	require.Equal(t, FailedAuctions{}, keeper.GetFailedAuctions(ctx))
+}
+
+func TestKeeper_DutchAuction(t *testing.T) {
+	// setup keeper, create auction
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1, Time: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	seller, buyer, cdpOwner := addresses[0], addresses[1], addresses[2]
+	start := ctx.BlockHeader().Time
+	curve := NewPriceCurve(StepCurve, time.Hour, sdk.MustNewDecFromStr("0.25")) // price of token1 drops from 4 token2 to 3, 2, 1, 0
+	auctionID, err := keeper.StartDutchAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 60), cdpOwner, sdk.NewDec(4), sdk.NewDec(2), curve)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, seller))
+
+	// invalid prices are rejected
+	_, err = keeper.StartDutchAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 60), cdpOwner, sdk.NewDec(2), sdk.NewDec(2), curve)
+	require.Error(t, err)
+
+	// buying part of the lot settles immediately
+	ctx = ctx.WithBlockTime(start.Add(time.Hour))
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer, sdk.NewInt64Coin("token2", 30), sdk.NewInt64Coin("token1", 10)))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 110), sdk.NewInt64Coin("token2", 70)), keeper.bankKeeper.GetCoins(ctx, buyer))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 130)), keeper.bankKeeper.GetCoins(ctx, seller))
+
+	// once the price falls below the reset price the auction restarts rather than closing
+	ctx = ctx.WithBlockTime(start.Add(3*time.Hour + time.Second))
+	BeginBlocker(ctx, keeper)
+	auction, found := keeper.GetAuction(ctx, auctionID)
+	require.True(t, found)
+	require.Equal(t, ctx.BlockHeader().Time, auction.(*DutchAuction).StartTime)
+	require.Equal(t, sdk.NewDec(4), auction.(*DutchAuction).CurrentPrice(ctx.BlockHeader().Time))
+
+	// at the max end time the unsold lot goes back to the seller
+	ctx = ctx.WithBlockTime(start.Add(DefaultMaxAuctionDuration + time.Second))
+	BeginBlocker(ctx, keeper)
+	_, found = keeper.GetAuction(ctx, auctionID)
+	require.False(t, found)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 90), sdk.NewInt64Coin("token2", 130)), keeper.bankKeeper.GetCoins(ctx, seller))
+}
+
+func TestKeeper_UserAuctions(t *testing.T) {
+	// setup keeper, charging a creation deposit of 5 token2
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1, Time: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	params := keeper.GetParams(ctx)
+	params.CreationDeposit = sdk.NewInt64Coin("token2", 5)
+	keeper.setParams(ctx, params)
+	seller, buyer := addresses[0], addresses[1]
+
+	// the lot and deposit are taken from the seller
+	bidAuctionID, err := keeper.StartUserForwardAuction(ctx, seller, sdk.NewCoins(sdk.NewInt64Coin("token1", 20)), "token2", sdk.NewInt(10), time.Hour)
+	require.NoError(t, err)
+	noBidAuctionID, err := keeper.StartUserForwardAuction(ctx, seller, sdk.NewCoins(sdk.NewInt64Coin("token1", 20)), "token2", sdk.NewInt(10), 2*time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 60), sdk.NewInt64Coin("token2", 90)), keeper.bankKeeper.GetCoins(ctx, seller))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token2", 10)), keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress))
+
+	// durations longer than the max auction duration are rejected
+	_, err = keeper.StartUserForwardAuction(ctx, seller, sdk.NewCoins(sdk.NewInt64Coin("token1", 20)), "token2", sdk.NewInt(10), DefaultMaxAuctionDuration+time.Second)
+	require.Error(t, err)
+	// auctions aren't started if the deposit can't be paid
+	_, err = keeper.StartUserReverseAuction(ctx, seller, sdk.NewInt64Coin("token1", 10), "token2", sdk.NewInt(90), time.Hour)
+	require.Error(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 60), sdk.NewInt64Coin("token2", 90)), keeper.bankKeeper.GetCoins(ctx, seller))
+
+	// bids below the reserve price are rejected
+	require.Error(t, keeper.PlaceBid(ctx, bidAuctionID, buyer, sdk.NewInt64Coin("token2", 9), sdk.NewInt64Coin("token1", 20)))
+	require.NoError(t, keeper.PlaceBid(ctx, bidAuctionID, buyer, sdk.NewInt64Coin("token2", 10), sdk.NewInt64Coin("token1", 20)))
+
+	// the deposit is returned when an auction that was bid on closes
+	ctx = ctx.WithBlockTime(header.Time.Add(time.Hour + time.Second))
+	BeginBlocker(ctx, keeper)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 60), sdk.NewInt64Coin("token2", 105)), keeper.bankKeeper.GetCoins(ctx, seller))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 120), sdk.NewInt64Coin("token2", 90)), keeper.bankKeeper.GetCoins(ctx, buyer))
+
+	// the deposit is forfeited when an auction closes without any bids
+	ctx = ctx.WithBlockTime(header.Time.Add(2*time.Hour + time.Second))
+	BeginBlocker(ctx, keeper)
+	_, found := keeper.GetAuction(ctx, noBidAuctionID)
+	require.False(t, found)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 80), sdk.NewInt64Coin("token2", 105)), keeper.bankKeeper.GetCoins(ctx, seller))
+	require.Equal(t, sdk.Coins(nil), keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress))
+}
+
+func TestKeeper_BidHistory(t *testing.T) {
+	// setup keeper, create auction
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1, Time: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	seller, buyer1, buyer2, cdpOwner := addresses[0], addresses[1], addresses[2], addresses[3]
+	auctionID, err := keeper.StartForwardReverseAuction(ctx, seller, sdk.NewInt64Coin("token1", 20), sdk.NewInt64Coin("token2", 50), sdk.ZeroInt(), cdpOwner)
+	require.NoError(t, err)
+
+	// bids are recorded in order, along with the phase of the auction, failed bids are not recorded
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer1, sdk.NewInt64Coin("token2", 10), sdk.NewInt64Coin("token1", 20)))
+	require.Error(t, keeper.PlaceBid(ctx, auctionID, buyer2, sdk.NewInt64Coin("token2", 10), sdk.NewInt64Coin("token1", 20)))
+	ctx = ctx.WithBlockHeight(header.Height + 1).WithBlockTime(header.Time.Add(time.Minute))
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer2, sdk.NewInt64Coin("token2", 50), sdk.NewInt64Coin("token1", 20)))
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer1, sdk.NewInt64Coin("token2", 50), sdk.NewInt64Coin("token1", 15)))
+	expectedBids := BidRecords{
+		{auctionID, buyer1, sdk.NewInt64Coin("token2", 10), sdk.Coins{sdk.NewInt64Coin("token1", 20)}, header.Height, header.Time, ForwardPhase, false},
+		{auctionID, buyer2, sdk.NewInt64Coin("token2", 50), sdk.Coins{sdk.NewInt64Coin("token1", 20)}, header.Height + 1, header.Time.Add(time.Minute), ForwardPhase, false},
+		{auctionID, buyer1, sdk.NewInt64Coin("token2", 50), sdk.Coins{sdk.NewInt64Coin("token1", 15)}, header.Height + 1, header.Time.Add(time.Minute), ReversePhase, false},
+	}
+	require.Equal(t, expectedBids, keeper.GetBids(ctx, auctionID))
+	require.Equal(t, BidRecords{}, keeper.GetBids(ctx, auctionID+1))
+
+	// the history is kept after the auction closes
+	closeTime := header.Time.Add(time.Minute + DefaultBidDuration + time.Second) // the block after the end time
+	ctx = ctx.WithBlockTime(closeTime)
+	BeginBlocker(ctx, keeper)
+	_, found := keeper.GetAuction(ctx, auctionID)
+	require.False(t, found)
+	require.Equal(t, expectedBids, keeper.GetBids(ctx, auctionID))
+
+	// and deleted once the retention period is over
+	ctx = ctx.WithBlockTime(closeTime.Add(DefaultBidHistoryRetention - time.Second))
+	BeginBlocker(ctx, keeper)
+	require.Equal(t, expectedBids, keeper.GetBids(ctx, auctionID))
+	ctx = ctx.WithBlockTime(closeTime.Add(DefaultBidHistoryRetention))
+	BeginBlocker(ctx, keeper)
+	require.Equal(t, BidRecords{}, keeper.GetBids(ctx, auctionID))
+}
+
+func TestKeeper_BasketAuction(t *testing.T) {
+	// setup keeper, give the seller a basket of coins
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1, Time: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	seller, buyer := addresses[0], addresses[1]
+	lot := sdk.NewCoins(sdk.NewInt64Coin("btc", 1), sdk.NewInt64Coin("xrp", 500))
+	_, err := keeper.bankKeeper.AddCoins(ctx, seller, lot)
+	require.NoError(t, err)
+
+	// the whole basket is taken from the seller
+	auctionID, err := keeper.StartBasketAuction(ctx, seller, lot, sdk.NewInt64Coin("token2", 0))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 100)), keeper.bankKeeper.GetCoins(ctx, seller))
+	auction, found := keeper.GetAuction(ctx, auctionID)
+	require.True(t, found)
+	require.Equal(t, lot, auction.GetLot())
+
+	// and goes to the winning bidder when the auction closes
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer, sdk.NewInt64Coin("token2", 40), sdk.NewInt64Coin("btc", 1)))
+	require.Equal(t, BidRecords{{auctionID, buyer, sdk.NewInt64Coin("token2", 40), lot, header.Height, header.Time, "", false}}, keeper.GetBids(ctx, auctionID))
+	ctx = ctx.WithBlockTime(header.Time.Add(DefaultBidDuration + time.Second))
+	BeginBlocker(ctx, keeper)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 140)), keeper.bankKeeper.GetCoins(ctx, seller))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("btc", 1), sdk.NewInt64Coin("token1", 100), sdk.NewInt64Coin("token2", 60), sdk.NewInt64Coin("xrp", 500)), keeper.bankKeeper.GetCoins(ctx, buyer))
+}
+
+func TestKeeper_BatchAuction(t *testing.T) {
+	// setup keeper, give the seller some coins to sell
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1, Time: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	seller, buyer1, buyer2 := addresses[0], addresses[1], addresses[2]
+	_, err := keeper.bankKeeper.AddCoins(ctx, seller, cs(c("btc", 10)))
+	require.NoError(t, err)
+
+	// start an auction open for 2 blocks, adding to the lot in the second
+	auctionID, err := keeper.StartBatchAuction(ctx, seller, c("btc", 6), "token2", 2)
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(header.Height + 1)
+	require.Error(t, keeper.AddToBatchAuction(ctx, auctionID, buyer1, c("btc", 4)), "only the initiator can add to the lot")
+	require.NoError(t, keeper.AddToBatchAuction(ctx, auctionID, seller, c("btc", 4)))
+	require.Equal(t, cs(c("token1", 100), c("token2", 100)), keeper.bankKeeper.GetCoins(ctx, seller))
+
+	// orders are paid for in full at their max price
+	require.NoError(t, keeper.PlaceBatchOrder(ctx, auctionID, buyer1, sdk.NewInt(6), sdk.MustNewDecFromStr("10")))
+	require.NoError(t, keeper.PlaceBatchOrder(ctx, auctionID, buyer2, sdk.NewInt(6), sdk.MustNewDecFromStr("5")))
+	require.Equal(t, cs(c("token1", 100), c("token2", 40)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	require.Equal(t, BidRecords{
+		{auctionID, buyer1, c("token2", 60), cs(c("btc", 6)), header.Height + 1, header.Time, "", false},
+		{auctionID, buyer2, c("token2", 30), cs(c("btc", 6)), header.Height + 1, header.Time, "", false},
+	}, keeper.GetBids(ctx, auctionID))
+	require.Error(t, keeper.CloseAuction(ctx.WithBlockHeight(header.Height), auctionID), "auction can't be closed before its end height")
+
+	// the auction closes in the block after its end height, whatever the block time
+	BeginBlocker(ctx, keeper)
+	_, found := keeper.GetAuction(ctx, auctionID)
+	require.True(t, found)
+	ctx = ctx.WithBlockHeight(header.Height + 2)
+	BeginBlocker(ctx, keeper)
+	_, found = keeper.GetAuction(ctx, auctionID)
+	require.False(t, found)
+
+	// both buyers pay buyer2's max price, and buyer2 gets what is left of the lot
+	require.Equal(t, cs(c("token1", 100), c("token2", 150)), keeper.bankKeeper.GetCoins(ctx, seller))
+	require.Equal(t, cs(c("btc", 6), c("token1", 100), c("token2", 70)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	require.Equal(t, cs(c("btc", 4), c("token1", 100), c("token2", 80)), keeper.bankKeeper.GetCoins(ctx, buyer2))
+}
+
+func TestKeeper_FreezeCancelAuction(t *testing.T) {
+	// setup keeper, give the seller some coins to sell
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1, Time: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	seller, buyer1, buyer2 := addresses[0], addresses[1], addresses[2]
+	_, err := keeper.bankKeeper.AddCoins(ctx, seller, cs(c("btc", 10)))
+	require.NoError(t, err)
+
+	// start an auction with a bid and a proxy bid on it
+	auctionID, err := keeper.StartForwardAuction(ctx, seller, c("btc", 10), c("token2", 0))
+	require.NoError(t, err)
+	require.NoError(t, keeper.PlaceProxyBid(ctx, auctionID, buyer1, c("token2", 50), c("btc", 0)))
+	require.NoError(t, keeper.PlaceBid(ctx, auctionID, buyer2, c("token2", 20), c("btc", 10)))
+	a, _ := keeper.GetAuction(ctx, auctionID)
+	require.Equal(t, buyer1, a.GetBidder())
+
+	// frozen auctions don't accept bids or close
+	require.NoError(t, keeper.FreezeAuction(ctx, auctionID))
+	require.NoError(t, keeper.FreezeAuction(ctx, auctionID), "freezing twice does nothing")
+	require.True(t, keeper.IsAuctionFrozen(ctx, auctionID))
+	require.Error(t, keeper.PlaceBid(ctx, auctionID, buyer2, c("token2", 60), c("btc", 10)))
+	require.Error(t, keeper.PlaceProxyBid(ctx, auctionID, buyer2, c("token2", 60), c("btc", 0)))
+	closeCtx := ctx.WithBlockTime(header.Time.Add(DefaultMaxAuctionDuration).Add(time.Second))
+	require.Error(t, keeper.CloseAuction(closeCtx, auctionID))
+	BeginBlocker(closeCtx, keeper)
+	_, found := keeper.GetAuction(ctx, auctionID)
+	require.True(t, found)
+
+	// cancelling refunds the bids and returns the lot
+	cancelled, err := keeper.CancelAuction(closeCtx, auctionID)
+	require.NoError(t, err)
+	require.Equal(t, auctionID, cancelled.GetID())
+	_, found = keeper.GetAuction(ctx, auctionID)
+	require.False(t, found)
+	require.False(t, keeper.IsAuctionFrozen(ctx, auctionID))
+	require.Equal(t, cs(c("btc", 10), c("token1", 100), c("token2", 100)), keeper.bankKeeper.GetCoins(ctx, seller))
+	require.Equal(t, cs(c("token1", 100), c("token2", 100)), keeper.bankKeeper.GetCoins(ctx, buyer1))
+	require.Equal(t, cs(c("token1", 100), c("token2", 100)), keeper.bankKeeper.GetCoins(ctx, buyer2))
+	require.True(t, keeper.bankKeeper.GetCoins(ctx, EscrowAccountAddress).IsZero())
+	_, err = keeper.CancelAuction(ctx, auctionID)
+	require.Error(t, err)
+}
+
+// failingBankKeeper wraps a bankKeeper, failing to add coins to one address.
+type failingBankKeeper struct {
+	bankKeeper
+	failingAddress sdk.AccAddress
+}
+
+func (fbk failingBankKeeper) AddCoins(ctx sdk.Context, address sdk.AccAddress, amount sdk.Coins) (sdk.Coins, sdk.Error) {
+	if address.Equals(fbk.failingAddress) {
+		return nil, sdk.ErrInternal("failed to add coins")
+	}
+	return fbk.bankKeeper.AddCoins(ctx, address, amount)
+}
+
+// panickingBankKeeper wraps a bankKeeper, panicking when adding coins to one address.
+type panickingBankKeeper struct {
+	bankKeeper
+	panickingAddress sdk.AccAddress
+}
+
+func (pbk panickingBankKeeper) AddCoins(ctx sdk.Context, address sdk.AccAddress, amount sdk.Coins) (sdk.Coins, sdk.Error) {
+	if address.Equals(pbk.panickingAddress) {
+		panic("failed to add coins")
+	}
+	return pbk.bankKeeper.AddCoins(ctx, address, amount)
+}
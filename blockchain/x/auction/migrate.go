@@ -0,0 +1,139 @@
+package auction
+
+import (
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+/*
+Auctions used to measure EndTime and MaxEndTime in block heights. They are now measured in block time.
+The types below are the old auction types, registered under the same amino names so auctions written to the store by the old module can still be decoded.
+*/
+
+type legacyAuction interface {
+	migrate(toTime func(height int64) time.Time) Auction
+}
+
+type legacyBaseAuction struct {
+	ID         ID
+	Initiator  sdk.AccAddress
+	Lot        sdk.Coin
+	Bidder     sdk.AccAddress
+	Bid        sdk.Coin
+	EndTime    int64
+	MaxEndTime int64
+}
+
+func (a legacyBaseAuction) migrate(toTime func(height int64) time.Time) BaseAuction {
+	return BaseAuction{
+		ID:         a.ID,
+		Initiator:  a.Initiator,
+		Lot:        a.Lot,
+		Bidder:     a.Bidder,
+		Bid:        a.Bid,
+		EndTime:    toTime(a.EndTime),
+		MaxEndTime: toTime(a.MaxEndTime),
+	}
+}
+
+// Embedded fields are encoded by amino as a nested struct in the first field, so the old types can be matched with a named (exported) field.
+
+type legacyForwardAuction struct {
+	BaseAuction legacyBaseAuction
+}
+
+func (a *legacyForwardAuction) migrate(toTime func(height int64) time.Time) Auction {
+	return &ForwardAuction{a.BaseAuction.migrate(toTime)}
+}
+
+type legacyReverseAuction struct {
+	BaseAuction legacyBaseAuction
+}
+
+func (a *legacyReverseAuction) migrate(toTime func(height int64) time.Time) Auction {
+	return &ReverseAuction{a.BaseAuction.migrate(toTime)}
+}
+
+type legacyForwardReverseAuction struct {
+	BaseAuction legacyBaseAuction
+	MaxBid      sdk.Coin
+	OtherPerson sdk.AccAddress
+}
+
+func (a *legacyForwardReverseAuction) migrate(toTime func(height int64) time.Time) Auction {
+	return &ForwardReverseAuction{
+		BaseAuction: a.BaseAuction.migrate(toTime),
+		MaxBid:      a.MaxBid,
+		OtherPerson: a.OtherPerson,
+	}
+}
+
+func makeLegacyCodec() *codec.Codec {
+	cdc := codec.New()
+	cdc.RegisterInterface((*legacyAuction)(nil), nil)
+	cdc.RegisterConcrete(&legacyForwardAuction{}, "auction/ForwardAuction", nil)
+	cdc.RegisterConcrete(&legacyReverseAuction{}, "auction/ReverseAuction", nil)
+	cdc.RegisterConcrete(&legacyForwardReverseAuction{}, "auction/ForwardReverseAuction", nil)
+	return cdc
+}
+
+// LegacyAverageBlockTime is the block time the old block height based auction durations assumed.
+const LegacyAverageBlockTime = 5 * time.Second
+
+// StoreNeedsMigration reports whether the auction store was written by the old block height based module, which had no params.
+// The app checks it once when it loads the latest state, so the migration doesn't cost a params lookup every block.
+func StoreNeedsMigration(ctx sdk.Context, k Keeper) bool {
+	return !k.paramsSubspace.Has(ctx, moduleParamsKey)
+}
+
+// MigrateStore upgrades an auction store written by the old block height based module. The app runs it at the start of the first block after loading a store that needs it, before any auctions are closed.
+// The store is migrated to block time (using LegacyAverageBlockTime) and given the default params.
+// Once params are set, by the migration or by InitGenesis, it does nothing.
+func MigrateStore(ctx sdk.Context, k Keeper) {
+	if !StoreNeedsMigration(ctx, k) {
+		return
+	}
+	ctx.Logger().Info("migrating auctions to block time")
+	MigrateToBlockTime(ctx, k, LegacyAverageBlockTime)
+	k.setParams(ctx, DefaultAuctionParams())
+}
+
+// MigrateToBlockTime converts auctions stored with block height end times to block time end times, and rebuilds the queue to be keyed by time.
+// Remaining blocks are converted to time assuming one block every averageBlockTime from the current block.
+// It must be run once, in the block where the chain switches to this version of the module, before any auctions are started or bid on. MigrateStore does this.
+func MigrateToBlockTime(ctx sdk.Context, k Keeper, averageBlockTime time.Duration) {
+	legacyCdc := makeLegacyCodec()
+	toTime := func(height int64) time.Time {
+		return ctx.BlockHeader().Time.Add(time.Duration(height-ctx.BlockHeight()) * averageBlockTime)
+	}
+	store := ctx.KVStore(k.storeKey)
+
+	// read all the old auctions before modifying the store
+	var auctions []Auction
+	iter := sdk.KVStorePrefixIterator(store, auctionKeyPrefix)
+	for ; iter.Valid(); iter.Next() {
+		var legacy legacyAuction
+		legacyCdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &legacy)
+		auctions = append(auctions, legacy.migrate(toTime))
+	}
+	iter.Close()
+
+	// clear out the old height based queue
+	var queueKeys [][]byte
+	iter = sdk.KVStorePrefixIterator(store, queueKeyPrefix)
+	for ; iter.Valid(); iter.Next() {
+		queueKeys = append(queueKeys, iter.Key())
+	}
+	iter.Close()
+	for _, key := range queueKeys {
+		store.Delete(key)
+	}
+
+	// write back the migrated auctions, adding them to the new queue
+	for _, auction := range auctions {
+		store.Set(k.getAuctionKey(auction.GetID()), k.cdc.MustMarshalBinaryLengthPrefixed(auction))
+		k.insertIntoQueue(ctx, auction.GetEndTime(), auction.GetID())
+	}
+}
@@ -0,0 +1,85 @@
+package auction
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestMigrateToBlockTime(t *testing.T) {
+	// setup keeper
+	mapp, keeper, addresses, _ := setUpMockApp()
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1, Time: now}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header).WithBlockHeight(1000)
+
+	// write old height based auctions and queue entries to the store
+	legacyCdc := makeLegacyCodec()
+	store := ctx.KVStore(keeper.storeKey)
+	legacyAuctions := []legacyAuction{
+		&legacyForwardAuction{legacyBaseAuction{ID: 0, Initiator: addresses[0], Lot: c("usdx", 100), Bidder: addresses[1], Bid: c("kava", 10), EndTime: 1100, MaxEndTime: 2000}},
+		&legacyForwardReverseAuction{
+			BaseAuction: legacyBaseAuction{ID: 1, Initiator: addresses[0], Lot: c("btc", 1), Bidder: addresses[0], Bid: c("usdx", 0), EndTime: 1500, MaxEndTime: 1500},
+			MaxBid:      c("usdx", 100),
+			OtherPerson: addresses[2],
+		},
+	}
+	for _, a := range legacyAuctions {
+		id := a.migrate(func(int64) time.Time { return time.Time{} }).GetID()
+		store.Set(keeper.getAuctionKey(id), legacyCdc.MustMarshalBinaryLengthPrefixed(a))
+		store.Set(append(queueKeyPrefix, sdk.Uint64ToBigEndian(uint64(id))...), keeper.cdc.MustMarshalBinaryLengthPrefixed(id))
+	}
+
+	// run migration
+	MigrateToBlockTime(ctx, keeper, 5*time.Second)
+
+	// check end times were converted
+	auction, found := keeper.GetAuction(ctx, 0)
+	require.True(t, found)
+	require.Equal(t, now.Add(100*5*time.Second), auction.GetEndTime())
+	require.Equal(t, now.Add(1000*5*time.Second), auction.(*ForwardAuction).MaxEndTime)
+	auction, found = keeper.GetAuction(ctx, 1)
+	require.True(t, found)
+	require.Equal(t, now.Add(500*5*time.Second), auction.GetEndTime())
+	require.Equal(t, c("usdx", 100), auction.(*ForwardReverseAuction).MaxBid)
+
+	// check the queue only contains the time based entries
+	iter := keeper.getQueueIterator(ctx, now.Add(100*5*time.Second))
+	require.Equal(t, []ID{0}, convertIteratorToSlice(keeper, iter))
+	iter.Close()
+	iter = keeper.getQueueIterator(ctx, now.Add(DefaultMaxAuctionDuration))
+	require.Equal(t, []ID{0, 1}, convertIteratorToSlice(keeper, iter))
+	iter.Close()
+}
+
+func TestMigrateStore(t *testing.T) {
+	// setup keeper
+	mapp, keeper, addresses, _ := setUpMockApp()
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1, Time: now}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header).WithBlockHeight(1000)
+
+	// a store with params is already up to date, so it's left alone
+	legacyCdc := makeLegacyCodec()
+	store := ctx.KVStore(keeper.storeKey)
+	legacy := &legacyForwardAuction{legacyBaseAuction{ID: 0, Initiator: addresses[0], Lot: c("usdx", 100), Bidder: addresses[1], Bid: c("kava", 10), EndTime: 1100, MaxEndTime: 2000}}
+	store.Set(keeper.getAuctionKey(0), legacyCdc.MustMarshalBinaryLengthPrefixed(legacy))
+	require.False(t, StoreNeedsMigration(ctx, keeper))
+	MigrateStore(ctx, keeper)
+	require.Equal(t, legacyCdc.MustMarshalBinaryLengthPrefixed(legacy), store.Get(keeper.getAuctionKey(0)))
+
+	// a store written by the old module has no params, so its auctions are migrated and default params set
+	ctx.KVStore(mapp.KeyParams).Delete(append([]byte(DefaultParamspace+"/"), moduleParamsKey...))
+	require.True(t, StoreNeedsMigration(ctx, keeper))
+	MigrateStore(ctx, keeper)
+	require.False(t, StoreNeedsMigration(ctx, keeper))
+	auction, found := keeper.GetAuction(ctx, 0)
+	require.True(t, found)
+	require.Equal(t, now.Add(100*LegacyAverageBlockTime), auction.GetEndTime())
+	require.Equal(t, DefaultMaxAuctionDuration, keeper.GetParams(ctx).MaxAuctionDuration)
+}
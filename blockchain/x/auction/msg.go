@@ -1,6 +1,12 @@
 package auction
 
-import sdk "github.com/cosmos/cosmos-sdk/types"
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
 
 // MsgPlaceBid is the message type used to place a bid on any type of auction.
 type MsgPlaceBid struct {
@@ -37,7 +43,13 @@ func (msg MsgPlaceBid) ValidateBasic() sdk.Error {
 	if msg.Lot.Amount.LT(sdk.ZeroInt()) {
 		return sdk.ErrInternal("invalid (negative) lot amount")
 	}
-	// TODO check coin denoms
+	// the denoms are checked against the auction's when the bid is placed
+	if len(msg.Bid.Denom) == 0 || len(msg.Lot.Denom) == 0 {
+		return sdk.ErrInternal("invalid (empty) bid or lot denom")
+	}
+	if msg.Bid.Denom == msg.Lot.Denom {
+		return sdk.ErrInternal("bid and lot must be in different denoms")
+	}
 	return nil
 }
 
@@ -52,42 +64,404 @@ func (msg MsgPlaceBid) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Bidder}
 }
 
-// The CDP system doesn't need Msgs for starting auctions. But they could be added to allow people to create random auctions of their own, and to make this module more general purpose.
-
-// type MsgStartForwardAuction struct {
-// 	Seller sdk.AccAddress
-// 	Amount sdk.Coins
-// 	// TODO add starting bid amount?
-// 	// TODO specify asset denom to be received
-// }
-
-// // NewMsgStartAuction returns a new MsgStartAuction.
-// func NewMsgStartAuction(seller sdk.AccAddress, amount sdk.Coins, maxBid sdk.Coins) MsgStartAuction {
-// 	return MsgStartAuction{
-// 		Seller: seller,
-// 		Amount: amount,
-// 		MaxBid: maxBid,
-// 	}
-// }
-
-// // Route return the message type used for routing the message.
-// func (msg MsgStartAuction) Route() string { return "auction" }
-
-// // Type returns a human-readable string for the message, intended for utilization within tags.
-// func (msg MsgStartAuction) Type() string { return "start_auction" }
-
-// // ValidateBasic does a simple validation check that doesn't require access to any other information.
-// func (msg MsgStartAuction) ValidateBasic() sdk.Error {
-// 	return nil
-// }
-
-// // GetSignBytes gets the canonical byte representation of the Msg.
-// func (msg MsgStartAuction) GetSignBytes() []byte {
-// 	bz := msgCdc.MustMarshalJSON(msg)
-// 	return sdk.MustSortJSON(bz)
-// }
-
-// // GetSigners returns the addresses of signers that must sign.
-// func (msg MsgStartAuction) GetSigners() []sdk.AccAddress {
-// 	return []sdk.AccAddress{msg.Seller}
-// }
+// MsgPlacePartialBid is the message type used to bid on part of the lot of a forward reverse auction. The part is split off into a new auction.
+type MsgPlacePartialBid struct {
+	AuctionID ID
+	Bidder    sdk.AccAddress
+	Bid       sdk.Coin
+	Lot       sdk.Coin // the part of the lot to bid on
+}
+
+// NewMsgPlacePartialBid returns a new MsgPlacePartialBid.
+func NewMsgPlacePartialBid(auctionID ID, bidder sdk.AccAddress, bid sdk.Coin, lot sdk.Coin) MsgPlacePartialBid {
+	return MsgPlacePartialBid{
+		AuctionID: auctionID,
+		Bidder:    bidder,
+		Bid:       bid,
+		Lot:       lot,
+	}
+}
+
+// Route return the message type used for routing the message.
+func (msg MsgPlacePartialBid) Route() string { return "auction" }
+
+// Type returns a human-readable string for the message, intended for utilization within tags.
+func (msg MsgPlacePartialBid) Type() string { return "place_partial_bid" }
+
+// ValidateBasic does a simple validation check that doesn't require access to any other information.
+func (msg MsgPlacePartialBid) ValidateBasic() sdk.Error {
+	if msg.Bidder.Empty() {
+		return sdk.ErrInternal("invalid (empty) bidder address")
+	}
+	if !msg.Bid.IsPositive() {
+		return sdk.ErrInternal("invalid (non positive) bid amount")
+	}
+	if !msg.Lot.IsPositive() {
+		return sdk.ErrInternal("invalid (non positive) lot amount")
+	}
+	return nil
+}
+
+// GetSignBytes gets the canonical byte representation of the Msg.
+func (msg MsgPlacePartialBid) GetSignBytes() []byte {
+	bz := moduleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the addresses of signers that must sign.
+func (msg MsgPlacePartialBid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
+// MsgPlaceProxyBid is the message type used to set a hidden limit that the auction module bids up to on the bidder's behalf.
+type MsgPlaceProxyBid struct {
+	AuctionID ID
+	Bidder    sdk.AccAddress
+	MaxBid    sdk.Coin // most the bidder will bid
+	MinLot    sdk.Coin // smallest lot the bidder will accept, only used when bidding down the lot
+}
+
+// NewMsgPlaceProxyBid returns a new MsgPlaceProxyBid.
+func NewMsgPlaceProxyBid(auctionID ID, bidder sdk.AccAddress, maxBid sdk.Coin, minLot sdk.Coin) MsgPlaceProxyBid {
+	return MsgPlaceProxyBid{
+		AuctionID: auctionID,
+		Bidder:    bidder,
+		MaxBid:    maxBid,
+		MinLot:    minLot,
+	}
+}
+
+// Route return the message type used for routing the message.
+func (msg MsgPlaceProxyBid) Route() string { return "auction" }
+
+// Type returns a human-readable string for the message, intended for utilization within tags.
+func (msg MsgPlaceProxyBid) Type() string { return "place_proxy_bid" }
+
+// ValidateBasic does a simple validation check that doesn't require access to any other information.
+func (msg MsgPlaceProxyBid) ValidateBasic() sdk.Error {
+	if msg.Bidder.Empty() {
+		return sdk.ErrInternal("invalid (empty) bidder address")
+	}
+	if !msg.MaxBid.IsPositive() {
+		return sdk.ErrInternal("invalid (non positive) max bid amount")
+	}
+	if msg.MinLot.Amount.LT(sdk.ZeroInt()) {
+		return sdk.ErrInternal("invalid (negative) min lot amount")
+	}
+	return nil
+}
+
+// GetSignBytes gets the canonical byte representation of the Msg.
+func (msg MsgPlaceProxyBid) GetSignBytes() []byte {
+	bz := moduleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the addresses of signers that must sign.
+func (msg MsgPlaceProxyBid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
+// MsgPlaceBatchOrder is the message type used to place a limit order on a batch auction.
+type MsgPlaceBatchOrder struct {
+	AuctionID ID
+	Bidder    sdk.AccAddress
+	Amount    sdk.Int // amount of the lot wanted
+	MaxPrice  sdk.Dec // most the bidder will pay for each unit of the lot
+}
+
+// NewMsgPlaceBatchOrder returns a new MsgPlaceBatchOrder.
+func NewMsgPlaceBatchOrder(auctionID ID, bidder sdk.AccAddress, amount sdk.Int, maxPrice sdk.Dec) MsgPlaceBatchOrder {
+	return MsgPlaceBatchOrder{
+		AuctionID: auctionID,
+		Bidder:    bidder,
+		Amount:    amount,
+		MaxPrice:  maxPrice,
+	}
+}
+
+// Route return the message type used for routing the message.
+func (msg MsgPlaceBatchOrder) Route() string { return "auction" }
+
+// Type returns a human-readable string for the message, intended for utilization within tags.
+func (msg MsgPlaceBatchOrder) Type() string { return "place_batch_order" }
+
+// ValidateBasic does a simple validation check that doesn't require access to any other information.
+func (msg MsgPlaceBatchOrder) ValidateBasic() sdk.Error {
+	if msg.Bidder.Empty() {
+		return sdk.ErrInternal("invalid (empty) bidder address")
+	}
+	if !msg.Amount.IsPositive() {
+		return sdk.ErrInternal("invalid (non positive) order amount")
+	}
+	if !msg.MaxPrice.IsPositive() {
+		return sdk.ErrInternal("invalid (non positive) max price")
+	}
+	return nil
+}
+
+// GetSignBytes gets the canonical byte representation of the Msg.
+func (msg MsgPlaceBatchOrder) GetSignBytes() []byte {
+	bz := moduleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the addresses of signers that must sign.
+func (msg MsgPlaceBatchOrder) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
+// MsgCommitBid is the message type used to place a sealed bid on a sealed bid auction.
+type MsgCommitBid struct {
+	AuctionID ID
+	Bidder    sdk.AccAddress
+	Hash      []byte // hash of the bid and a secret salt, see SealedBidHash
+}
+
+// NewMsgCommitBid returns a new MsgCommitBid.
+func NewMsgCommitBid(auctionID ID, bidder sdk.AccAddress, hash []byte) MsgCommitBid {
+	return MsgCommitBid{
+		AuctionID: auctionID,
+		Bidder:    bidder,
+		Hash:      hash,
+	}
+}
+
+// Route return the message type used for routing the message.
+func (msg MsgCommitBid) Route() string { return "auction" }
+
+// Type returns a human-readable string for the message, intended for utilization within tags.
+func (msg MsgCommitBid) Type() string { return "commit_bid" }
+
+// ValidateBasic does a simple validation check that doesn't require access to any other information.
+func (msg MsgCommitBid) ValidateBasic() sdk.Error {
+	if msg.Bidder.Empty() {
+		return sdk.ErrInternal("invalid (empty) bidder address")
+	}
+	if len(msg.Hash) != sha256.Size {
+		return sdk.ErrInternal(fmt.Sprintf("invalid hash length, must be %d bytes", sha256.Size))
+	}
+	return nil
+}
+
+// GetSignBytes gets the canonical byte representation of the Msg.
+func (msg MsgCommitBid) GetSignBytes() []byte {
+	bz := moduleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the addresses of signers that must sign.
+func (msg MsgCommitBid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
+// MsgRevealBid is the message type used to reveal a sealed bid on a sealed bid auction.
+type MsgRevealBid struct {
+	AuctionID ID
+	Bidder    sdk.AccAddress
+	Bid       sdk.Coin
+	Salt      string
+}
+
+// NewMsgRevealBid returns a new MsgRevealBid.
+func NewMsgRevealBid(auctionID ID, bidder sdk.AccAddress, bid sdk.Coin, salt string) MsgRevealBid {
+	return MsgRevealBid{
+		AuctionID: auctionID,
+		Bidder:    bidder,
+		Bid:       bid,
+		Salt:      salt,
+	}
+}
+
+// Route return the message type used for routing the message.
+func (msg MsgRevealBid) Route() string { return "auction" }
+
+// Type returns a human-readable string for the message, intended for utilization within tags.
+func (msg MsgRevealBid) Type() string { return "reveal_bid" }
+
+// ValidateBasic does a simple validation check that doesn't require access to any other information.
+func (msg MsgRevealBid) ValidateBasic() sdk.Error {
+	if msg.Bidder.Empty() {
+		return sdk.ErrInternal("invalid (empty) bidder address")
+	}
+	if msg.Bid.Amount.LT(sdk.ZeroInt()) {
+		return sdk.ErrInternal("invalid (negative) bid amount")
+	}
+	return nil
+}
+
+// GetSignBytes gets the canonical byte representation of the Msg.
+func (msg MsgRevealBid) GetSignBytes() []byte {
+	bz := moduleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the addresses of signers that must sign.
+func (msg MsgRevealBid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
+// MsgStartForwardAuction is the msg for users to sell a lot to whoever bids the most for it. Known as flap in maker.
+type MsgStartForwardAuction struct {
+	Seller       sdk.AccAddress
+	Lot          sdk.Coins // lots of more than one coin are sold in a basket auction
+	BidDenom     string
+	ReservePrice sdk.Int // lowest bid the seller will accept for the lot
+	Duration     time.Duration
+}
+
+// NewMsgStartForwardAuction returns a new MsgStartForwardAuction.
+func NewMsgStartForwardAuction(seller sdk.AccAddress, lot sdk.Coins, bidDenom string, reservePrice sdk.Int, duration time.Duration) MsgStartForwardAuction {
+	return MsgStartForwardAuction{
+		Seller:       seller,
+		Lot:          lot,
+		BidDenom:     bidDenom,
+		ReservePrice: reservePrice,
+		Duration:     duration,
+	}
+}
+
+// Route return the message type used for routing the message.
+func (msg MsgStartForwardAuction) Route() string { return "auction" }
+
+// Type returns a human-readable string for the message, intended for utilization within tags.
+func (msg MsgStartForwardAuction) Type() string { return "start_forward_auction" }
+
+// ValidateBasic does a simple validation check that doesn't require access to any other information.
+func (msg MsgStartForwardAuction) ValidateBasic() sdk.Error {
+	if msg.Seller.Empty() {
+		return sdk.ErrInternal("invalid (empty) seller address")
+	}
+	return validateStartAuction(msg.Lot, msg.BidDenom, msg.ReservePrice, msg.Duration)
+}
+
+// GetSignBytes gets the canonical byte representation of the Msg.
+func (msg MsgStartForwardAuction) GetSignBytes() []byte {
+	bz := moduleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the addresses of signers that must sign.
+func (msg MsgStartForwardAuction) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Seller}
+}
+
+// MsgStartReverseAuction is the msg for users to buy a fixed amount of coins from whoever will accept the smallest lot for them. Known as flop in maker.
+type MsgStartReverseAuction struct {
+	Buyer        sdk.AccAddress
+	Bid          sdk.Coin // amount the buyer is buying
+	LotDenom     string
+	ReservePrice sdk.Int // most the buyer will pay for the bid
+	Duration     time.Duration
+}
+
+// NewMsgStartReverseAuction returns a new MsgStartReverseAuction.
+func NewMsgStartReverseAuction(buyer sdk.AccAddress, bid sdk.Coin, lotDenom string, reservePrice sdk.Int, duration time.Duration) MsgStartReverseAuction {
+	return MsgStartReverseAuction{
+		Buyer:        buyer,
+		Bid:          bid,
+		LotDenom:     lotDenom,
+		ReservePrice: reservePrice,
+		Duration:     duration,
+	}
+}
+
+// Route return the message type used for routing the message.
+func (msg MsgStartReverseAuction) Route() string { return "auction" }
+
+// Type returns a human-readable string for the message, intended for utilization within tags.
+func (msg MsgStartReverseAuction) Type() string { return "start_reverse_auction" }
+
+// ValidateBasic does a simple validation check that doesn't require access to any other information.
+func (msg MsgStartReverseAuction) ValidateBasic() sdk.Error {
+	if msg.Buyer.Empty() {
+		return sdk.ErrInternal("invalid (empty) buyer address")
+	}
+	return validateStartAuction(sdk.Coins{msg.Bid}, msg.LotDenom, msg.ReservePrice, msg.Duration)
+}
+
+// GetSignBytes gets the canonical byte representation of the Msg.
+func (msg MsgStartReverseAuction) GetSignBytes() []byte {
+	bz := moduleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the addresses of signers that must sign.
+func (msg MsgStartReverseAuction) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Buyer}
+}
+
+// MsgStartSealedBidAuction is the msg for users to sell a lot in a sealed bid auction, where bids are committed as hashes then revealed.
+type MsgStartSealedBidAuction struct {
+	Seller  sdk.AccAddress
+	Lot     sdk.Coin
+	MinBid  sdk.Coin // lowest bid the seller will accept for the lot
+	Deposit sdk.Coin // amount each bidder must deposit with their sealed bid, in the bid denom
+}
+
+// NewMsgStartSealedBidAuction returns a new MsgStartSealedBidAuction.
+func NewMsgStartSealedBidAuction(seller sdk.AccAddress, lot sdk.Coin, minBid sdk.Coin, deposit sdk.Coin) MsgStartSealedBidAuction {
+	return MsgStartSealedBidAuction{
+		Seller:  seller,
+		Lot:     lot,
+		MinBid:  minBid,
+		Deposit: deposit,
+	}
+}
+
+// Route return the message type used for routing the message.
+func (msg MsgStartSealedBidAuction) Route() string { return "auction" }
+
+// Type returns a human-readable string for the message, intended for utilization within tags.
+func (msg MsgStartSealedBidAuction) Type() string { return "start_sealed_bid_auction" }
+
+// ValidateBasic does a simple validation check that doesn't require access to any other information.
+func (msg MsgStartSealedBidAuction) ValidateBasic() sdk.Error {
+	if msg.Seller.Empty() {
+		return sdk.ErrInternal("invalid (empty) seller address")
+	}
+	if !msg.Lot.IsPositive() {
+		return sdk.ErrInternal("invalid (non positive) lot amount")
+	}
+	if len(msg.MinBid.Denom) == 0 || msg.MinBid.Denom == msg.Lot.Denom {
+		return sdk.ErrInternal("invalid bid denom, must be non empty and different to the lot denom")
+	}
+	if msg.MinBid.Amount.IsNegative() {
+		return sdk.ErrInternal("invalid (negative) min bid amount")
+	}
+	if msg.Deposit.Denom != msg.MinBid.Denom {
+		return sdk.ErrInternal("invalid deposit, must be in the bid denom")
+	}
+	if msg.Deposit.Amount.IsNegative() {
+		return sdk.ErrInternal("invalid (negative) deposit amount")
+	}
+	return nil
+}
+
+// GetSignBytes gets the canonical byte representation of the Msg.
+func (msg MsgStartSealedBidAuction) GetSignBytes() []byte {
+	bz := moduleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// GetSigners returns the addresses of signers that must sign.
+func (msg MsgStartSealedBidAuction) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Seller}
+}
+
+// validateStartAuction checks the parts of a start auction msg that are common to both auction types.
+func validateStartAuction(amount sdk.Coins, priceDenom string, reservePrice sdk.Int, duration time.Duration) sdk.Error {
+	if amount.Empty() || !amount.IsValid() {
+		return sdk.ErrInternal("invalid amount, must be positive and sorted, with no duplicate denoms")
+	}
+	if len(priceDenom) == 0 || !amount.AmountOf(priceDenom).IsZero() {
+		return sdk.ErrInternal("invalid denom, must be non empty and different to the amount being auctioned")
+	}
+	if reservePrice.IsNegative() {
+		return sdk.ErrInternal("invalid (negative) reserve price")
+	}
+	if duration <= 0 {
+		return sdk.ErrInternal("invalid (non positive) duration")
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package auction
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
@@ -16,9 +17,206 @@ func TestMsgPlaceBid_ValidateBasic(t *testing.T) {
 	}{
 		{"normal", MsgPlaceBid{0, addr, sdk.NewInt64Coin("usdx", 10), sdk.NewInt64Coin("kava", 20)}, true},
 		{"emptyAddr", MsgPlaceBid{0, sdk.AccAddress{}, sdk.NewInt64Coin("usdx", 10), sdk.NewInt64Coin("kava", 20)}, false},
-		{"negativeBid", MsgPlaceBid{0, addr, sdk.Coin{"usdx", sdk.NewInt(-10)}, sdk.NewInt64Coin("kava", 20)}, false},
-		{"negativeLot", MsgPlaceBid{0, addr, sdk.NewInt64Coin("usdx", 10), sdk.Coin{"kava", sdk.NewInt(-20)}}, false},
+		{"negativeBid", MsgPlaceBid{0, addr, sdk.Coin{Denom: "usdx", Amount: sdk.NewInt(-10)}, sdk.NewInt64Coin("kava", 20)}, false},
+		{"negativeLot", MsgPlaceBid{0, addr, sdk.NewInt64Coin("usdx", 10), sdk.Coin{Denom: "kava", Amount: sdk.NewInt(-20)}}, false},
 		{"zerocoins", MsgPlaceBid{0, addr, sdk.NewInt64Coin("usdx", 0), sdk.NewInt64Coin("kava", 0)}, true},
+		{"emptyBidDenom", MsgPlaceBid{0, addr, sdk.Coin{Denom: "", Amount: sdk.NewInt(10)}, sdk.NewInt64Coin("kava", 20)}, false},
+		{"emptyLotDenom", MsgPlaceBid{0, addr, sdk.NewInt64Coin("usdx", 10), sdk.Coin{Denom: "", Amount: sdk.NewInt(20)}}, false},
+		{"sameDenoms", MsgPlaceBid{0, addr, sdk.NewInt64Coin("usdx", 10), sdk.NewInt64Coin("usdx", 20)}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectPass {
+				require.Nil(t, tc.msg.ValidateBasic())
+			} else {
+				require.NotNil(t, tc.msg.ValidateBasic())
+			}
+		})
+	}
+}
+
+func TestMsgPlaceProxyBid_ValidateBasic(t *testing.T) {
+	addr := sdk.AccAddress([]byte("someName"))
+	tests := []struct {
+		name       string
+		msg        MsgPlaceProxyBid
+		expectPass bool
+	}{
+		{"normal", MsgPlaceProxyBid{0, addr, sdk.NewInt64Coin("usdx", 10), sdk.NewInt64Coin("kava", 20)}, true},
+		{"zeroMinLot", MsgPlaceProxyBid{0, addr, sdk.NewInt64Coin("usdx", 10), sdk.NewInt64Coin("kava", 0)}, true},
+		{"emptyAddr", MsgPlaceProxyBid{0, sdk.AccAddress{}, sdk.NewInt64Coin("usdx", 10), sdk.NewInt64Coin("kava", 20)}, false},
+		{"zeroMaxBid", MsgPlaceProxyBid{0, addr, sdk.NewInt64Coin("usdx", 0), sdk.NewInt64Coin("kava", 20)}, false},
+		{"negativeMinLot", MsgPlaceProxyBid{0, addr, sdk.NewInt64Coin("usdx", 10), sdk.Coin{Denom: "kava", Amount: sdk.NewInt(-20)}}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectPass {
+				require.Nil(t, tc.msg.ValidateBasic())
+			} else {
+				require.NotNil(t, tc.msg.ValidateBasic())
+			}
+		})
+	}
+}
+
+func TestMsgPlaceBatchOrder_ValidateBasic(t *testing.T) {
+	addr := sdk.AccAddress([]byte("someName"))
+	tests := []struct {
+		name       string
+		msg        MsgPlaceBatchOrder
+		expectPass bool
+	}{
+		{"normal", MsgPlaceBatchOrder{0, addr, sdk.NewInt(10), sdk.MustNewDecFromStr("1.5")}, true},
+		{"emptyAddr", MsgPlaceBatchOrder{0, sdk.AccAddress{}, sdk.NewInt(10), sdk.MustNewDecFromStr("1.5")}, false},
+		{"zeroAmount", MsgPlaceBatchOrder{0, addr, sdk.NewInt(0), sdk.MustNewDecFromStr("1.5")}, false},
+		{"zeroMaxPrice", MsgPlaceBatchOrder{0, addr, sdk.NewInt(10), sdk.ZeroDec()}, false},
+		{"negativeMaxPrice", MsgPlaceBatchOrder{0, addr, sdk.NewInt(10), sdk.MustNewDecFromStr("-1.5")}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectPass {
+				require.Nil(t, tc.msg.ValidateBasic())
+			} else {
+				require.NotNil(t, tc.msg.ValidateBasic())
+			}
+		})
+	}
+}
+
+func TestMsgCommitBid_ValidateBasic(t *testing.T) {
+	addr := sdk.AccAddress([]byte("someName"))
+	tests := []struct {
+		name       string
+		msg        MsgCommitBid
+		expectPass bool
+	}{
+		{"normal", MsgCommitBid{0, addr, SealedBidHash(sdk.NewInt64Coin("usdx", 10), "salt")}, true},
+		{"emptyAddr", MsgCommitBid{0, sdk.AccAddress{}, SealedBidHash(sdk.NewInt64Coin("usdx", 10), "salt")}, false},
+		{"shortHash", MsgCommitBid{0, addr, []byte("notahash")}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectPass {
+				require.Nil(t, tc.msg.ValidateBasic())
+			} else {
+				require.NotNil(t, tc.msg.ValidateBasic())
+			}
+		})
+	}
+}
+
+func TestMsgRevealBid_ValidateBasic(t *testing.T) {
+	addr := sdk.AccAddress([]byte("someName"))
+	tests := []struct {
+		name       string
+		msg        MsgRevealBid
+		expectPass bool
+	}{
+		{"normal", MsgRevealBid{0, addr, sdk.NewInt64Coin("usdx", 10), "salt"}, true},
+		{"emptyAddr", MsgRevealBid{0, sdk.AccAddress{}, sdk.NewInt64Coin("usdx", 10), "salt"}, false},
+		{"negativeBid", MsgRevealBid{0, addr, sdk.Coin{Denom: "usdx", Amount: sdk.NewInt(-10)}, "salt"}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectPass {
+				require.Nil(t, tc.msg.ValidateBasic())
+			} else {
+				require.NotNil(t, tc.msg.ValidateBasic())
+			}
+		})
+	}
+}
+
+func TestMsgStartForwardAuction_ValidateBasic(t *testing.T) {
+	addr := sdk.AccAddress([]byte("someName"))
+	tests := []struct {
+		name       string
+		msg        MsgStartForwardAuction
+		expectPass bool
+	}{
+		{"normal", NewMsgStartForwardAuction(addr, sdk.NewCoins(sdk.NewInt64Coin("kava", 10)), "usdx", sdk.NewInt(5), time.Hour), true},
+		{"basketLot", NewMsgStartForwardAuction(addr, sdk.NewCoins(sdk.NewInt64Coin("btc", 1), sdk.NewInt64Coin("xrp", 500)), "usdx", sdk.NewInt(5), time.Hour), true},
+		{"basketLotContainsBidDenom", NewMsgStartForwardAuction(addr, sdk.NewCoins(sdk.NewInt64Coin("btc", 1), sdk.NewInt64Coin("usdx", 500)), "usdx", sdk.NewInt(5), time.Hour), false},
+		{"unsortedLot", NewMsgStartForwardAuction(addr, sdk.Coins{sdk.NewInt64Coin("xrp", 500), sdk.NewInt64Coin("btc", 1)}, "usdx", sdk.NewInt(5), time.Hour), false},
+		{"zeroReservePrice", NewMsgStartForwardAuction(addr, sdk.NewCoins(sdk.NewInt64Coin("kava", 10)), "usdx", sdk.ZeroInt(), time.Hour), true},
+		{"emptyAddr", NewMsgStartForwardAuction(sdk.AccAddress{}, sdk.NewCoins(sdk.NewInt64Coin("kava", 10)), "usdx", sdk.NewInt(5), time.Hour), false},
+		{"zeroLot", NewMsgStartForwardAuction(addr, sdk.NewCoins(sdk.NewInt64Coin("kava", 0)), "usdx", sdk.NewInt(5), time.Hour), false},
+		{"sameDenom", NewMsgStartForwardAuction(addr, sdk.NewCoins(sdk.NewInt64Coin("kava", 10)), "kava", sdk.NewInt(5), time.Hour), false},
+		{"emptyDenom", NewMsgStartForwardAuction(addr, sdk.NewCoins(sdk.NewInt64Coin("kava", 10)), "", sdk.NewInt(5), time.Hour), false},
+		{"negativeReservePrice", NewMsgStartForwardAuction(addr, sdk.NewCoins(sdk.NewInt64Coin("kava", 10)), "usdx", sdk.NewInt(-5), time.Hour), false},
+		{"zeroDuration", NewMsgStartForwardAuction(addr, sdk.NewCoins(sdk.NewInt64Coin("kava", 10)), "usdx", sdk.NewInt(5), 0), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectPass {
+				require.Nil(t, tc.msg.ValidateBasic())
+			} else {
+				require.NotNil(t, tc.msg.ValidateBasic())
+			}
+		})
+	}
+}
+
+func TestMsgStartReverseAuction_ValidateBasic(t *testing.T) {
+	addr := sdk.AccAddress([]byte("someName"))
+	tests := []struct {
+		name       string
+		msg        MsgStartReverseAuction
+		expectPass bool
+	}{
+		{"normal", NewMsgStartReverseAuction(addr, sdk.NewInt64Coin("usdx", 10), "kava", sdk.NewInt(5), time.Hour), true},
+		{"emptyAddr", NewMsgStartReverseAuction(sdk.AccAddress{}, sdk.NewInt64Coin("usdx", 10), "kava", sdk.NewInt(5), time.Hour), false},
+		{"zeroBid", NewMsgStartReverseAuction(addr, sdk.NewInt64Coin("usdx", 0), "kava", sdk.NewInt(5), time.Hour), false},
+		{"sameDenom", NewMsgStartReverseAuction(addr, sdk.NewInt64Coin("usdx", 10), "usdx", sdk.NewInt(5), time.Hour), false},
+		{"negativeDuration", NewMsgStartReverseAuction(addr, sdk.NewInt64Coin("usdx", 10), "kava", sdk.NewInt(5), -time.Hour), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectPass {
+				require.Nil(t, tc.msg.ValidateBasic())
+			} else {
+				require.NotNil(t, tc.msg.ValidateBasic())
+			}
+		})
+	}
+}
+
+func TestMsgStartSealedBidAuction_ValidateBasic(t *testing.T) {
+	addr := sdk.AccAddress([]byte("someName"))
+	tests := []struct {
+		name       string
+		msg        MsgStartSealedBidAuction
+		expectPass bool
+	}{
+		{"normal", NewMsgStartSealedBidAuction(addr, sdk.NewInt64Coin("kava", 10), sdk.NewInt64Coin("usdx", 5), sdk.NewInt64Coin("usdx", 1)), true},
+		{"zeroMinBidAndDeposit", NewMsgStartSealedBidAuction(addr, sdk.NewInt64Coin("kava", 10), sdk.NewInt64Coin("usdx", 0), sdk.NewInt64Coin("usdx", 0)), true},
+		{"emptyAddr", NewMsgStartSealedBidAuction(sdk.AccAddress{}, sdk.NewInt64Coin("kava", 10), sdk.NewInt64Coin("usdx", 5), sdk.NewInt64Coin("usdx", 1)), false},
+		{"zeroLot", NewMsgStartSealedBidAuction(addr, sdk.NewInt64Coin("kava", 0), sdk.NewInt64Coin("usdx", 5), sdk.NewInt64Coin("usdx", 1)), false},
+		{"sameDenom", NewMsgStartSealedBidAuction(addr, sdk.NewInt64Coin("kava", 10), sdk.NewInt64Coin("kava", 5), sdk.NewInt64Coin("kava", 1)), false},
+		{"depositDenom", NewMsgStartSealedBidAuction(addr, sdk.NewInt64Coin("kava", 10), sdk.NewInt64Coin("usdx", 5), sdk.NewInt64Coin("btc", 1)), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectPass {
+				require.Nil(t, tc.msg.ValidateBasic())
+			} else {
+				require.NotNil(t, tc.msg.ValidateBasic())
+			}
+		})
+	}
+}
+
+func TestMsgPlacePartialBid_ValidateBasic(t *testing.T) {
+	addr := sdk.AccAddress([]byte("someName"))
+	tests := []struct {
+		name       string
+		msg        MsgPlacePartialBid
+		expectPass bool
+	}{
+		{"normal", NewMsgPlacePartialBid(0, addr, sdk.NewInt64Coin("usdx", 10), sdk.NewInt64Coin("kava", 20)), true},
+		{"emptyAddr", NewMsgPlacePartialBid(0, sdk.AccAddress{}, sdk.NewInt64Coin("usdx", 10), sdk.NewInt64Coin("kava", 20)), false},
+		{"zeroBid", NewMsgPlacePartialBid(0, addr, sdk.NewInt64Coin("usdx", 0), sdk.NewInt64Coin("kava", 20)), false},
+		{"zeroLot", NewMsgPlacePartialBid(0, addr, sdk.NewInt64Coin("usdx", 10), sdk.NewInt64Coin("kava", 0)), false},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
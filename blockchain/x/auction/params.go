@@ -0,0 +1,194 @@
+package auction
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+const (
+	// DefaultParamspace is the params subspace the auction module stores its params in.
+	DefaultParamspace = ModuleName
+	// DefaultMaxAuctionDuration is the default max length of an auction
+	DefaultMaxAuctionDuration = 2 * 24 * time.Hour
+	// DefaultBidDuration is the default length an auction gets extended by when someone bids
+	DefaultBidDuration = 3 * time.Hour
+	// DefaultCommitDuration is the default length of the commit phase of sealed bid auctions
+	DefaultCommitDuration = 24 * time.Hour
+	// DefaultRevealDuration is the default length of the reveal phase of sealed bid auctions
+	DefaultRevealDuration = 24 * time.Hour
+	// DefaultBidHistoryRetention is the default length of time the bid history of an auction is kept after it closes
+	DefaultBidHistoryRetention = 30 * 24 * time.Hour
+	// DefaultCreationDepositDenom is the denom of the default deposit for starting an auction with a msg
+	DefaultCreationDepositDenom = "kava"
+	// DefaultMaxRestarts is the default number of times an auction can restart after closing without bids
+	DefaultMaxRestarts = 3
+	// DefaultMaxClosesPerBlock is the default number of auctions that can be closed in one block
+	DefaultMaxClosesPerBlock = 100
+	// DefaultCloseRetryDelay is the default length of time before closing an auction that failed to close is tried again
+	DefaultCloseRetryDelay = time.Hour
+)
+
+// No bid outcomes, what happens to an auction that closes without any bids
+const (
+	RestartOutcome  = "restart"  // the auction starts again, lasting MaxAuctionDuration
+	ReturnOutcome   = "return"   // the lot is returned to the initiator
+	BackstopOutcome = "backstop" // the backstop account buys the lot at the auction's reserve price
+)
+
+/*
+All the params for this module are stored together in one struct `AuctionParams` under one key, in the same way as the cdp and liquidator modules.
+They can be changed by governance with a param change proposal on the "auction" subspace.
+*/
+
+// AuctionParams are the governance controlled parameters for all auctions.
+type AuctionParams struct {
+	MaxAuctionDuration  time.Duration  `json:"max_auction_duration"`  // Max length of an auction, measured in block time. Known as tau in maker.
+	BidDuration         time.Duration  `json:"bid_duration"`          // How long an auction gets extended when someone bids, measured in block time. Known as ttl in maker.
+	MinBidIncrement     sdk.Dec        `json:"min_bid_increment"`     // Fraction a new bid must be above the current bid in forward auctions (and the forward phase of forward reverse auctions). Known as beg in maker.
+	MinLotDecrement     sdk.Dec        `json:"min_lot_decrement"`     // Fraction a new lot must be below the current lot in reverse auctions (and the reverse phase of forward reverse auctions).
+	CommitDuration      time.Duration  `json:"commit_duration"`       // Length of the phase where sealed bids can be placed in sealed bid auctions.
+	RevealDuration      time.Duration  `json:"reveal_duration"`       // Length of the phase where sealed bids can be revealed in sealed bid auctions.
+	CreationDeposit     sdk.Coin       `json:"creation_deposit"`      // Deposit taken from users starting auctions with a msg, forfeited if the auction gets no bids.
+	BidHistoryRetention time.Duration  `json:"bid_history_retention"` // How long the bid history of an auction is kept after it closes, measured in block time.
+	NoBidOutcomes       NoBidOutcomes  `json:"no_bid_outcomes"`       // What happens to each type of auction if it closes without bids. Types not listed return the lot to the initiator.
+	MaxRestarts         int            `json:"max_restarts"`          // Number of times an auction can restart before its lot is returned to the initiator instead.
+	Backstop            sdk.AccAddress `json:"backstop"`              // Account that buys the lots of auctions with the backstop outcome. Known as the lender of last resort.
+	MaxClosesPerBlock   int            `json:"max_closes_per_block"`  // Most auctions closed in one block, to bound the work done by the begin blocker. Any more are closed in later blocks.
+	CloseRetryDelay     time.Duration  `json:"close_retry_delay"`     // How long an auction that failed to close waits before closing it is tried again.
+}
+
+// NoBidOutcome sets what happens to one type of auction if it closes without bids.
+type NoBidOutcome struct {
+	AuctionType string `json:"auction_type"` // one of the auction types, eg ForwardAuctionType
+	Outcome     string `json:"outcome"`      // one of RestartOutcome, ReturnOutcome or BackstopOutcome
+}
+
+// NoBidOutcomes is a slice of NoBidOutcome
+type NoBidOutcomes []NoBidOutcome
+
+// Get returns the outcome for an auction type, defaulting to ReturnOutcome.
+func (outcomes NoBidOutcomes) Get(auctionType string) string {
+	for _, o := range outcomes {
+		if o.AuctionType == auctionType {
+			return o.Outcome
+		}
+	}
+	return ReturnOutcome
+}
+
+var moduleParamsKey = []byte("AuctionParams")
+
+func createParamsKeyTable() params.KeyTable {
+	return params.NewKeyTable(
+		moduleParamsKey, AuctionParams{},
+	)
+}
+
+// DefaultAuctionParams returns the default params, with bids required to move by at least 3%.
+func DefaultAuctionParams() AuctionParams {
+	return AuctionParams{
+		MaxAuctionDuration:  DefaultMaxAuctionDuration,
+		BidDuration:         DefaultBidDuration,
+		MinBidIncrement:     sdk.MustNewDecFromStr("0.03"),
+		MinLotDecrement:     sdk.MustNewDecFromStr("0.03"),
+		CommitDuration:      DefaultCommitDuration,
+		RevealDuration:      DefaultRevealDuration,
+		CreationDeposit:     sdk.NewInt64Coin(DefaultCreationDepositDenom, 10),
+		BidHistoryRetention: DefaultBidHistoryRetention,
+		NoBidOutcomes:       NoBidOutcomes{},
+		MaxRestarts:         DefaultMaxRestarts,
+		MaxClosesPerBlock:   DefaultMaxClosesPerBlock,
+		CloseRetryDelay:     DefaultCloseRetryDelay,
+	}
+}
+
+// Validate checks the params are within sensible bounds.
+func (p AuctionParams) Validate() error {
+	if p.MaxAuctionDuration <= 0 {
+		return fmt.Errorf("max auction duration must be positive: %s", p.MaxAuctionDuration)
+	}
+	if p.BidDuration <= 0 || p.BidDuration > p.MaxAuctionDuration {
+		return fmt.Errorf("bid duration must be positive and no longer than the max auction duration: %s", p.BidDuration)
+	}
+	if p.MinBidIncrement.IsNegative() {
+		return fmt.Errorf("min bid increment cannot be negative: %s", p.MinBidIncrement)
+	}
+	if p.MinLotDecrement.IsNegative() || p.MinLotDecrement.GTE(sdk.OneDec()) {
+		return fmt.Errorf("min lot decrement must be between 0 and 1: %s", p.MinLotDecrement)
+	}
+	if p.CommitDuration <= 0 || p.RevealDuration <= 0 {
+		return fmt.Errorf("commit and reveal durations must be positive: %s, %s", p.CommitDuration, p.RevealDuration)
+	}
+	if p.CreationDeposit.IsNegative() {
+		return fmt.Errorf("creation deposit cannot be negative: %s", p.CreationDeposit)
+	}
+	if p.BidHistoryRetention < 0 {
+		return fmt.Errorf("bid history retention cannot be negative: %s", p.BidHistoryRetention)
+	}
+	if p.MaxRestarts < 0 {
+		return fmt.Errorf("max restarts cannot be negative: %d", p.MaxRestarts)
+	}
+	if p.MaxClosesPerBlock <= 0 {
+		return fmt.Errorf("max closes per block must be positive: %d", p.MaxClosesPerBlock)
+	}
+	if p.CloseRetryDelay <= 0 {
+		return fmt.Errorf("close retry delay must be positive: %s", p.CloseRetryDelay)
+	}
+	auctionTypes := make(map[string]bool)
+	for _, o := range p.NoBidOutcomes {
+		switch o.AuctionType {
+		case ForwardAuctionType, ReverseAuctionType, ForwardReverseAuctionType, DutchAuctionType, SealedBidAuctionType, BasketAuctionType:
+		default:
+			return fmt.Errorf("no bid outcome has an invalid auction type: %s", o.AuctionType)
+		}
+		if auctionTypes[o.AuctionType] {
+			return fmt.Errorf("no bid outcome for %s is set more than once", o.AuctionType)
+		}
+		auctionTypes[o.AuctionType] = true
+		switch o.Outcome {
+		case RestartOutcome, ReturnOutcome:
+		case BackstopOutcome:
+			if p.Backstop.Empty() {
+				return fmt.Errorf("no bid outcome for %s is backstop, but no backstop account is set", o.AuctionType)
+			}
+		default:
+			return fmt.Errorf("no bid outcome for %s is invalid: %s", o.AuctionType, o.Outcome)
+		}
+	}
+	return nil
+}
+
+// Implement fmt.Stringer interface for cli querying
+func (p AuctionParams) String() string {
+	return fmt.Sprintf(`Params:
+	Max Auction Duration:  %s
+	Bid Duration:          %s
+	Min Bid Increment:     %s
+	Min Lot Decrement:     %s
+	Commit Duration:       %s
+	Reveal Duration:       %s
+	Creation Deposit:      %s
+	Bid History Retention: %s
+	No Bid Outcomes:       %s
+	Max Restarts:          %d
+	Backstop:              %s
+	Max Closes Per Block:  %d
+	Close Retry Delay:     %s`,
+		p.MaxAuctionDuration,
+		p.BidDuration,
+		p.MinBidIncrement,
+		p.MinLotDecrement,
+		p.CommitDuration,
+		p.RevealDuration,
+		p.CreationDeposit,
+		p.BidHistoryRetention,
+		p.NoBidOutcomes,
+		p.MaxRestarts,
+		p.Backstop,
+		p.MaxClosesPerBlock,
+		p.CloseRetryDelay,
+	)
+}
@@ -1,7 +1,8 @@
 package auction
 
 import (
-	"strings"
+	"fmt"
+	"sort"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -9,46 +10,177 @@ import (
 )
 
 const (
+	// QueryGetAuctions command for getting a list of auctions, optionally filtered
+	QueryGetAuctions = "auctions"
 	// QueryGetAuction command for getting the information about a particular auction
-	QueryGetAuction = "getauctions"
+	QueryGetAuction = "auction"
+	// QueryGetBids command for getting the bid history of an auction
+	QueryGetBids = "bids"
+	// QueryGetFailedAuctions command for getting auctions that closed without bids, or one of them if an ID is given
+	QueryGetFailedAuctions = "failed"
 )
 
 // NewQuerier is the module level router for state queries
 func NewQuerier(keeper Keeper) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
 		switch path[0] {
-		case QueryGetAuction:
+		case QueryGetAuctions:
 			return queryAuctions(ctx, req, keeper)
+		case QueryGetAuction:
+			return queryAuction(ctx, path[1:], keeper)
+		case QueryGetBids:
+			return queryBids(ctx, path[1:], keeper)
+		case QueryGetFailedAuctions:
+			return queryFailedAuctions(ctx, path[1:], keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown auction query endpoint")
 		}
 	}
 }
 
-func queryAuctions(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
-	var AuctionsList QueryResAuctions
+// QueryAuctionsParams are the optional filters for an auctions query. Empty fields are not filtered on.
+type QueryAuctionsParams struct {
+	Type      string         `json:"type"`      // one of the auction types, eg ForwardAuctionType
+	Bidder    sdk.AccAddress `json:"bidder"`    // current bidder
+	Initiator sdk.AccAddress `json:"initiator"` // auction initiator
+	Page      int            `json:"page"`      // page number, starting at 1
+	Limit     int            `json:"limit"`     // number of auctions per page, 0 returns all matching auctions
+}
+
+// NewQueryAuctionsParams creates a new QueryAuctionsParams
+func NewQueryAuctionsParams(auctionType string, bidder, initiator sdk.AccAddress, page, limit int) QueryAuctionsParams {
+	return QueryAuctionsParams{
+		Type:      auctionType,
+		Bidder:    bidder,
+		Initiator: initiator,
+		Page:      page,
+		Limit:     limit,
+	}
+}
+
+func (p QueryAuctionsParams) matches(auction Auction) bool {
+	if p.Type != "" && auction.GetType() != p.Type {
+		return false
+	}
+	if len(p.Bidder) != 0 && !auction.GetBidder().Equals(p.Bidder) {
+		return false
+	}
+	if len(p.Initiator) != 0 && !auction.GetInitiator().Equals(p.Initiator) {
+		return false
+	}
+	return true
+}
+
+// queryAuctions fetches auctions ordered by ID, filtered and paginated by the query params (in QueryAuctionsParams).
+func queryAuctions(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	// Decode request
+	var requestParams QueryAuctionsParams
+	if len(req.Data) != 0 {
+		err := keeper.cdc.UnmarshalJSON(req.Data, &requestParams)
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("failed to parse params: %s", err))
+		}
+	}
+	if requestParams.Page < 0 || requestParams.Limit < 0 {
+		return nil, sdk.ErrUnknownRequest("page and limit must not be negative")
+	}
 
-	iterator := keeper.GetAuctionIterator(ctx)
+	// Get auctions
+	auctions := Auctions{}
+	keeper.IterateAuctions(ctx, func(auction Auction) bool {
+		if requestParams.matches(auction) {
+			auctions = append(auctions, auction)
+		}
+		return false
+	})
+	// auction keys are not stored in numerical order, so sort before paginating
+	sort.Slice(auctions, func(i, j int) bool { return auctions[i].GetID() < auctions[j].GetID() })
+	auctions = paginate(auctions, requestParams.Page, requestParams.Limit)
 
-	for ; iterator.Valid(); iterator.Next() {
+	// Encode results
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, auctions)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
 
-		var auction Auction
-		keeper.cdc.MustUnmarshalBinaryBare(iterator.Value(), &auction)
-		AuctionsList = append(AuctionsList, auction.String())
+// queryAuction fetches a single auction by ID, passed as the first path element.
+func queryAuction(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("no auction ID specified")
+	}
+	id, err := NewIDFromString(path[0])
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid auction ID: %s", err))
 	}
 
-	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, AuctionsList)
-	if err2 != nil {
-		panic("could not marshal result to JSON")
+	auction, found := keeper.GetAuction(ctx, id)
+	if !found {
+		return nil, ErrAuctionNotFound(DefaultCodespace, id)
 	}
 
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, auction)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
 	return bz, nil
 }
 
-// QueryResAuctions Result Payload for an auctions query
-type QueryResAuctions []string
+// paginate returns one page of auctions. Pages start at 1, page 0 is treated as page 1, and a limit of 0 returns all auctions.
+func paginate(auctions Auctions, page, limit int) Auctions {
+	if limit == 0 {
+		return auctions
+	}
+	if page == 0 {
+		page = 1
+	}
+	start := (page - 1) * limit
+	if start >= len(auctions) {
+		return Auctions{}
+	}
+	end := start + limit
+	if end > len(auctions) {
+		end = len(auctions)
+	}
+	return auctions[start:end]
+}
+
+// queryBids fetches the bid history of an auction by ID, passed as the first path element. Bid histories are kept for a while after auctions close.
+func queryBids(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("no auction ID specified")
+	}
+	id, err := NewIDFromString(path[0])
+	if err != nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid auction ID: %s", err))
+	}
 
-// implement fmt.Stringer
-func (n QueryResAuctions) String() string {
-	return strings.Join(n[:], "\n")
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetBids(ctx, id))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+// queryFailedAuctions fetches the records of auctions that closed without bids, or just one if an ID is passed as the first path element.
+func queryFailedAuctions(ctx sdk.Context, path []string, keeper Keeper) ([]byte, sdk.Error) {
+	var result interface{} = keeper.GetFailedAuctions(ctx)
+	if len(path) != 0 {
+		id, err := NewIDFromString(path[0])
+		if err != nil {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid auction ID: %s", err))
+		}
+		failedAuction, found := keeper.GetFailedAuction(ctx, id)
+		if !found {
+			return nil, ErrFailedAuctionNotFound(DefaultCodespace, id)
+		}
+		result = failedAuction
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
 }
@@ -0,0 +1,170 @@
+package auction
+
+import (
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestQuerier_Auctions(t *testing.T) {
+	// setup keeper
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	querier := NewQuerier(keeper)
+
+	// start more than 10 auctions so the store order (by decimal ID) differs from numerical order
+	for i := 0; i < 6; i++ {
+		_, err := keeper.StartForwardAuction(ctx, addresses[0], sdk.NewInt64Coin("token1", 1), sdk.NewInt64Coin("token2", 0))
+		require.NoError(t, err)
+		_, err = keeper.StartReverseAuction(ctx, addresses[1], sdk.NewInt64Coin("token1", 1), sdk.NewInt64Coin("token2", 1))
+		require.NoError(t, err)
+	}
+	_, err := keeper.StartForwardReverseAuction(ctx, addresses[2], sdk.NewInt64Coin("token1", 1), sdk.NewInt64Coin("token2", 5), sdk.ZeroInt(), addresses[3])
+	require.NoError(t, err)
+	// place a bid so one auction has a bidder other than its initiator
+	require.NoError(t, keeper.PlaceBid(ctx, 2, addresses[4], sdk.NewInt64Coin("token2", 1), sdk.NewInt64Coin("token1", 1)))
+
+	tests := []struct {
+		name        string
+		params      QueryAuctionsParams
+		expectedIDs []ID
+	}{
+		{"all", QueryAuctionsParams{}, []ID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+		{"type", QueryAuctionsParams{Type: ReverseAuctionType}, []ID{1, 3, 5, 7, 9, 11}},
+		{"initiator", QueryAuctionsParams{Initiator: addresses[2]}, []ID{12}},
+		{"bidder", QueryAuctionsParams{Bidder: addresses[4]}, []ID{2}},
+		{"combined", QueryAuctionsParams{Type: ForwardAuctionType, Bidder: addresses[0]}, []ID{0, 4, 6, 8, 10}},
+		{"firstPage", QueryAuctionsParams{Page: 1, Limit: 5}, []ID{0, 1, 2, 3, 4}},
+		{"lastPage", QueryAuctionsParams{Page: 3, Limit: 5}, []ID{10, 11, 12}},
+		{"pastLastPage", QueryAuctionsParams{Page: 4, Limit: 5}, []ID{}},
+		{"noMatches", QueryAuctionsParams{Type: "unknown"}, []ID{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bz, err := keeper.cdc.MarshalJSON(tc.params)
+			require.NoError(t, err)
+			res, sdkErr := querier(ctx, []string{QueryGetAuctions}, abci.RequestQuery{Data: bz})
+			require.Nil(t, sdkErr)
+
+			var auctions Auctions
+			keeper.cdc.MustUnmarshalJSON(res, &auctions)
+			ids := []ID{}
+			for _, a := range auctions {
+				ids = append(ids, a.GetID())
+			}
+			require.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+
+	// negative pagination and malformed params are rejected as bad requests
+	for _, data := range [][]byte{
+		keeper.cdc.MustMarshalJSON(QueryAuctionsParams{Page: -1, Limit: 5}),
+		keeper.cdc.MustMarshalJSON(QueryAuctionsParams{Page: 1, Limit: -5}),
+		[]byte("{"),
+	} {
+		_, sdkErr := querier(ctx, []string{QueryGetAuctions}, abci.RequestQuery{Data: data})
+		require.NotNil(t, sdkErr)
+		require.Equal(t, sdk.CodeUnknownRequest, sdkErr.Code())
+	}
+}
+
+func TestQuerier_Auction(t *testing.T) {
+	// setup keeper
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	querier := NewQuerier(keeper)
+	id, err := keeper.StartForwardReverseAuction(ctx, addresses[0], sdk.NewInt64Coin("token1", 1), sdk.NewInt64Coin("token2", 5), sdk.ZeroInt(), addresses[1])
+	require.NoError(t, err)
+
+	// query an existing auction
+	res, sdkErr := querier(ctx, []string{QueryGetAuction, fmt.Sprint(id)}, abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	var auction Auction
+	keeper.cdc.MustUnmarshalJSON(res, &auction)
+	expected, _ := keeper.GetAuction(ctx, id)
+	require.Equal(t, expected, auction)
+	// check the JSON is typed
+	require.Contains(t, string(res), `"type": "auction/ForwardReverseAuction"`)
+	require.Contains(t, string(res), `"other_person"`)
+
+	// query missing and invalid IDs
+	_, sdkErr = querier(ctx, []string{QueryGetAuction, "10"}, abci.RequestQuery{})
+	require.NotNil(t, sdkErr)
+	require.Equal(t, DefaultCodespace, sdkErr.Codespace())
+	require.Equal(t, CodeAuctionNotFound, sdkErr.Code())
+	_, sdkErr = querier(ctx, []string{QueryGetAuction, "notanid"}, abci.RequestQuery{})
+	require.NotNil(t, sdkErr)
+	require.Equal(t, sdk.CodeUnknownRequest, sdkErr.Code())
+	_, sdkErr = querier(ctx, []string{QueryGetAuction}, abci.RequestQuery{})
+	require.NotNil(t, sdkErr)
+}
+
+func TestQuerier_Bids(t *testing.T) {
+	// setup keeper, create an auction with a bid
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	querier := NewQuerier(keeper)
+	id, err := keeper.StartForwardReverseAuction(ctx, addresses[0], sdk.NewInt64Coin("token1", 1), sdk.NewInt64Coin("token2", 5), sdk.ZeroInt(), addresses[1])
+	require.NoError(t, err)
+	require.NoError(t, keeper.PlaceBid(ctx, id, addresses[2], sdk.NewInt64Coin("token2", 2), sdk.NewInt64Coin("token1", 1)))
+
+	// query the bids
+	res, sdkErr := querier(ctx, []string{QueryGetBids, fmt.Sprint(id)}, abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	var bids BidRecords
+	keeper.cdc.MustUnmarshalJSON(res, &bids)
+	require.Equal(t, keeper.GetBids(ctx, id), bids)
+	require.Len(t, bids, 1)
+	require.Contains(t, string(res), `"phase": "forward"`)
+
+	// query invalid IDs
+	_, sdkErr = querier(ctx, []string{QueryGetBids, "notanid"}, abci.RequestQuery{})
+	require.NotNil(t, sdkErr)
+	_, sdkErr = querier(ctx, []string{QueryGetBids}, abci.RequestQuery{})
+	require.NotNil(t, sdkErr)
+}
+
+func TestQuerier_FailedAuctions(t *testing.T) {
+	// setup keeper, close one auction without bids
+	mapp, keeper, addresses, _ := setUpMockApp()
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	querier := NewQuerier(keeper)
+	failedID, err := keeper.StartForwardAuction(ctx, addresses[0], sdk.NewInt64Coin("token1", 1), sdk.NewInt64Coin("token2", 0))
+	require.NoError(t, err)
+	_, err = keeper.StartForwardAuction(ctx, addresses[0], sdk.NewInt64Coin("token1", 1), sdk.NewInt64Coin("token2", 0))
+	require.NoError(t, err)
+	require.NoError(t, keeper.CloseAuction(ctx.WithBlockTime(header.Time.Add(DefaultMaxAuctionDuration)), failedID))
+
+	// query all failed auctions
+	res, sdkErr := querier(ctx, []string{QueryGetFailedAuctions}, abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	var failedAuctions FailedAuctions
+	keeper.cdc.MustUnmarshalJSON(res, &failedAuctions)
+	require.Len(t, failedAuctions, 1)
+	require.Equal(t, failedID, failedAuctions[0].Auction.GetID())
+
+	// query one failed auction
+	res, sdkErr = querier(ctx, []string{QueryGetFailedAuctions, fmt.Sprint(failedID)}, abci.RequestQuery{})
+	require.Nil(t, sdkErr)
+	var failedAuction FailedAuction
+	keeper.cdc.MustUnmarshalJSON(res, &failedAuction)
+	require.Equal(t, ReturnOutcome, failedAuction.Outcome)
+
+	// query auctions that haven't failed and invalid IDs
+	_, sdkErr = querier(ctx, []string{QueryGetFailedAuctions, fmt.Sprint(failedID + 1)}, abci.RequestQuery{})
+	require.NotNil(t, sdkErr)
+	require.Equal(t, CodeFailedAuctionNotFound, sdkErr.Code())
+	_, sdkErr = querier(ctx, []string{QueryGetFailedAuctions, "notanid"}, abci.RequestQuery{})
+	require.NotNil(t, sdkErr)
+}
@@ -1,8 +1,12 @@
 package auction
 
-// Go doesn't have a built in min function for integers :(
-func min(a, b int64) int64 {
-	if a < b {
+import (
+	"time"
+)
+
+// Go doesn't have a built in min function for times :(
+func earliestTime(a, b time.Time) time.Time {
+	if a.Before(b) {
 		return a
 	}
 	return b
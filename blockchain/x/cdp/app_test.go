@@ -20,6 +20,7 @@ func TestApp_CreateModifyDeleteCDP(t *testing.T) {
 	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
 	ctx := mapp.BaseApp.NewContext(false, header)
 	keeper.pricefeed.AddAsset(ctx, "xrp", "xrp test")
+	keeper.pricefeed.AddOracle(ctx, sdk.AccAddress{}.String())
 	keeper.pricefeed.SetPrice(
 		ctx, sdk.AccAddress{}, "xrp",
 		sdk.MustNewDecFromStr("1.00"),
@@ -16,6 +16,8 @@ type pricefeedKeeper interface {
 	GetCurrentPrice(sdk.Context, string) pricefeed.CurrentPrice
 	// These are used for testing TODO replace mockApp with keeper in tests to remove these
 	AddAsset(sdk.Context, string, string)
+	AddOracle(sdk.Context, string) sdk.Error
+	SetAssetQuorum(sdk.Context, string, int, sdk.Dec) sdk.Error
 	SetPrice(sdk.Context, sdk.AccAddress, string, sdk.Dec, sdk.Int) (pricefeed.PostedPrice, sdk.Error)
-	SetCurrentPrices(sdk.Context) sdk.Error
+	SetCurrentPrices(sdk.Context) sdk.Tags
 }
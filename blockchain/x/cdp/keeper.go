@@ -8,6 +8,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
+
+	"github.com/kava-labs/kava-devnet/blockchain/x/pricefeed"
 )
 
 // StableDenom asset code of the dollar-denominated debt coin
@@ -77,8 +79,12 @@ func (k Keeper) ModifyCDP(ctx sdk.Context, owner sdk.AccAddress, collateralDenom
 	if cdp.Debt.IsNegative() {
 		return sdk.ErrInternal("can't pay back more debt than exists in CDP")
 	}
+	price := k.pricefeed.GetCurrentPrice(ctx, cdp.CollateralDenom)
+	if price.Status != pricefeed.PriceFresh && (changeInDebt.IsPositive() || changeInCollateral.IsNegative()) {
+		return sdk.ErrInternal("collateral price is stale or missing, debt can't be drawn or collateral withdrawn until it is updated")
+	}
 	isUnderCollateralized := cdp.IsUnderCollateralized(
-		k.pricefeed.GetCurrentPrice(ctx, cdp.CollateralDenom).Price,
+		price.Price,
 		p.GetCollateralParams(cdp.CollateralDenom).LiquidationRatio,
 	)
 	if isUnderCollateralized {
@@ -159,8 +165,12 @@ func (k Keeper) PartialSeizeCDP(ctx sdk.Context, owner sdk.AccAddress, collatera
 
 	// Check if CDP is undercollateralized
 	p := k.GetParams(ctx)
+	price := k.pricefeed.GetCurrentPrice(ctx, cdp.CollateralDenom)
+	if price.Status != pricefeed.PriceFresh {
+		return sdk.ErrInternal("collateral price is stale or missing, CDPs can't be seized until it is updated")
+	}
 	isUnderCollateralized := cdp.IsUnderCollateralized(
-		k.pricefeed.GetCurrentPrice(ctx, cdp.CollateralDenom).Price,
+		price.Price,
 		p.GetCollateralParams(cdp.CollateralDenom).LiquidationRatio,
 	)
 	if !isUnderCollateralized {
@@ -208,6 +218,34 @@ func (k Keeper) PartialSeizeCDP(ctx sdk.Context, owner sdk.AccAddress, collatera
 	return nil
 }
 
+// RestoreSeizedCDP puts collateral and debt seized by PartialSeizeCDP back into a CDP, recreating it if it was fully seized.
+// It is used by the liquidator when a collateral auction is cancelled. Global debt is unchanged as it isn't reduced when debt is seized.
+// The caller is responsible for removing the collateral from wherever it is held.
+func (k Keeper) RestoreSeizedCDP(ctx sdk.Context, owner sdk.AccAddress, collateralDenom string, collateralToRestore sdk.Int, debtToRestore sdk.Int) sdk.Error {
+	if collateralToRestore.IsNegative() || debtToRestore.IsNegative() {
+		return sdk.ErrInternal("cannot restore negative collateral or debt")
+	}
+	collateralState, found := k.GetCollateralState(ctx, collateralDenom)
+	if !found {
+		return sdk.ErrInternal("could not find collateral state")
+	}
+	cdp, found := k.GetCDP(ctx, owner, collateralDenom)
+	if !found {
+		cdp = CDP{Owner: owner, CollateralDenom: collateralDenom, CollateralAmount: sdk.ZeroInt(), Debt: sdk.ZeroInt()}
+	}
+	cdp.CollateralAmount = cdp.CollateralAmount.Add(collateralToRestore)
+	cdp.Debt = cdp.Debt.Add(debtToRestore)
+	collateralState.TotalDebt = collateralState.TotalDebt.Add(debtToRestore)
+
+	// Store updated state
+	if cdp.CollateralAmount.IsZero() && cdp.Debt.IsZero() {
+		return nil
+	}
+	k.setCDP(ctx, cdp)
+	k.setCollateralState(ctx, collateralState)
+	return nil
+}
+
 // ReduceGlobalDebt decreases the stored global debt counter. It is used by the liquidator when it annihilates debt and stable coin.
 // TODO Can the interface between cdp and liquidator modules be improved so that this function doesn't exist?
 func (k Keeper) ReduceGlobalDebt(ctx sdk.Context, amount sdk.Int) sdk.Error {
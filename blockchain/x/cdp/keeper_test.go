@@ -9,6 +9,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/kava-labs/kava-devnet/blockchain/x/pricefeed"
 )
 
 // How could one reduce the number of params in the test cases. Create a table driven test for each of the 4 add/withdraw collateral/debt?
@@ -104,6 +106,7 @@ func TestKeeper_ModifyCDP(t *testing.T) {
 			ctx := mapp.BaseApp.NewContext(false, header)
 			// setup store state
 			keeper.pricefeed.AddAsset(ctx, "xrp", "xrp test")
+			keeper.pricefeed.AddOracle(ctx, sdk.AccAddress{}.String())
 			keeper.pricefeed.SetPrice(
 				ctx, sdk.AccAddress{}, "xrp",
 				sdk.MustNewDecFromStr(tc.price),
@@ -160,6 +163,7 @@ func TestKeeper_PartialSeizeCDP(t *testing.T) {
 	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
 	ctx := mapp.BaseApp.NewContext(false, header)
 	keeper.pricefeed.AddAsset(ctx, collateral, "test description")
+	keeper.pricefeed.AddOracle(ctx, sdk.AccAddress{}.String())
 	keeper.pricefeed.SetPrice(
 		ctx, sdk.AccAddress{}, collateral,
 		sdk.MustNewDecFromStr("1.00"),
@@ -185,6 +189,49 @@ func TestKeeper_PartialSeizeCDP(t *testing.T) {
 	collateralState, found := keeper.GetCollateralState(ctx, collateral)
 	require.True(t, found)
 	require.Equal(t, sdk.ZeroInt(), collateralState.TotalDebt)
+
+	// Restore the seized collateral and debt, recreating the CDP
+	err = keeper.RestoreSeizedCDP(ctx, testAddr, collateral, i(10), i(5))
+	require.NoError(t, err)
+	cdp, found := keeper.GetCDP(ctx, testAddr, collateral)
+	require.True(t, found)
+	require.Equal(t, CDP{testAddr, collateral, i(10), i(5)}, cdp)
+	collateralState, _ = keeper.GetCollateralState(ctx, collateral)
+	require.Equal(t, i(5), collateralState.TotalDebt)
+	require.Equal(t, i(5), keeper.GetGlobalDebt(ctx))
+}
+
+func TestKeeper_StalePrice(t *testing.T) {
+	// Setup
+	const collateral = "xrp"
+	mapp, keeper := setUpMockAppWithoutGenesis()
+	genAccs, addrs, _, _ := mock.CreateGenAccounts(1, cs(c(collateral, 100)))
+	testAddr := addrs[0]
+	mock.SetGenesis(mapp, genAccs)
+	header := abci.Header{Height: mapp.LastBlockHeight() + 1}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := mapp.BaseApp.NewContext(false, header)
+	keeper.pricefeed.AddAsset(ctx, collateral, "test description")
+	keeper.pricefeed.AddOracle(ctx, sdk.AccAddress{}.String())
+	keeper.pricefeed.SetPrice(ctx, sdk.AccAddress{}, collateral, sdk.MustNewDecFromStr("1.00"), i(10))
+	keeper.pricefeed.SetCurrentPrices(ctx)
+	require.NoError(t, keeper.ModifyCDP(ctx, testAddr, collateral, i(10), i(5)))
+
+	// Drop the price, without enough oracles posting for it to be updated
+	require.NoError(t, keeper.pricefeed.SetAssetQuorum(ctx, collateral, 2, sdk.ZeroDec()))
+	keeper.pricefeed.SetPrice(ctx, sdk.AccAddress{}, collateral, sdk.MustNewDecFromStr("0.10"), i(10))
+	keeper.pricefeed.SetCurrentPrices(ctx)
+	require.Equal(t, pricefeed.PriceStale, keeper.pricefeed.GetCurrentPrice(ctx, collateral).Status)
+
+	// Check debt can't be drawn and collateral can't be withdrawn, but debt can be repaid and collateral added
+	require.Error(t, keeper.ModifyCDP(ctx, testAddr, collateral, i(0), i(1)))
+	require.Error(t, keeper.ModifyCDP(ctx, testAddr, collateral, i(-1), i(0)))
+	require.NoError(t, keeper.ModifyCDP(ctx, testAddr, collateral, i(1), i(-1)))
+
+	// Check the CDP can't be seized
+	require.Error(t, keeper.PartialSeizeCDP(ctx, testAddr, collateral, i(11), i(4)))
+	_, found := keeper.GetCDP(ctx, testAddr, collateral)
+	require.True(t, found)
 }
 
 func TestKeeper_GetCDPs(t *testing.T) {
@@ -28,7 +28,7 @@ func setUpMockAppWithoutGenesis() (*mock.App, Keeper) {
 	// Create keepers
 	keyCDP := sdk.NewKVStoreKey("cdp")
 	keyPriceFeed := sdk.NewKVStoreKey(pricefeed.StoreKey)
-	priceFeedKeeper := pricefeed.NewKeeper(keyPriceFeed, mapp.Cdc, pricefeed.DefaultCodespace)
+	priceFeedKeeper := pricefeed.NewKeeper(keyPriceFeed, mapp.Cdc, mapp.ParamsKeeper.Subspace("pricefeedSubspace"), pricefeed.DefaultCodespace)
 	bankKeeper := bank.NewBaseKeeper(mapp.AccountKeeper, mapp.ParamsKeeper.Subspace(bank.DefaultParamspace), bank.DefaultCodespace)
 	cdpKeeper := NewKeeper(mapp.Cdc, keyCDP, mapp.ParamsKeeper.Subspace("cdpSubspace"), priceFeedKeeper, bankKeeper)
 
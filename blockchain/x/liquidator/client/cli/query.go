@@ -31,3 +31,154 @@ func GetCmd_GetOutstandingDebt(queryRoute string, cdc *codec.Codec) *cobra.Comma
 		},
 	}
 }
+
+// GetCmd_GetBadDebt queries for the total bad debt realized by collateral auctions.
+func GetCmd_GetBadDebt(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "bad-debt [collateral-denom]",
+		Short: "get the bad debt from collateral auctions",
+		Long:  "Get the seized debt that collateral auctions have failed to cover. Specify a collateral denom to get only the bad debt for that collateral type.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, liquidator.QueryGetBadDebt)
+			if len(args) == 1 {
+				route = fmt.Sprintf("custom/%s/%s/%s", queryRoute, liquidator.QueryGetCollateralBadDebt, args[0])
+			}
+			res, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+			var badDebt sdk.Int
+			cdc.MustUnmarshalJSON(res, &badDebt)
+			return cliCtx.PrintOutput(badDebt)
+		},
+	}
+}
+
+// GetCmd_GetUnderwaterAuctions queries for the collateral auctions that closed without covering their seized debt.
+func GetCmd_GetUnderwaterAuctions(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "underwater-auctions",
+		Short: "get the collateral auctions that created bad debt",
+		Long:  "Get a record of each collateral auction that closed without raising enough stable coin to cover the debt seized for it.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, liquidator.QueryGetUnderwaterAuctions), nil)
+			if err != nil {
+				return err
+			}
+			var records liquidator.BadDebtRecords
+			cdc.MustUnmarshalJSON(res, &records)
+			return cliCtx.PrintOutput(records)
+		},
+	}
+}
+
+// GetCmd_GetSeizedDebt queries for the total debt seized from CDPs, the portion sent to debt auctions, and the portion still available.
+func GetCmd_GetSeizedDebt(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "seized-debt",
+		Short: "get the seized debt record",
+		Long:  "Get the total debt seized from CDPs, the portion sent to debt auctions, and the portion still available.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, liquidator.QueryGetSeizedDebt), nil)
+			if err != nil {
+				return err
+			}
+			var out liquidator.SeizedDebtResponse
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmd_GetModuleAccount queries for the coins held by the liquidator module account, per denom.
+func GetCmd_GetModuleAccount(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "account",
+		Short: "get the liquidator module account balances",
+		Long:  "Get the coins held by the liquidator module account, per denom.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, liquidator.QueryGetModuleAccount), nil)
+			if err != nil {
+				return err
+			}
+			var out sdk.Coins
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmd_GetSurplus queries for the stable coin held by the liquidator in excess of the seized debt.
+func GetCmd_GetSurplus(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "surplus",
+		Short: "get the liquidator surplus",
+		Long:  "Get the stable coin held by the liquidator in excess of the seized debt.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, liquidator.QueryGetSurplus), nil)
+			if err != nil {
+				return err
+			}
+			var out sdk.Int
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmd_GetAuctions queries for the open auctions started by the liquidator, grouped into collateral auctions and debt auctions.
+func GetCmd_GetAuctions(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "auctions",
+		Short: "get the open liquidator auctions",
+		Long:  "Get the open auctions started by the liquidator, grouped into collateral auctions and debt auctions.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, liquidator.QueryGetAuctions), nil)
+			if err != nil {
+				return err
+			}
+			var out liquidator.LiquidatorAuctions
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmd_GetParams queries for the current global liquidator module parameters.
+func GetCmd_GetParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "get the liquidator params",
+		Long:  "Get the current global liquidator module parameters.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, liquidator.QueryGetParams), nil)
+			if err != nil {
+				return err
+			}
+			var out liquidator.LiquidatorModuleParams
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
@@ -1,13 +1,19 @@
 package cli
 
 import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/spf13/cobra"
 
+	"github.com/kava-labs/kava-devnet/blockchain/x/auction"
 	"github.com/kava-labs/kava-devnet/blockchain/x/liquidator"
 )
 
@@ -15,10 +21,11 @@ func GetCmd_SeizeAndStartCollateralAuction(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "seize [cdp-owner] [collateral-denom]",
 		Short: "seize funds from a CDP and send to auction",
-		Long: `Seize a fixed amount of collateral and debt from a CDP then start an auction with the collateral.
-The amount of collateral seized is given by the 'AuctionSize' module parameter or, if there isn't enough collateral in the CDP, all the CDP's collateral is seized.
-Debt is seized in proportion to the collateral seized so that the CDP stays at the same collateral to debt ratio.
-A 'forward-reverse' auction is started selling the seized collateral for some stable coin, with a maximum bid of stable coin set to equal the debt seized.
+		Long: `Seize collateral and debt from a CDP then start an auction with the collateral.
+If the CDP's collateral ratio is above the 'FloorRatio' module parameter, it is partially liquidated: just enough collateral and debt are seized to bring the CDP back up to the 'TargetRatio' parameter.
+Otherwise the CDP is fully liquidated: all its collateral is seized, with debt seized in proportion so that the CDP stays at the same collateral to debt ratio.
+In both cases no more than the 'AuctionSize' module parameter of collateral is seized at once.
+A 'forward-reverse' auction is started selling the seized collateral for some stable coin, with a maximum bid of stable coin set to equal the debt seized plus the 'LiquidationPenalty'.
 As this is a forward-reverse auction type, if the max stable coin is bid then bidding continues by bidding down the amount of collateral taken by the bidder. At the end, extra collateral is returned to the original CDP owner.`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -73,3 +80,96 @@ func GetCmd_StartDebtAuction(cdc *codec.Codec) *cobra.Command {
 	}
 	return cmd
 }
+
+// AuctionsProposalJSON is the contents of a proposal file for freezing or cancelling auctions
+type AuctionsProposalJSON struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	AuctionIDs  []auction.ID `json:"auction_ids"`
+	StartHeight int64        `json:"start_height"`
+	EndHeight   int64        `json:"end_height"`
+	Deposit     sdk.Coins    `json:"deposit"`
+}
+
+const auctionsProposalExample = `
+Where proposal.json contains:
+
+{
+  "title": "%s auctions from bad price",
+  "description": "A bad oracle price caused liquidations in blocks 1000 to 1010",
+  "auction_ids": ["4"],
+  "start_height": "1000",
+  "end_height": "1010",
+  "deposit": [
+    {
+      "denom": "kava",
+      "amount": "10000"
+    }
+  ]
+}
+
+Auctions are chosen by ID, and if end_height is set, by the block height of the liquidations the collateral auctions were started for.
+Leave out the heights to only choose auctions by ID.
+`
+
+func GetCmd_SubmitFreezeAuctionsProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "freeze-auctions [proposal-file]",
+		Short: "Submit a proposal to freeze auctions",
+		Long: strings.TrimSpace(`Submit a proposal to stop auctions accepting bids or closing, along with an initial deposit. Frozen auctions can then be cancelled by another proposal.
+The proposal details must be supplied via a JSON file.
+` + fmt.Sprintf(auctionsProposalExample, "Freeze")),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitAuctionsProposal(cdc, args[0], func(p AuctionsProposalJSON) gov.Content {
+				return liquidator.NewFreezeAuctionsProposal(p.Title, p.Description, p.AuctionIDs, p.StartHeight, p.EndHeight)
+			})
+		},
+	}
+	return cmd
+}
+
+func GetCmd_SubmitCancelAuctionsProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-auctions [proposal-file]",
+		Short: "Submit a proposal to cancel auctions",
+		Long: strings.TrimSpace(`Submit a proposal to cancel auctions, along with an initial deposit. Bids are refunded and lots returned, with the collateral and debt of the liquidator's collateral auctions restored to the CDPs it was seized from.
+The proposal details must be supplied via a JSON file.
+` + fmt.Sprintf(auctionsProposalExample, "Cancel")),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitAuctionsProposal(cdc, args[0], func(p AuctionsProposalJSON) gov.Content {
+				return liquidator.NewCancelAuctionsProposal(p.Title, p.Description, p.AuctionIDs, p.StartHeight, p.EndHeight)
+			})
+		},
+	}
+	return cmd
+}
+
+// submitAuctionsProposal reads a proposal file and submits the proposal content made from it
+func submitAuctionsProposal(cdc *codec.Codec, proposalFile string, makeContent func(AuctionsProposalJSON) gov.Content) error {
+	// Setup
+	txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+	cliCtx := context.NewCLIContext().
+		WithCodec(cdc).
+		WithAccountDecoder(cdc)
+
+	// Read proposal
+	var proposal AuctionsProposalJSON
+	contents, err := ioutil.ReadFile(proposalFile)
+	if err != nil {
+		return err
+	}
+	err = cdc.UnmarshalJSON(contents, &proposal)
+	if err != nil {
+		return err
+	}
+
+	// Prepare and send message
+	msg := gov.NewMsgSubmitProposal(makeContent(proposal), proposal.Deposit, cliCtx.GetFromAddress())
+	err = msg.ValidateBasic()
+	if err != nil {
+		return err
+	}
+	return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+}
@@ -28,6 +28,13 @@ func (mc ModuleClient) GetQueryCmd() *cobra.Command {
 
 	queryCmd.AddCommand(client.GetCommands(
 		cli.GetCmd_GetOutstandingDebt(mc.storeKey, mc.cdc),
+		cli.GetCmd_GetBadDebt(mc.storeKey, mc.cdc),
+		cli.GetCmd_GetUnderwaterAuctions(mc.storeKey, mc.cdc),
+		cli.GetCmd_GetSeizedDebt(mc.storeKey, mc.cdc),
+		cli.GetCmd_GetModuleAccount(mc.storeKey, mc.cdc),
+		cli.GetCmd_GetSurplus(mc.storeKey, mc.cdc),
+		cli.GetCmd_GetAuctions(mc.storeKey, mc.cdc),
+		cli.GetCmd_GetParams(mc.storeKey, mc.cdc),
 	)...)
 
 	return queryCmd
@@ -14,9 +14,19 @@ import (
 	"github.com/kava-labs/kava-devnet/blockchain/x/liquidator"
 )
 
+const restCollateralDenom = "collateral_denom"
+
 // RegisterRoutes - Central function to define routes that get registered by the main application
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
 	r.HandleFunc("/liquidator/outstandingdebt", queryDebtHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/liquidator/baddebt", queryBadDebtHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/liquidator/baddebt/{%s}", restCollateralDenom), queryCollateralBadDebtHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/liquidator/underwaterauctions", queryUnderwaterAuctionsHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/liquidator/seizeddebt", queryHandlerFn(cdc, cliCtx, liquidator.QueryGetSeizedDebt)).Methods("GET")
+	r.HandleFunc("/liquidator/account", queryHandlerFn(cdc, cliCtx, liquidator.QueryGetModuleAccount)).Methods("GET")
+	r.HandleFunc("/liquidator/surplus", queryHandlerFn(cdc, cliCtx, liquidator.QueryGetSurplus)).Methods("GET")
+	r.HandleFunc("/liquidator/auctions", queryHandlerFn(cdc, cliCtx, liquidator.QueryGetAuctions)).Methods("GET")
+	r.HandleFunc("/liquidator/params", queryHandlerFn(cdc, cliCtx, liquidator.QueryGetParams)).Methods("GET")
 	r.HandleFunc("/liquidator/seize", seizeCdpHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc("/liquidator/mint", debtAuctionHandlerFn(cdc, cliCtx)).Methods("POST")
 	// r.HandleFunc("liquidator/burn", surplusAuctionHandlerFn(cdc, cliCtx).Methods("POST"))
@@ -33,6 +43,52 @@ func queryDebtHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Handle
 	}
 }
 
+func queryBadDebtHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/liquidator/%s", liquidator.QueryGetBadDebt), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+func queryCollateralBadDebtHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		denom := mux.Vars(r)[restCollateralDenom]
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/liquidator/%s/%s", liquidator.QueryGetCollateralBadDebt, denom), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+func queryUnderwaterAuctionsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/liquidator/%s", liquidator.QueryGetUnderwaterAuctions), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+// queryHandlerFn forwards a GET request to a liquidator query endpoint that takes no arguments.
+func queryHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext, queryPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/liquidator/%s", queryPath), nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		rest.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
 type SeizeAndStartCollateralAuctionRequest struct {
 	BaseReq         rest.BaseReq   `json:"base_req"`
 	Sender          sdk.AccAddress `json:"sender"`
@@ -54,9 +110,9 @@ func seizeCdpHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Handler
 
 		// Create msg
 		msg := liquidator.MsgSeizeAndStartCollateralAuction{
-			req.Sender,
-			req.CdpOwner,
-			req.CollateralDenom,
+			Sender:          req.Sender,
+			CdpOwner:        req.CdpOwner,
+			CollateralDenom: req.CollateralDenom,
 		}
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -87,7 +143,7 @@ func debtAuctionHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Hand
 
 		// Create msg
 		msg := liquidator.MsgStartDebtAuction{
-			req.Sender,
+			Sender: req.Sender,
 		}
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -16,4 +16,6 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSeizeAndStartCollateralAuction{}, "liquidator/MsgSeizeAndStartCollateralAuction", nil)
 	cdc.RegisterConcrete(MsgStartDebtAuction{}, "liquidator/MsgStartDebtAuction", nil)
 	// cdc.RegisterConcrete(MsgStartSurplusAuction{}, "liquidator/MsgStartSurplusAuction", nil)
+	cdc.RegisterConcrete(FreezeAuctionsProposal{}, "liquidator/FreezeAuctionsProposal", nil)
+	cdc.RegisterConcrete(CancelAuctionsProposal{}, "liquidator/CancelAuctionsProposal", nil)
 }
@@ -5,11 +5,14 @@ import (
 
 	"github.com/kava-labs/kava-devnet/blockchain/x/auction"
 	"github.com/kava-labs/kava-devnet/blockchain/x/cdp"
+	"github.com/kava-labs/kava-devnet/blockchain/x/pricefeed"
 )
 
 type cdpKeeper interface {
 	GetCDP(sdk.Context, sdk.AccAddress, string) (cdp.CDP, bool)
+	GetParams(sdk.Context) cdp.CdpModuleParams
 	PartialSeizeCDP(sdk.Context, sdk.AccAddress, string, sdk.Int, sdk.Int) sdk.Error
+	RestoreSeizedCDP(sdk.Context, sdk.AccAddress, string, sdk.Int, sdk.Int) sdk.Error
 	ReduceGlobalDebt(sdk.Context, sdk.Int) sdk.Error
 	GetStableDenom() string // TODO can this be removed somehow?
 	GetGovDenom() string
@@ -25,5 +28,16 @@ type bankKeeper interface {
 type auctionKeeper interface {
 	StartForwardAuction(sdk.Context, sdk.AccAddress, sdk.Coin, sdk.Coin) (auction.ID, sdk.Error)
 	StartReverseAuction(sdk.Context, sdk.AccAddress, sdk.Coin, sdk.Coin) (auction.ID, sdk.Error)
-	StartForwardReverseAuction(sdk.Context, sdk.AccAddress, sdk.Coin, sdk.Coin, sdk.AccAddress) (auction.ID, sdk.Error)
+	StartForwardReverseAuction(sdk.Context, sdk.AccAddress, sdk.Coin, sdk.Coin, sdk.Int, sdk.AccAddress) (auction.ID, sdk.Error)
+	StartDutchAuction(sdk.Context, sdk.AccAddress, sdk.Coin, sdk.Coin, sdk.AccAddress, sdk.Dec, sdk.Dec, auction.PriceCurve) (auction.ID, sdk.Error)
+	StartBatchAuction(sdk.Context, sdk.AccAddress, sdk.Coin, string, int64) (auction.ID, sdk.Error)
+	AddToBatchAuction(sdk.Context, auction.ID, sdk.AccAddress, sdk.Coin) sdk.Error
+	GetAuction(sdk.Context, auction.ID) (auction.Auction, bool)
+	IterateAuctions(sdk.Context, func(auction.Auction) bool)
+	FreezeAuction(sdk.Context, auction.ID) sdk.Error
+	CancelAuction(sdk.Context, auction.ID) (auction.Auction, sdk.Error)
+}
+
+type pricefeedKeeper interface {
+	GetCurrentPrice(sdk.Context, string) pricefeed.CurrentPrice
 }
@@ -1,7 +1,12 @@
 package liquidator
 
 import (
+	"fmt"
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/kava-labs/kava-devnet/blockchain/x/auction"
 )
 
 // GenesisState is the state that must be provided at genesis.
@@ -14,21 +19,53 @@ type GenesisState struct {
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
 		LiquidatorModuleParams{
-			DebtAuctionSize: sdk.NewInt(1000),
+			DebtAuctionSize:        sdk.NewInt(1000),
+			GlobalLiquidationLimit: sdk.NewInt(400000),
 			CollateralParams: []CollateralParams{
 				{
-					Denom:       "btc",
-					AuctionSize: sdk.NewInt(1),
+					Denom:              "btc",
+					AuctionSize:        sdk.NewInt(1),
+					LiquidationPenalty: sdk.MustNewDecFromStr("0.05"),
+					TargetRatio:        sdk.MustNewDecFromStr("1.75"),
+					FloorRatio:         sdk.MustNewDecFromStr("1.2"),
+					LiquidationLimit:   sdk.NewInt(250000),
+					AuctionType:        auction.ForwardReverseAuctionType,
+					DutchAuctionParams: defaultDutchAuctionParams(),
+					BatchAuctionParams: defaultBatchAuctionParams(),
 				},
 				{
-					Denom:       "xrp",
-					AuctionSize: sdk.NewInt(1000),
+					Denom:              "xrp",
+					AuctionSize:        sdk.NewInt(1000),
+					LiquidationPenalty: sdk.MustNewDecFromStr("0.05"),
+					TargetRatio:        sdk.MustNewDecFromStr("2.25"),
+					FloorRatio:         sdk.MustNewDecFromStr("1.5"),
+					LiquidationLimit:   sdk.NewInt(250000),
+					AuctionType:        auction.ForwardReverseAuctionType,
+					DutchAuctionParams: defaultDutchAuctionParams(),
+					BatchAuctionParams: defaultBatchAuctionParams(),
 				},
 			},
 		},
 	}
 }
 
+// defaultDutchAuctionParams start auctions 20% above the pricefeed price, dropping by 1% of that each minute, and restart them once the price is 40% below the pricefeed price.
+func defaultDutchAuctionParams() DutchAuctionParams {
+	return DutchAuctionParams{
+		StartMarkup: sdk.MustNewDecFromStr("0.2"),
+		ResetRatio:  sdk.MustNewDecFromStr("0.6"),
+		PriceCurve:  auction.NewPriceCurve(auction.LinearCurve, time.Minute, sdk.MustNewDecFromStr("0.01")),
+	}
+}
+
+// defaultBatchAuctionParams pool seized collateral for 100 blocks before selling it, giving unsold collateral up to 3 more auctions.
+func defaultBatchAuctionParams() BatchAuctionParams {
+	return BatchAuctionParams{
+		Window:     100,
+		MaxRepools: 3,
+	}
+}
+
 // InitGenesis sets the genesis state in the keeper.
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
 	keeper.setParams(ctx, data.LiquidatorModuleParams)
@@ -47,5 +84,45 @@ func ValidateGenesis(data GenesisState) error {
 	// validate denoms
 	// check no repeated denoms
 	// check collateral auction sizes > 0
+	if data.LiquidatorModuleParams.GlobalLiquidationLimit.IsNegative() {
+		return fmt.Errorf("global liquidation limit cannot be negative")
+	}
+	for _, cp := range data.LiquidatorModuleParams.CollateralParams {
+		if cp.LiquidationLimit.IsNegative() {
+			return fmt.Errorf("liquidation limit for %s cannot be negative", cp.Denom)
+		}
+		if cp.LiquidationPenalty.IsNegative() {
+			return fmt.Errorf("liquidation penalty for %s cannot be negative", cp.Denom)
+		}
+		if !cp.FloorRatio.LT(cp.TargetRatio) {
+			return fmt.Errorf("floor ratio for %s must be below the target ratio", cp.Denom)
+		}
+		if !cp.TargetRatio.GT(sdk.OneDec().Add(cp.LiquidationPenalty)) {
+			return fmt.Errorf("target ratio for %s must be above 1 + liquidation penalty", cp.Denom)
+		}
+		switch cp.auctionType() {
+		case auction.ForwardReverseAuctionType:
+		case auction.DutchAuctionType:
+			dp := cp.DutchAuctionParams
+			if dp.StartMarkup.IsNil() || dp.StartMarkup.IsNegative() {
+				return fmt.Errorf("dutch auction start markup for %s cannot be negative", cp.Denom)
+			}
+			if dp.ResetRatio.IsNil() || dp.ResetRatio.IsNegative() || !dp.ResetRatio.LT(sdk.OneDec().Add(dp.StartMarkup)) {
+				return fmt.Errorf("dutch auction reset ratio for %s must be between 0 and 1 + start markup", cp.Denom)
+			}
+			if err := dp.PriceCurve.Validate(); err != nil {
+				return fmt.Errorf("dutch auction price curve for %s is invalid: %s", cp.Denom, err)
+			}
+		case auction.BatchAuctionType:
+			if cp.BatchAuctionParams.Window <= 0 {
+				return fmt.Errorf("batch auction window for %s must be positive", cp.Denom)
+			}
+			if cp.BatchAuctionParams.MaxRepools < 0 {
+				return fmt.Errorf("batch auction max repools for %s cannot be negative", cp.Denom)
+			}
+		default:
+			return fmt.Errorf("unknown auction type for %s: %s", cp.Denom, cp.AuctionType)
+		}
+	}
 	return nil
 }
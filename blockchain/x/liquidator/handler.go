@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 )
 
 // Handle all liquidator messages.
@@ -23,6 +24,21 @@ func NewHandler(keeper Keeper) sdk.Handler {
 	}
 }
 
+// NewProposalHandler handles the liquidator's governance proposals, which freeze and cancel auctions.
+func NewProposalHandler(keeper Keeper) gov.Handler {
+	return func(ctx sdk.Context, content gov.Content) sdk.Error {
+		switch c := content.(type) {
+		case FreezeAuctionsProposal:
+			return keeper.FreezeAuctions(ctx, keeper.selectAuctions(ctx, c.AuctionIDs, c.StartHeight, c.EndHeight))
+		case CancelAuctionsProposal:
+			return keeper.CancelAuctions(ctx, keeper.selectAuctions(ctx, c.AuctionIDs, c.StartHeight, c.EndHeight))
+		default:
+			errMsg := fmt.Sprintf("Unrecognized liquidator proposal content type: %T", c)
+			return sdk.ErrUnknownRequest(errMsg)
+		}
+	}
+}
+
 func handleMsgSeizeAndStartCollateralAuction(ctx sdk.Context, keeper Keeper, msg MsgSeizeAndStartCollateralAuction) sdk.Result {
 	_, err := keeper.SeizeAndStartCollateralAuction(ctx, msg.CdpOwner, msg.CollateralDenom)
 	if err != nil {
@@ -0,0 +1,77 @@
+package liquidator
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/kava-labs/kava-devnet/blockchain/x/auction"
+)
+
+// Hooks wrapper struct for the liquidator keeper, used to receive auction events
+type Hooks struct {
+	k Keeper
+}
+
+var _ auction.AuctionHooks = Hooks{}
+
+// Hooks creates new liquidator hooks
+func (k Keeper) Hooks() Hooks { return Hooks{k} }
+
+// AfterAuctionClosed updates the liquidator's records when one of its auctions closes, then settles as much debt as possible with the stable coin raised.
+func (h Hooks) AfterAuctionClosed(ctx sdk.Context, a auction.Auction) {
+	liquidatorAddress := h.k.cdpKeeper.GetLiquidatorAccountAddress()
+	switch a := a.(type) {
+	case *auction.ForwardReverseAuction: // collateral auctions
+		if !a.Initiator.Equals(liquidatorAddress) {
+			return
+		}
+		raised := a.Bid.Amount
+		if a.Bidder.Equals(a.Initiator) { // closed without bids, so the bid is only a reserve price that was never paid
+			raised = sdk.ZeroInt()
+		}
+		h.k.closeCollateralAuction(ctx, a.GetID(), a.Lot.Denom, a.MaxBid.Amount, raised)
+	case *auction.DutchAuction: // collateral auctions
+		if !a.Initiator.Equals(liquidatorAddress) {
+			return
+		}
+		h.k.closeCollateralAuction(ctx, a.GetID(), a.Lot.Denom, a.MaxBid.Amount, a.Bid.Amount)
+	case *auction.BatchAuction: // collateral auctions
+		if !a.Initiator.Equals(liquidatorAddress) {
+			return
+		}
+		h.k.closeBatchCollateralAuction(ctx, a)
+	case *auction.ReverseAuction: // debt auctions
+		if !a.Initiator.Equals(liquidatorAddress) {
+			return
+		}
+	default:
+		return
+	}
+
+	err := h.k.settleDebt(ctx)
+	if err != nil {
+		// don't halt the chain as debt can still be settled later, when the next auction closes or a debt auction is started
+		ctx.Logger().Error(fmt.Sprintf("could not settle debt after auction %d closed: %s", a.GetID(), err))
+	}
+}
+
+// AfterAuctionSplit shares the debt recorded against one of the liquidator's collateral auctions between it and the auction split off from it, in proportion to their max bids.
+// The records of the CDPs the collateral was seized from are shared the same way, with collateral shared in proportion to the lots.
+func (h Hooks) AfterAuctionSplit(ctx sdk.Context, parent auction.Auction, child auction.Auction) {
+	p, ok := parent.(*auction.ForwardReverseAuction)
+	if !ok || !p.Initiator.Equals(h.k.cdpKeeper.GetLiquidatorAccountAddress()) {
+		return
+	}
+	c := child.(*auction.ForwardReverseAuction)
+	debt, found := h.k.getCollateralAuctionDebt(ctx, p.GetID())
+	if !found {
+		return
+	}
+	childDebt := debt.Mul(c.MaxBid.Amount).Quo(c.MaxBid.Amount.Add(p.MaxBid.Amount))
+	h.k.setCollateralAuctionDebt(ctx, p.GetID(), debt.Sub(childDebt))
+	h.k.setCollateralAuctionDebt(ctx, c.GetID(), childDebt)
+	childSeizures, parentSeizures := h.k.getCollateralSeizures(ctx, p.GetID()).split(c.Lot.Amount, c.Lot.Amount.Add(p.Lot.Amount), childDebt, debt)
+	h.k.setCollateralSeizures(ctx, p.GetID(), parentSeizures)
+	h.k.setCollateralSeizures(ctx, c.GetID(), childSeizures)
+}
@@ -1,31 +1,37 @@
 package liquidator
 
 import (
+	"fmt"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 
 	"github.com/kava-labs/kava-devnet/blockchain/x/auction"
+	"github.com/kava-labs/kava-devnet/blockchain/x/cdp"
+	"github.com/kava-labs/kava-devnet/blockchain/x/pricefeed"
 )
 
 type Keeper struct {
-	cdc            *codec.Codec
-	paramsSubspace params.Subspace
-	storeKey       sdk.StoreKey
-	cdpKeeper      cdpKeeper
-	auctionKeeper  auctionKeeper
-	bankKeeper     bankKeeper
+	cdc             *codec.Codec
+	paramsSubspace  params.Subspace
+	storeKey        sdk.StoreKey
+	cdpKeeper       cdpKeeper
+	auctionKeeper   auctionKeeper
+	bankKeeper      bankKeeper
+	pricefeedKeeper pricefeedKeeper
 }
 
-func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, subspace params.Subspace, cdpKeeper cdpKeeper, auctionKeeper auctionKeeper, bankKeeper bankKeeper) Keeper {
+func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, subspace params.Subspace, cdpKeeper cdpKeeper, auctionKeeper auctionKeeper, bankKeeper bankKeeper, pricefeedKeeper pricefeedKeeper) Keeper {
 	subspace = subspace.WithKeyTable(createParamsKeyTable())
 	return Keeper{
-		cdc:            cdc,
-		paramsSubspace: subspace,
-		storeKey:       storeKey,
-		cdpKeeper:      cdpKeeper,
-		auctionKeeper:  auctionKeeper,
-		bankKeeper:     bankKeeper,
+		cdc:             cdc,
+		paramsSubspace:  subspace,
+		storeKey:        storeKey,
+		cdpKeeper:       cdpKeeper,
+		auctionKeeper:   auctionKeeper,
+		bankKeeper:      bankKeeper,
+		pricefeedKeeper: pricefeedKeeper,
 	}
 }
 
@@ -39,28 +45,322 @@ func (k Keeper) SeizeAndStartCollateralAuction(ctx sdk.Context, owner sdk.AccAdd
 		return 0, sdk.ErrInternal("CDP not found")
 	}
 
-	// Calculate amount of collateral to sell in this auction
+	// Calculate amount of collateral and debt to seize
 	params := k.GetParams(ctx).GetCollateralParams(cdp.CollateralDenom)
-	collateralToSell := sdk.MinInt(cdp.CollateralAmount, params.AuctionSize)
-	// Calculate the corresponding maximum amount of stable coin to raise TODO test maths
-	stableToRaise := sdk.NewDecFromInt(collateralToSell).Quo(sdk.NewDecFromInt(cdp.CollateralAmount)).Mul(sdk.NewDecFromInt(cdp.Debt)).RoundInt()
+	currentPrice := k.pricefeedKeeper.GetCurrentPrice(ctx, cdp.CollateralDenom)
+	if currentPrice.Status != pricefeed.PriceFresh {
+		return 0, sdk.ErrInternal("collateral price is stale or missing, CDPs can't be liquidated until it is updated")
+	}
+	price := currentPrice.Price
+	if params.auctionType() == auction.DutchAuctionType && !price.IsPositive() {
+		return 0, sdk.ErrInternal("dutch auctions can't be started without a positive collateral price")
+	}
+	collateralToSell, debtToSeize := calculateAmountsToSeize(cdp, price, params)
+	// Calculate the corresponding maximum amount of stable coin to raise, including the liquidation penalty
+	stableToRaise := sdk.NewDecFromInt(debtToSeize).Mul(sdk.OneDec().Add(params.LiquidationPenalty)).RoundInt()
+
+	// Check the auction won't put too much debt up for auction at once
+	inFlightDebt := k.GetInFlightDebt(ctx, cdp.CollateralDenom).Add(stableToRaise)
+	if inFlightDebt.GT(params.LiquidationLimit) {
+		return 0, sdk.ErrInternal("liquidation would put the debt being auctioned for this collateral type over its limit")
+	}
+	totalInFlightDebt := k.GetTotalInFlightDebt(ctx).Add(stableToRaise)
+	if totalInFlightDebt.GT(k.GetParams(ctx).GlobalLiquidationLimit) {
+		return 0, sdk.ErrInternal("liquidation would put the total debt being auctioned over the global limit")
+	}
 
-	// Seize the collateral and debt from the CDP
-	err := k.partialSeizeCDP(ctx, owner, collateralDenom, collateralToSell, stableToRaise)
+	// Seize the collateral and debt from the CDP and start the auction in a cached context, so the CDP is left unchanged if the auction can't be started
+	cacheCtx, write := ctx.CacheContext()
+	err := k.partialSeizeCDP(cacheCtx, owner, collateralDenom, collateralToSell, debtToSeize)
 	if err != nil {
 		return 0, err
 	}
 
-	// Start "forward reverse" auction type
+	// Start the auction type set for the collateral, "forward reverse" by default
 	lot := sdk.NewCoin(cdp.CollateralDenom, collateralToSell)
 	maxBid := sdk.NewCoin(k.cdpKeeper.GetStableDenom(), stableToRaise)
-	auctionID, err := k.auctionKeeper.StartForwardReverseAuction(ctx, k.cdpKeeper.GetLiquidatorAccountAddress(), lot, maxBid, owner)
+	var auctionID auction.ID
+	switch params.auctionType() {
+	case auction.DutchAuctionType:
+		dp := params.DutchAuctionParams
+		startPrice := price.Mul(sdk.OneDec().Add(dp.StartMarkup))
+		resetPrice := price.Mul(dp.ResetRatio)
+		auctionID, err = k.auctionKeeper.StartDutchAuction(cacheCtx, k.cdpKeeper.GetLiquidatorAccountAddress(), lot, maxBid, owner, startPrice, resetPrice, dp.PriceCurve)
+	case auction.BatchAuctionType:
+		auctionID, err = k.poolCollateral(cacheCtx, lot, stableToRaise, params.BatchAuctionParams.Window)
+	default:
+		auctionID, err = k.auctionKeeper.StartForwardReverseAuction(cacheCtx, k.cdpKeeper.GetLiquidatorAccountAddress(), lot, maxBid, sdk.ZeroInt(), owner) // no reserve, any bid helps cover the debt
+	}
 	if err != nil {
-		panic(err) // TODO how can errors here be handled to be safe with the state update in PartialSeizeCDP?
+		return 0, err
+	}
+	write()
+	// Record the debt now being covered by the auction. This is released when the auction closes.
+	k.setInFlightDebt(ctx, cdp.CollateralDenom, inFlightDebt)
+	k.setTotalInFlightDebt(ctx, totalInFlightDebt)
+	k.addCollateralAuctionDebt(ctx, auctionID, debtToSeize)
+	k.addCollateralSeizures(ctx, auctionID, CollateralSeizures{{owner, collateralToSell, debtToSeize, ctx.BlockHeight()}})
+	return auctionID, nil
+}
+
+// poolCollateral adds seized collateral to the open batch auction for its type, starting a new one if there isn't one open.
+// maxBid is added to the amount of stable coin the batch auction is raising.
+func (k Keeper) poolCollateral(ctx sdk.Context, lot sdk.Coin, maxBid sdk.Int, window int64) (auction.ID, sdk.Error) {
+	liquidatorAddress := k.cdpKeeper.GetLiquidatorAccountAddress()
+	auctionID, found := k.getOpenBatchAuction(ctx, lot.Denom)
+	// the open batch auction stops accepting collateral once its window has passed, even if it hasn't closed yet
+	if !found || k.auctionKeeper.AddToBatchAuction(ctx, auctionID, liquidatorAddress, lot) != nil {
+		var err sdk.Error
+		auctionID, err = k.auctionKeeper.StartBatchAuction(ctx, liquidatorAddress, lot, k.cdpKeeper.GetStableDenom(), window)
+		if err != nil {
+			return 0, err
+		}
+		k.setOpenBatchAuction(ctx, lot.Denom, auctionID)
 	}
+	k.setBatchAuctionMaxBid(ctx, auctionID, k.getBatchAuctionMaxBid(ctx, auctionID).Add(maxBid))
 	return auctionID, nil
 }
 
+// closeBatchCollateralAuction updates the liquidator's records after one of its batch auctions has cleared.
+// Collateral left unsold is pooled into the next batch auction for its type, along with the debt it didn't cover, rather than being recorded as bad debt.
+// Once collateral has been pooled MaxRepools times without covering its debt, the shortfall is recorded as bad debt instead.
+func (k Keeper) closeBatchCollateralAuction(ctx sdk.Context, a *auction.BatchAuction) {
+	clearing := a.Clear()
+	denom := a.Lot.Denom
+	maxBid := k.getBatchAuctionMaxBid(ctx, a.GetID())
+	k.deleteBatchAuctionMaxBid(ctx, a.GetID())
+	repools := k.getBatchAuctionRepools(ctx, a.GetID())
+	k.deleteBatchAuctionRepools(ctx, a.GetID())
+	if openID, found := k.getOpenBatchAuction(ctx, denom); found && openID == a.GetID() {
+		k.deleteOpenBatchAuction(ctx, denom)
+	}
+	debt, found := k.getCollateralAuctionDebt(ctx, a.GetID())
+	batchParams := k.GetParams(ctx).GetCollateralParams(denom).BatchAuctionParams
+	if !clearing.Unsold.IsPositive() || !found || !clearing.Raised.Amount.LT(debt) || repools >= batchParams.MaxRepools {
+		k.closeCollateralAuction(ctx, a.GetID(), denom, maxBid, clearing.Raised.Amount)
+		return
+	}
+
+	// pool the unsold collateral in a cached context, so it can fall back to recording bad debt if starting a new batch auction fails
+	remainingDebt := debt.Sub(clearing.Raised.Amount)
+	remainingMaxBid := sdk.MaxInt(maxBid.Sub(clearing.Raised.Amount), sdk.ZeroInt())
+	cacheCtx, write := ctx.CacheContext()
+	auctionID, err := k.poolCollateral(cacheCtx, clearing.Unsold, remainingMaxBid, batchParams.Window)
+	if err != nil {
+		ctx.Logger().Error(fmt.Sprintf("could not pool the unsold collateral of auction %d: %s", a.GetID(), err))
+		k.closeCollateralAuction(ctx, a.GetID(), denom, maxBid, clearing.Raised.Amount)
+		return
+	}
+	write()
+	moved, _ := k.getCollateralSeizures(ctx, a.GetID()).split(clearing.Unsold.Amount, a.Lot.Amount, remainingDebt, debt)
+	k.addCollateralSeizures(ctx, auctionID, moved)
+	// the new auction may already hold fresh collateral, count it as repooled as many times as the oldest collateral in it
+	if k.getBatchAuctionRepools(ctx, auctionID) < repools+1 {
+		k.setBatchAuctionRepools(ctx, auctionID, repools+1)
+	}
+	k.setCollateralAuctionDebt(ctx, a.GetID(), clearing.Raised.Amount) // the rest of the debt moves to the new auction
+	k.closeCollateralAuction(ctx, a.GetID(), denom, maxBid, clearing.Raised.Amount)
+	k.addCollateralAuctionDebt(ctx, auctionID, remainingDebt)
+	k.setInFlightDebt(ctx, denom, k.GetInFlightDebt(ctx, denom).Add(remainingMaxBid))
+	k.setTotalInFlightDebt(ctx, k.GetTotalInFlightDebt(ctx).Add(remainingMaxBid))
+}
+
+// closeCollateralAuction updates the liquidator's records after one of its collateral auctions has closed, given the amount it aimed to raise (maxBid) and the amount it did raise.
+// Any seized debt that wasn't covered by the amount raised is recorded as bad debt.
+func (k Keeper) closeCollateralAuction(ctx sdk.Context, auctionID auction.ID, collateralDenom string, maxBid sdk.Int, raised sdk.Int) {
+	k.releaseInFlightDebt(ctx, collateralDenom, maxBid)
+	k.deleteCollateralSeizures(ctx, auctionID)
+
+	debt, found := k.getCollateralAuctionDebt(ctx, auctionID)
+	if !found {
+		return
+	}
+	k.deleteCollateralAuctionDebt(ctx, auctionID)
+	if !raised.LT(debt) {
+		return
+	}
+
+	// Record the bad debt against the auction and the collateral type
+	badDebt := debt.Sub(raised)
+	k.setBadDebtRecord(ctx, BadDebtRecord{
+		AuctionID:       auctionID,
+		CollateralDenom: collateralDenom,
+		SeizedDebt:      debt,
+		AmountRaised:    raised,
+		BadDebt:         badDebt,
+		Height:          ctx.BlockHeight(),
+	})
+	k.setCollateralBadDebt(ctx, collateralDenom, k.GetCollateralBadDebt(ctx, collateralDenom).Add(badDebt))
+	k.setBadDebt(ctx, k.GetBadDebt(ctx).Add(badDebt))
+}
+
+// releaseInFlightDebt removes debt from the in flight totals once the collateral auction covering it has closed.
+func (k Keeper) releaseInFlightDebt(ctx sdk.Context, collateralDenom string, amount sdk.Int) {
+	k.setInFlightDebt(ctx, collateralDenom, sdk.MaxInt(k.GetInFlightDebt(ctx, collateralDenom).Sub(amount), sdk.ZeroInt()))
+	k.setTotalInFlightDebt(ctx, sdk.MaxInt(k.GetTotalInFlightDebt(ctx).Sub(amount), sdk.ZeroInt()))
+}
+
+// FreezeAuctions stops auctions from accepting bids or closing, so they can be cancelled if they were started in error (eg after a bad price caused mass liquidations).
+// Auctions that have already closed are skipped, as they may have closed while the proposal was being voted on.
+func (k Keeper) FreezeAuctions(ctx sdk.Context, auctionIDs []auction.ID) sdk.Error {
+	for _, auctionID := range auctionIDs {
+		if _, found := k.auctionKeeper.GetAuction(ctx, auctionID); !found {
+			ctx.Logger().Info(fmt.Sprintf("not freezing auction %d as it no longer exists", auctionID))
+			continue
+		}
+		err := k.auctionKeeper.FreezeAuction(ctx, auctionID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CancelAuctions cancels auctions, refunding their bids and returning their lots.
+// The collateral and debt seized for the liquidator's collateral auctions is restored to the CDPs it was seized from, and debt auctions no longer count towards the debt sent to auction.
+// Auctions that have already closed are skipped.
+func (k Keeper) CancelAuctions(ctx sdk.Context, auctionIDs []auction.ID) sdk.Error {
+	liquidatorAddress := k.cdpKeeper.GetLiquidatorAccountAddress()
+	for _, auctionID := range auctionIDs {
+		if _, found := k.auctionKeeper.GetAuction(ctx, auctionID); !found {
+			ctx.Logger().Info(fmt.Sprintf("not cancelling auction %d as it no longer exists", auctionID))
+			continue
+		}
+		a, err := k.auctionKeeper.CancelAuction(ctx, auctionID)
+		if err != nil {
+			return err
+		}
+		if !a.GetInitiator().Equals(liquidatorAddress) {
+			continue
+		}
+		switch a := a.(type) {
+		case *auction.ForwardReverseAuction: // collateral auctions
+			// in the reverse phase, collateral bid away has already gone to the CDP owner, so only the lot left is restored
+			err = k.restoreCollateralAuction(ctx, a.GetID(), a.Lot, a.MaxBid.Amount, sdk.ZeroInt())
+		case *auction.DutchAuction: // collateral auctions
+			// purchases have already settled, so the debt covered by the stable coin raised stays seized
+			err = k.restoreCollateralAuction(ctx, a.GetID(), a.Lot, a.MaxBid.Amount, a.Bid.Amount)
+		case *auction.BatchAuction: // collateral auctions
+			maxBid := k.getBatchAuctionMaxBid(ctx, a.GetID())
+			k.deleteBatchAuctionMaxBid(ctx, a.GetID())
+			k.deleteBatchAuctionRepools(ctx, a.GetID())
+			if openID, found := k.getOpenBatchAuction(ctx, a.Lot.Denom); found && openID == a.GetID() {
+				k.deleteOpenBatchAuction(ctx, a.Lot.Denom)
+			}
+			err = k.restoreCollateralAuction(ctx, a.GetID(), a.Lot, maxBid, sdk.ZeroInt())
+		case *auction.ReverseAuction: // debt auctions
+			seizedDebt := k.GetSeizedDebt(ctx)
+			seizedDebt.SentToAuction = sdk.MaxInt(seizedDebt.SentToAuction.Sub(a.Bid.Amount), sdk.ZeroInt())
+			k.setSeizedDebt(ctx, seizedDebt)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	err := k.settleDebt(ctx)
+	if err != nil {
+		// the auctions are still cancelled, debt can be settled later
+		ctx.Logger().Error(fmt.Sprintf("could not settle debt after cancelling auctions: %s", err))
+	}
+	return nil
+}
+
+// restoreCollateralAuction puts the collateral returned from a cancelled collateral auction back into the CDPs it was seized from, along with the debt it was covering.
+// Both are shared between the CDPs in proportion to the amounts seized from each. Debt covered by stable coin raised in the auction stays seized, to be settled with that stable coin.
+func (k Keeper) restoreCollateralAuction(ctx sdk.Context, auctionID auction.ID, lot sdk.Coin, maxBid sdk.Int, raised sdk.Int) sdk.Error {
+	k.releaseInFlightDebt(ctx, lot.Denom, maxBid)
+	seizures := k.getCollateralSeizures(ctx, auctionID)
+	k.deleteCollateralSeizures(ctx, auctionID)
+	debt, found := k.getCollateralAuctionDebt(ctx, auctionID)
+	if !found {
+		debt = sdk.ZeroInt()
+	}
+	k.deleteCollateralAuctionDebt(ctx, auctionID)
+	debtToRestore := sdk.MaxInt(debt.Sub(raised), sdk.ZeroInt())
+	if len(seizures) == 0 {
+		if lot.IsPositive() || debtToRestore.IsPositive() {
+			return sdk.ErrInternal("no CDPs are recorded for the auction")
+		}
+		return nil
+	}
+
+	// the lot was returned to the liquidator when the auction was cancelled
+	_, err := k.bankKeeper.SubtractCoins(ctx, k.cdpKeeper.GetLiquidatorAccountAddress(), sdk.NewCoins(lot))
+	if err != nil {
+		return err
+	}
+	collateral := seizures.distribute(lot.Amount, func(s CollateralSeizure) sdk.Int { return s.Collateral })
+	debts := seizures.distribute(debtToRestore, func(s CollateralSeizure) sdk.Int { return s.Debt })
+	for j, s := range seizures {
+		err = k.cdpKeeper.RestoreSeizedCDP(ctx, s.Owner, lot.Denom, collateral[j], debts[j])
+		if err != nil {
+			return err
+		}
+	}
+
+	// the restored debt is no longer seized
+	seizedDebt := k.GetSeizedDebt(ctx)
+	seizedDebt.Total = sdk.MaxInt(seizedDebt.Total.Sub(debtToRestore), sdk.ZeroInt())
+	seizedDebt.SentToAuction = sdk.MinInt(seizedDebt.SentToAuction, seizedDebt.Total)
+	k.setSeizedDebt(ctx, seizedDebt)
+	return nil
+}
+
+// selectAuctions returns the auctions chosen by a governance proposal, by ID and by the block heights CDPs were liquidated at, without duplicates.
+// If endHeight is zero no auctions are selected by height, otherwise it selects the liquidator's collateral auctions holding collateral seized between startHeight and endHeight, inclusive.
+func (k Keeper) selectAuctions(ctx sdk.Context, auctionIDs []auction.ID, startHeight int64, endHeight int64) []auction.ID {
+	selected := []auction.ID{}
+	seen := make(map[auction.ID]bool)
+	for _, auctionID := range auctionIDs {
+		if !seen[auctionID] {
+			selected = append(selected, auctionID)
+			seen[auctionID] = true
+		}
+	}
+	if endHeight == 0 {
+		return selected
+	}
+	for _, a := range k.GetAuctions(ctx).CollateralAuctions {
+		if !seen[a.GetID()] && k.getCollateralSeizures(ctx, a.GetID()).seizedBetween(startHeight, endHeight) {
+			selected = append(selected, a.GetID())
+			seen[a.GetID()] = true
+		}
+	}
+	return selected
+}
+
+// calculateAmountsToSeize works out how much collateral and debt to take from an under-collateralized CDP.
+// CDPs above the floor ratio are partially liquidated: just enough collateral is seized to cover some debt plus the liquidation penalty, such that the CDP is restored to the target ratio.
+// CDPs below the floor ratio (or that can't be restored to the target ratio) are fully liquidated, in lumps of at most AuctionSize.
+func calculateAmountsToSeize(cdp cdp.CDP, price sdk.Dec, params CollateralParams) (sdk.Int, sdk.Int) {
+	collateralValue := sdk.NewDecFromInt(cdp.CollateralAmount).Mul(price)
+	debt := sdk.NewDecFromInt(cdp.Debt)
+	penaltyMultiplier := sdk.OneDec().Add(params.LiquidationPenalty)
+
+	if price.IsPositive() && !collateralValue.LT(params.FloorRatio.Mul(debt)) && params.TargetRatio.GT(penaltyMultiplier) {
+		// Partial liquidation
+		// Seizing debt d and collateral worth d*(1+penalty) leaves the CDP at the target ratio when:
+		//   (collateralValue - d*(1+penalty)) / (debt - d) = targetRatio
+		//   d = (targetRatio*debt - collateralValue) / (targetRatio - (1+penalty))
+		debtNeeded := params.TargetRatio.Mul(debt).Sub(collateralValue).Quo(params.TargetRatio.Sub(penaltyMultiplier))
+		collateralToSeize := sdk.MinInt(
+			debtNeeded.Mul(penaltyMultiplier).Quo(price).Ceil().TruncateInt(),
+			params.AuctionSize,
+		)
+		// Seize the debt covered by the (rounded up) collateral, net of the penalty
+		debtToSeize := sdk.NewDecFromInt(collateralToSeize).Mul(price).Quo(penaltyMultiplier).TruncateInt()
+		if debtNeeded.IsPositive() && collateralToSeize.LT(cdp.CollateralAmount) && debtToSeize.LT(cdp.Debt) {
+			return collateralToSeize, debtToSeize
+		}
+	}
+
+	// Full liquidation
+	collateralToSeize := sdk.MinInt(cdp.CollateralAmount, params.AuctionSize)
+	// Debt is seized in proportion to the collateral, so the CDP stays at the same collateral ratio
+	debtToSeize := sdk.NewDecFromInt(collateralToSeize).Quo(sdk.NewDecFromInt(cdp.CollateralAmount)).Mul(debt).RoundInt()
+	return collateralToSeize, debtToSeize
+}
+
 // StartDebtAuction sells off minted gov coin to raise set amounts of stable coin.
 // Known as Vow.flop in maker
 // result: minted gov coin moved to highest bidder, stable coin moved to moduleAccount
@@ -144,7 +444,7 @@ func (k Keeper) partialSeizeCDP(ctx sdk.Context, owner sdk.AccAddress, collatera
 }
 
 // SettleDebt removes equal amounts of debt and stable coin from the liquidator's reserves (and also updates the global debt in the cdp module).
-// This is called in the handler when a debt or surplus auction is started
+// This is called in the handler when a debt or surplus auction is started, and when any of the liquidator's auctions close
 // TODO Should this be called with an amount, rather than annihilating the maximum?
 func (k Keeper) settleDebt(ctx sdk.Context) sdk.Error {
 	// Calculate max amount of debt and stable coins that can be settled (ie annihilated)
@@ -170,6 +470,32 @@ func (k Keeper) settleDebt(ctx sdk.Context) sdk.Error {
 	return nil
 }
 
+// GetSurplus returns the stable coin held by the liquidator in excess of the seized debt. Known as Joy - Awe in maker.
+func (k Keeper) GetSurplus(ctx sdk.Context) sdk.Int {
+	stableCoins := k.bankKeeper.GetCoins(ctx, k.cdpKeeper.GetLiquidatorAccountAddress()).AmountOf(k.cdpKeeper.GetStableDenom())
+	return sdk.MaxInt(stableCoins.Sub(k.GetSeizedDebt(ctx).Total), sdk.ZeroInt())
+}
+
+// GetAuctions returns the open auctions that were started by the liquidator.
+func (k Keeper) GetAuctions(ctx sdk.Context) LiquidatorAuctions {
+	liquidatorAddress := k.cdpKeeper.GetLiquidatorAccountAddress()
+	auctions := LiquidatorAuctions{CollateralAuctions: []auction.Auction{}, DebtAuctions: []auction.Auction{}}
+	k.auctionKeeper.IterateAuctions(ctx, func(a auction.Auction) bool {
+		switch a := a.(type) {
+		case *auction.ForwardReverseAuction, *auction.DutchAuction, *auction.BatchAuction:
+			if a.GetInitiator().Equals(liquidatorAddress) {
+				auctions.CollateralAuctions = append(auctions.CollateralAuctions, a)
+			}
+		case *auction.ReverseAuction:
+			if a.Initiator.Equals(liquidatorAddress) {
+				auctions.DebtAuctions = append(auctions.DebtAuctions, a)
+			}
+		}
+		return false
+	})
+	return auctions
+}
+
 // ---------- Module Parameters ----------
 
 func (k Keeper) GetParams(ctx sdk.Context) LiquidatorModuleParams {
@@ -204,3 +530,251 @@ func (k Keeper) setSeizedDebt(ctx sdk.Context, debt SeizedDebt) {
 	bz := k.cdc.MustMarshalBinaryLengthPrefixed(debt)
 	store.Set(k.getSeizedDebtKey(), bz)
 }
+
+func (k Keeper) getInFlightDebtKey(collateralDenom string) []byte {
+	return []byte("inFlightDebt:" + collateralDenom)
+}
+
+// GetInFlightDebt returns the amount of stable coin being raised by open collateral auctions for a collateral type. Known as dirt in maker.
+func (k Keeper) GetInFlightDebt(ctx sdk.Context, collateralDenom string) sdk.Int {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getInFlightDebtKey(collateralDenom))
+	if bz == nil {
+		return sdk.ZeroInt()
+	}
+	var debt sdk.Int
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &debt)
+	return debt
+}
+func (k Keeper) setInFlightDebt(ctx sdk.Context, collateralDenom string, debt sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(debt)
+	store.Set(k.getInFlightDebtKey(collateralDenom), bz)
+}
+
+func (k Keeper) getTotalInFlightDebtKey() []byte {
+	return []byte("totalInFlightDebt")
+}
+
+// GetTotalInFlightDebt returns the amount of stable coin being raised by all open collateral auctions. Known as Dirt in maker.
+func (k Keeper) GetTotalInFlightDebt(ctx sdk.Context) sdk.Int {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getTotalInFlightDebtKey())
+	if bz == nil {
+		return sdk.ZeroInt()
+	}
+	var debt sdk.Int
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &debt)
+	return debt
+}
+func (k Keeper) setTotalInFlightDebt(ctx sdk.Context, debt sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(debt)
+	store.Set(k.getTotalInFlightDebtKey(), bz)
+}
+
+func (k Keeper) getCollateralAuctionDebtKey(auctionID auction.ID) []byte {
+	return []byte(fmt.Sprintf("collateralAuctionDebt:%d", auctionID))
+}
+
+// getCollateralAuctionDebt returns the debt seized from a CDP to start a collateral auction
+func (k Keeper) getCollateralAuctionDebt(ctx sdk.Context, auctionID auction.ID) (sdk.Int, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getCollateralAuctionDebtKey(auctionID))
+	if bz == nil {
+		return sdk.Int{}, false
+	}
+	var debt sdk.Int
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &debt)
+	return debt, true
+}
+func (k Keeper) setCollateralAuctionDebt(ctx sdk.Context, auctionID auction.ID, debt sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(debt)
+	store.Set(k.getCollateralAuctionDebtKey(auctionID), bz)
+}
+func (k Keeper) deleteCollateralAuctionDebt(ctx sdk.Context, auctionID auction.ID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(k.getCollateralAuctionDebtKey(auctionID))
+}
+
+// addCollateralAuctionDebt adds to the debt recorded against a collateral auction, which batch auctions pool from many CDPs
+func (k Keeper) addCollateralAuctionDebt(ctx sdk.Context, auctionID auction.ID, debt sdk.Int) {
+	if existing, found := k.getCollateralAuctionDebt(ctx, auctionID); found {
+		debt = debt.Add(existing)
+	}
+	k.setCollateralAuctionDebt(ctx, auctionID, debt)
+}
+
+func (k Keeper) getCollateralSeizuresKey(auctionID auction.ID) []byte {
+	return []byte(fmt.Sprintf("collateralSeizures:%d", auctionID))
+}
+
+// getCollateralSeizures returns the records of the CDPs a collateral auction is selling collateral from
+func (k Keeper) getCollateralSeizures(ctx sdk.Context, auctionID auction.ID) CollateralSeizures {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getCollateralSeizuresKey(auctionID))
+	if bz == nil {
+		return CollateralSeizures{}
+	}
+	var seizures CollateralSeizures
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &seizures)
+	return seizures
+}
+func (k Keeper) setCollateralSeizures(ctx sdk.Context, auctionID auction.ID, seizures CollateralSeizures) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(seizures)
+	store.Set(k.getCollateralSeizuresKey(auctionID), bz)
+}
+func (k Keeper) deleteCollateralSeizures(ctx sdk.Context, auctionID auction.ID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(k.getCollateralSeizuresKey(auctionID))
+}
+
+// addCollateralSeizures adds to the seizures recorded against a collateral auction
+func (k Keeper) addCollateralSeizures(ctx sdk.Context, auctionID auction.ID, seizures CollateralSeizures) {
+	k.setCollateralSeizures(ctx, auctionID, append(k.getCollateralSeizures(ctx, auctionID), seizures...))
+}
+
+func (k Keeper) getOpenBatchAuctionKey(collateralDenom string) []byte {
+	return []byte("openBatchAuction:" + collateralDenom)
+}
+
+// getOpenBatchAuction returns the ID of the batch auction that seized collateral of a type is currently pooled into
+func (k Keeper) getOpenBatchAuction(ctx sdk.Context, collateralDenom string) (auction.ID, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getOpenBatchAuctionKey(collateralDenom))
+	if bz == nil {
+		return 0, false
+	}
+	var auctionID auction.ID
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &auctionID)
+	return auctionID, true
+}
+func (k Keeper) setOpenBatchAuction(ctx sdk.Context, collateralDenom string, auctionID auction.ID) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(auctionID)
+	store.Set(k.getOpenBatchAuctionKey(collateralDenom), bz)
+}
+func (k Keeper) deleteOpenBatchAuction(ctx sdk.Context, collateralDenom string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(k.getOpenBatchAuctionKey(collateralDenom))
+}
+
+func (k Keeper) getBatchAuctionMaxBidKey(auctionID auction.ID) []byte {
+	return []byte(fmt.Sprintf("batchAuctionMaxBid:%d", auctionID))
+}
+
+// getBatchAuctionMaxBid returns the stable coin a batch auction aims to raise, the total of the max bids of the collateral pooled into it
+func (k Keeper) getBatchAuctionMaxBid(ctx sdk.Context, auctionID auction.ID) sdk.Int {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getBatchAuctionMaxBidKey(auctionID))
+	if bz == nil {
+		return sdk.ZeroInt()
+	}
+	var maxBid sdk.Int
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &maxBid)
+	return maxBid
+}
+func (k Keeper) setBatchAuctionMaxBid(ctx sdk.Context, auctionID auction.ID, maxBid sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(maxBid)
+	store.Set(k.getBatchAuctionMaxBidKey(auctionID), bz)
+}
+func (k Keeper) deleteBatchAuctionMaxBid(ctx sdk.Context, auctionID auction.ID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(k.getBatchAuctionMaxBidKey(auctionID))
+}
+
+func (k Keeper) getBatchAuctionRepoolsKey(auctionID auction.ID) []byte {
+	return []byte(fmt.Sprintf("batchAuctionRepools:%d", auctionID))
+}
+
+// getBatchAuctionRepools returns the number of times the collateral in a batch auction has been pooled into a new one after going unsold
+func (k Keeper) getBatchAuctionRepools(ctx sdk.Context, auctionID auction.ID) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getBatchAuctionRepoolsKey(auctionID))
+	if bz == nil {
+		return 0
+	}
+	var repools int64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &repools)
+	return repools
+}
+func (k Keeper) setBatchAuctionRepools(ctx sdk.Context, auctionID auction.ID, repools int64) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(repools)
+	store.Set(k.getBatchAuctionRepoolsKey(auctionID), bz)
+}
+func (k Keeper) deleteBatchAuctionRepools(ctx sdk.Context, auctionID auction.ID) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(k.getBatchAuctionRepoolsKey(auctionID))
+}
+
+func (k Keeper) getBadDebtKey() []byte {
+	return []byte("badDebt")
+}
+
+// GetBadDebt returns the total seized debt that collateral auctions have failed to raise stable coin for.
+func (k Keeper) GetBadDebt(ctx sdk.Context) sdk.Int {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getBadDebtKey())
+	if bz == nil {
+		return sdk.ZeroInt()
+	}
+	var debt sdk.Int
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &debt)
+	return debt
+}
+func (k Keeper) setBadDebt(ctx sdk.Context, debt sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(debt)
+	store.Set(k.getBadDebtKey(), bz)
+}
+
+func (k Keeper) getCollateralBadDebtKey(collateralDenom string) []byte {
+	return []byte("badDebt:" + collateralDenom)
+}
+
+// GetCollateralBadDebt returns the total bad debt from collateral auctions of one collateral type.
+func (k Keeper) GetCollateralBadDebt(ctx sdk.Context, collateralDenom string) sdk.Int {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(k.getCollateralBadDebtKey(collateralDenom))
+	if bz == nil {
+		return sdk.ZeroInt()
+	}
+	var debt sdk.Int
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &debt)
+	return debt
+}
+func (k Keeper) setCollateralBadDebt(ctx sdk.Context, collateralDenom string, debt sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(debt)
+	store.Set(k.getCollateralBadDebtKey(collateralDenom), bz)
+}
+
+var badDebtRecordKeyPrefix = []byte("badDebtRecord:")
+
+func (k Keeper) getBadDebtRecordKey(auctionID auction.ID) []byte {
+	return append(badDebtRecordKeyPrefix, sdk.Uint64ToBigEndian(uint64(auctionID))...)
+}
+
+// GetBadDebtRecords returns the records of all collateral auctions that closed underwater, ordered by auction ID.
+func (k Keeper) GetBadDebtRecords(ctx sdk.Context) BadDebtRecords {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, badDebtRecordKeyPrefix)
+	defer iter.Close()
+
+	records := BadDebtRecords{}
+	for ; iter.Valid(); iter.Next() {
+		var record BadDebtRecord
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &record)
+		records = append(records, record)
+	}
+	return records
+}
+func (k Keeper) setBadDebtRecord(ctx sdk.Context, record BadDebtRecord) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(record)
+	store.Set(k.getBadDebtRecordKey(record.AuctionID), bz)
+}
@@ -2,51 +2,651 @@ package liquidator
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/kava-labs/kava-devnet/blockchain/x/auction"
 	"github.com/kava-labs/kava-devnet/blockchain/x/cdp"
 	"github.com/kava-labs/kava-devnet/blockchain/x/pricefeed"
 )
 
 func TestKeeper_SeizeAndStartCollateralAuction(t *testing.T) {
-	// Setup
-	ctx, k := setupTestKeepers()
+	_, addrs := mock.GeneratePrivKeyAddressPairs(1)
+	owner := addrs[0]
+
+	// Liquidation params for btc are: penalty 0.05, target ratio 1.75, floor ratio 1.2. The cdp liquidation ratio is 1.5.
+	type args struct {
+		auctionSize sdk.Int
+		collateral  sdk.Int
+		debt        sdk.Int
+		price       string // price the collateral drops to from 8000
+		minPosts    int    // oracle prices needed to update the price, the price is stale when this is more than one
+	}
+	tests := []struct {
+		name               string
+		args               args
+		expectPass         bool
+		expectedCollateral sdk.Int // collateral left in the CDP, zero if it has been closed
+		expectedDebt       sdk.Int
+		expectedLot        sdk.Coin
+		expectedMaxBid     sdk.Coin
+	}{
+		{
+			"partialLiquidation",
+			args{i(10), i(10), i(50000), "7000.00", 0},
+			true,
+			i(6), i(23334), // collateral ratio restored to 1.8
+			c("btc", 4), c("usdx", 27999),
+		},
+		{
+			"partialLiquidationCappedAtAuctionSize",
+			args{i(1), i(10), i(50000), "7000.00", 0},
+			true,
+			i(9), i(43334),
+			c("btc", 1), c("usdx", 6999),
+		},
+		{
+			"partialLiquidationNeedingAllCollateral",
+			args{i(10), i(2), i(10000), "6500.00", 0},
+			true,
+			i(0), i(0),
+			c("btc", 2), c("usdx", 10500),
+		},
+		{
+			"fullLiquidationBelowFloor",
+			args{i(10), i(3), i(16000), "5000.00", 0},
+			true,
+			i(0), i(0),
+			c("btc", 3), c("usdx", 16800),
+		},
+		{
+			"fullLiquidationCappedAtAuctionSize",
+			args{i(1), i(3), i(16000), "5000.00", 0},
+			true,
+			i(2), i(10667), // original debt scaled by amount of collateral removed
+			c("btc", 1), c("usdx", 5600),
+		},
+		{
+			"stalePrice",
+			args{i(10), i(10), i(50000), "7000.00", 2},
+			false,
+			i(10), i(50000),
+			sdk.Coin{}, sdk.Coin{},
+		},
+		{
+			"notUnderCollateralized",
+			args{i(10), i(3), i(16000), "8000.00", 0},
+			false,
+			i(3), i(16000),
+			sdk.Coin{}, sdk.Coin{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			ctx, k := setupTestKeepers()
+			auction.InitGenesis(ctx, k.auctionKeeper, auction.DefaultGenesisState())
+
+			cdp.InitGenesis(ctx, k.cdpKeeper, cdp.DefaultGenesisState())
+			genesis := DefaultGenesisState()
+			genesis.LiquidatorModuleParams.CollateralParams[0].AuctionSize = tc.args.auctionSize // btc
+			InitGenesis(ctx, k.liquidatorKeeper, genesis)
+			pricefeed.InitGenesis(ctx, k.pricefeedKeeper, pricefeed.GenesisState{Assets: []pricefeed.Asset{{AssetCode: "btc", Description: "a description"}}, Oracles: []pricefeed.Oracle{{OracleAddress: owner.String()}}})
+			k.pricefeedKeeper.SetPrice(ctx, owner, "btc", sdk.MustNewDecFromStr("8000.00"), i(999999999))
+			k.pricefeedKeeper.SetCurrentPrices(ctx)
+			k.bankKeeper.AddCoins(ctx, owner, cs(c("btc", 100)))
+
+			require.NoError(t, k.cdpKeeper.ModifyCDP(ctx, owner, "btc", tc.args.collateral, tc.args.debt))
 
+			require.NoError(t, k.pricefeedKeeper.SetAssetQuorum(ctx, "btc", tc.args.minPosts, sdk.ZeroDec()))
+			k.pricefeedKeeper.SetPrice(ctx, owner, "btc", sdk.MustNewDecFromStr(tc.args.price), i(999999999))
+			k.pricefeedKeeper.SetCurrentPrices(ctx)
+
+			// Run test function
+			auctionID, err := k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx, owner, "btc")
+
+			// Check CDP
+			cdp, found := k.cdpKeeper.GetCDP(ctx, owner, "btc")
+			if tc.expectedCollateral.IsZero() && tc.expectedDebt.IsZero() {
+				require.False(t, found)
+			} else {
+				require.True(t, found)
+				require.Equal(t, tc.expectedCollateral, cdp.CollateralAmount)
+				require.Equal(t, tc.expectedDebt, cdp.Debt)
+			}
+			if !tc.expectPass {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			// Check auction values
+			a, found := k.auctionKeeper.GetAuction(ctx, auctionID)
+			require.True(t, found)
+			frAuction, ok := a.(*auction.ForwardReverseAuction)
+			require.True(t, ok)
+			require.Equal(t, tc.expectedLot, frAuction.Lot)
+			require.Equal(t, tc.expectedMaxBid, frAuction.MaxBid)
+			require.Equal(t, owner, frAuction.OtherPerson)
+		})
+	}
+}
+
+func TestKeeper_SeizeAndStartCollateralAuction_AuctionFails(t *testing.T) {
 	_, addrs := mock.GeneratePrivKeyAddressPairs(1)
+	owner := addrs[0]
 
+	tests := []struct {
+		name   string
+		params func(*CollateralParams) // invalid params that genesis validation would catch, so starting the auction fails
+	}{
+		{"batchWindow", func(cp *CollateralParams) {
+			cp.AuctionType = auction.BatchAuctionType
+			cp.BatchAuctionParams = BatchAuctionParams{Window: 0}
+		}},
+		{"dutchResetAboveStart", func(cp *CollateralParams) {
+			cp.AuctionType = auction.DutchAuctionType
+			cp.DutchAuctionParams.ResetRatio = sdk.MustNewDecFromStr("2")
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			ctx, k := setupTestKeepers()
+			auction.InitGenesis(ctx, k.auctionKeeper, auction.DefaultGenesisState())
+			cdp.InitGenesis(ctx, k.cdpKeeper, cdp.DefaultGenesisState())
+			genesis := DefaultGenesisState()
+			genesis.LiquidatorModuleParams.CollateralParams[0].AuctionSize = i(10)
+			tc.params(&genesis.LiquidatorModuleParams.CollateralParams[0])
+			InitGenesis(ctx, k.liquidatorKeeper, genesis)
+			pricefeed.InitGenesis(ctx, k.pricefeedKeeper, pricefeed.GenesisState{Assets: []pricefeed.Asset{{AssetCode: "btc", Description: "a description"}}, Oracles: []pricefeed.Oracle{{OracleAddress: owner.String()}}})
+			k.pricefeedKeeper.SetPrice(ctx, owner, "btc", sdk.MustNewDecFromStr("8000.00"), i(999999999))
+			k.pricefeedKeeper.SetCurrentPrices(ctx)
+			k.bankKeeper.AddCoins(ctx, owner, cs(c("btc", 100)))
+			require.NoError(t, k.cdpKeeper.ModifyCDP(ctx, owner, "btc", i(3), i(16000)))
+			k.pricefeedKeeper.SetPrice(ctx, owner, "btc", sdk.MustNewDecFromStr("5000.00"), i(999999999))
+			k.pricefeedKeeper.SetCurrentPrices(ctx)
+			before, found := k.cdpKeeper.GetCDP(ctx, owner, "btc")
+			require.True(t, found)
+
+			// Run test function
+			var err sdk.Error
+			require.NotPanics(t, func() {
+				_, err = k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx, owner, "btc")
+			})
+
+			// Check the error is returned and nothing was seized
+			require.Error(t, err)
+			after, found := k.cdpKeeper.GetCDP(ctx, owner, "btc")
+			require.True(t, found)
+			require.Equal(t, before, after)
+			require.Equal(t, i(0), k.liquidatorKeeper.GetSeizedDebt(ctx).Total)
+			require.Equal(t, i(0), k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+			require.Empty(t, k.cdpKeeper.GetCoins(ctx, k.cdpKeeper.GetLiquidatorAccountAddress()))
+			require.Empty(t, k.liquidatorKeeper.GetAuctions(ctx).CollateralAuctions)
+		})
+	}
+}
+
+func TestKeeper_DutchCollateralAuction(t *testing.T) {
+	_, addrs := mock.GeneratePrivKeyAddressPairs(2)
+	owner, buyer := addrs[0], addrs[1]
+
+	// Setup, with btc sold in dutch auctions starting 20% above the pricefeed price
+	ctx, k := setupTestKeepers()
+	auction.InitGenesis(ctx, k.auctionKeeper, auction.DefaultGenesisState())
 	cdp.InitGenesis(ctx, k.cdpKeeper, cdp.DefaultGenesisState())
-	InitGenesis(ctx, k.liquidatorKeeper, DefaultGenesisState())
-	pricefeed.InitGenesis(ctx, k.pricefeedKeeper, pricefeed.GenesisState{Assets: []pricefeed.Asset{{"btc", "a description"}}})
-	k.pricefeedKeeper.SetPrice(ctx, addrs[0], "btc", sdk.MustNewDecFromStr("8000.00"), i(999999999))
+	genesis := DefaultGenesisState()
+	genesis.LiquidatorModuleParams.CollateralParams[0].AuctionSize = i(10)
+	genesis.LiquidatorModuleParams.CollateralParams[0].AuctionType = auction.DutchAuctionType
+	require.NoError(t, ValidateGenesis(genesis))
+	InitGenesis(ctx, k.liquidatorKeeper, genesis)
+	pricefeed.InitGenesis(ctx, k.pricefeedKeeper, pricefeed.GenesisState{Assets: []pricefeed.Asset{{AssetCode: "btc", Description: "a description"}}, Oracles: []pricefeed.Oracle{{OracleAddress: owner.String()}}})
+	k.pricefeedKeeper.SetPrice(ctx, owner, "btc", sdk.MustNewDecFromStr("8000.00"), i(999999999))
+	k.pricefeedKeeper.SetCurrentPrices(ctx)
+	k.bankKeeper.AddCoins(ctx, owner, cs(c("btc", 100)))
+	k.bankKeeper.AddCoins(ctx, buyer, cs(c("usdx", 20000)))
+	require.NoError(t, k.cdpKeeper.ModifyCDP(ctx, owner, "btc", i(3), i(16000)))
+	k.pricefeedKeeper.SetPrice(ctx, owner, "btc", sdk.MustNewDecFromStr("5000.00"), i(999999999))
 	k.pricefeedKeeper.SetCurrentPrices(ctx)
-	k.bankKeeper.AddCoins(ctx, addrs[0], cs(c("btc", 100)))
 
-	k.cdpKeeper.ModifyCDP(ctx, addrs[0], "btc", i(3), i(16000))
+	// Run test function, fully liquidating the CDP
+	auctionID, err := k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx, owner, "btc")
+	require.NoError(t, err)
 
-	k.pricefeedKeeper.SetPrice(ctx, addrs[0], "btc", sdk.MustNewDecFromStr("7999.99"), i(999999999))
+	// Check auction values
+	a, found := k.auctionKeeper.GetAuction(ctx, auctionID)
+	require.True(t, found)
+	dutchAuction, ok := a.(*auction.DutchAuction)
+	require.True(t, ok)
+	require.Equal(t, c("btc", 3), dutchAuction.Lot)
+	require.Equal(t, c("usdx", 16800), dutchAuction.MaxBid)
+	require.Equal(t, sdk.MustNewDecFromStr("6000"), dutchAuction.StartPrice)
+	require.Equal(t, sdk.MustNewDecFromStr("3000"), dutchAuction.ResetPrice)
+	require.Len(t, k.liquidatorKeeper.GetAuctions(ctx).CollateralAuctions, 1)
+
+	// Buy the lot, paying only the amount left to raise, then close the auction
+	require.NoError(t, k.auctionKeeper.PlaceBid(ctx, auctionID, buyer, c("usdx", 18000), c("btc", 3)))
+	require.Equal(t, cs(c("btc", 3), c("usdx", 3200)), k.bankKeeper.GetCoins(ctx, buyer))
+	auction.BeginBlocker(ctx.WithBlockTime(ctx.BlockHeader().Time.Add(time.Second)), k.auctionKeeper) // the sold out auction closes in the next block
+
+	// Check the debt was settled with no bad debt
+	_, found = k.auctionKeeper.GetAuction(ctx, auctionID)
+	require.False(t, found)
+	require.Equal(t, i(0), k.liquidatorKeeper.GetSeizedDebt(ctx).Total)
+	require.Equal(t, i(800), k.liquidatorKeeper.GetSurplus(ctx))
+	require.Equal(t, i(0), k.liquidatorKeeper.GetBadDebt(ctx))
+	require.Equal(t, i(0), k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+}
+
+func TestKeeper_BatchCollateralAuction(t *testing.T) {
+	_, addrs := mock.GeneratePrivKeyAddressPairs(4)
+	owner1, owner2, buyer1, buyer2 := addrs[0], addrs[1], addrs[2], addrs[3]
+
+	tests := []struct {
+		name                 string
+		orders               []sdk.Int // amount of btc each buyer orders
+		expectedSurplus      sdk.Int
+		expectedSeizedDebt   sdk.Int
+		expectedInFlightDebt sdk.Int
+		expectedPooledLot    sdk.Coin // unsold collateral pooled into the next batch auction, zero if there isn't one
+	}{
+		{"soldOut", []sdk.Int{i(4), i(4)}, i(1000), i(0), i(0), c("btc", 0)},
+		{"unsoldCollateralPooled", []sdk.Int{i(2), i(0)}, i(0), i(20000), i(21600), c("btc", 4)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup, with btc pooled over 2 blocks into batch auctions
+			ctx, k := setupTestKeepers()
+			auction.InitGenesis(ctx, k.auctionKeeper, auction.DefaultGenesisState())
+			cdp.InitGenesis(ctx, k.cdpKeeper, cdp.DefaultGenesisState())
+			genesis := DefaultGenesisState()
+			genesis.LiquidatorModuleParams.CollateralParams[0].AuctionSize = i(10)
+			genesis.LiquidatorModuleParams.CollateralParams[0].AuctionType = auction.BatchAuctionType
+			genesis.LiquidatorModuleParams.CollateralParams[0].BatchAuctionParams = BatchAuctionParams{Window: 2, MaxRepools: 1}
+			require.NoError(t, ValidateGenesis(genesis))
+			InitGenesis(ctx, k.liquidatorKeeper, genesis)
+			pricefeed.InitGenesis(ctx, k.pricefeedKeeper, pricefeed.GenesisState{Assets: []pricefeed.Asset{{AssetCode: "btc", Description: "a description"}}, Oracles: []pricefeed.Oracle{{OracleAddress: owner1.String()}}})
+			k.pricefeedKeeper.SetPrice(ctx, owner1, "btc", sdk.MustNewDecFromStr("8000.00"), i(999999999))
+			k.pricefeedKeeper.SetCurrentPrices(ctx)
+			for _, owner := range []sdk.AccAddress{owner1, owner2} {
+				k.bankKeeper.AddCoins(ctx, owner, cs(c("btc", 100)))
+				require.NoError(t, k.cdpKeeper.ModifyCDP(ctx, owner, "btc", i(3), i(16000)))
+			}
+			k.bankKeeper.AddCoins(ctx, buyer1, cs(c("usdx", 30000)))
+			k.bankKeeper.AddCoins(ctx, buyer2, cs(c("usdx", 30000)))
+			k.pricefeedKeeper.SetPrice(ctx, owner1, "btc", sdk.MustNewDecFromStr("5000.00"), i(999999999))
+			k.pricefeedKeeper.SetCurrentPrices(ctx)
+
+			// Fully liquidate both CDPs, pooling their collateral into one auction in different blocks
+			auctionID, err := k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx, owner1, "btc")
+			require.NoError(t, err)
+			ctx = ctx.WithBlockHeight(1)
+			pooledID, err := k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx, owner2, "btc")
+			require.NoError(t, err)
+			require.Equal(t, auctionID, pooledID)
+			a, found := k.auctionKeeper.GetAuction(ctx, auctionID)
+			require.True(t, found)
+			require.Equal(t, cs(c("btc", 6)), a.GetLot())
+			require.Equal(t, i(33600), k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+
+			// Place orders at different prices, the second buyer's is the marginal order and sets the clearing price
+			prices := []sdk.Dec{sdk.MustNewDecFromStr("6000"), sdk.MustNewDecFromStr("5500")}
+			for j, buyer := range []sdk.AccAddress{buyer1, buyer2} {
+				if tc.orders[j].IsPositive() {
+					require.NoError(t, k.auctionKeeper.PlaceBatchOrder(ctx, auctionID, buyer, tc.orders[j], prices[j]))
+				}
+			}
+			auction.BeginBlocker(ctx.WithBlockHeight(2), k.auctionKeeper)
+
+			// Check the debt was settled with no bad debt
+			_, found = k.auctionKeeper.GetAuction(ctx, auctionID)
+			require.False(t, found)
+			require.Equal(t, tc.expectedSeizedDebt, k.liquidatorKeeper.GetSeizedDebt(ctx).Total)
+			require.Equal(t, tc.expectedSurplus, k.liquidatorKeeper.GetSurplus(ctx))
+			require.Equal(t, i(0), k.liquidatorKeeper.GetBadDebt(ctx))
+			require.Equal(t, tc.expectedInFlightDebt, k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+			collateralAuctions := k.liquidatorKeeper.GetAuctions(ctx).CollateralAuctions
+			if tc.expectedPooledLot.Amount.IsZero() {
+				require.Empty(t, collateralAuctions)
+				return
+			}
+			require.Len(t, collateralAuctions, 1)
+			require.Equal(t, cs(tc.expectedPooledLot), collateralAuctions[0].GetLot())
+		})
+	}
+}
+
+func TestKeeper_BatchCollateralAuctionNeverFilled(t *testing.T) {
+	// Setup, with a CDP that is fully liquidated into a batch auction when the price drops to 5000
+	_, addrs := mock.GeneratePrivKeyAddressPairs(1)
+	owner := addrs[0]
+	ctx, k := setupTestKeepers()
+	auction.InitGenesis(ctx, k.auctionKeeper, auction.DefaultGenesisState())
+	cdp.InitGenesis(ctx, k.cdpKeeper, cdp.DefaultGenesisState())
+	genesis := DefaultGenesisState()
+	genesis.LiquidatorModuleParams.CollateralParams[0].AuctionSize = i(10)
+	genesis.LiquidatorModuleParams.CollateralParams[0].AuctionType = auction.BatchAuctionType
+	genesis.LiquidatorModuleParams.CollateralParams[0].BatchAuctionParams = BatchAuctionParams{Window: 2, MaxRepools: 2}
+	require.NoError(t, ValidateGenesis(genesis))
+	InitGenesis(ctx, k.liquidatorKeeper, genesis)
+	pricefeed.InitGenesis(ctx, k.pricefeedKeeper, pricefeed.GenesisState{Assets: []pricefeed.Asset{{AssetCode: "btc", Description: "a description"}}, Oracles: []pricefeed.Oracle{{OracleAddress: owner.String()}}})
+	k.pricefeedKeeper.SetPrice(ctx, owner, "btc", sdk.MustNewDecFromStr("8000.00"), i(999999999))
+	k.pricefeedKeeper.SetCurrentPrices(ctx)
+	k.bankKeeper.AddCoins(ctx, owner, cs(c("btc", 100)))
+	require.NoError(t, k.cdpKeeper.ModifyCDP(ctx, owner, "btc", i(3), i(16000)))
+	k.pricefeedKeeper.SetPrice(ctx, owner, "btc", sdk.MustNewDecFromStr("5000.00"), i(999999999))
 	k.pricefeedKeeper.SetCurrentPrices(ctx)
+	_, err := k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx, owner, "btc")
+	require.NoError(t, err)
 
-	// Run test function
-	auctionID, err := k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx, addrs[0], "btc")
+	// Run test function, closing each batch auction without any orders
+	// The collateral is pooled into a new auction MaxRepools times, with the debt still in flight
+	for round := int64(1); round <= 2; round++ {
+		auction.BeginBlocker(ctx.WithBlockHeight(2*round), k.auctionKeeper)
+		collateralAuctions := k.liquidatorKeeper.GetAuctions(ctx).CollateralAuctions
+		require.Len(t, collateralAuctions, 1)
+		require.Equal(t, cs(c("btc", 3)), collateralAuctions[0].GetLot())
+		require.Equal(t, round, k.liquidatorKeeper.getBatchAuctionRepools(ctx, collateralAuctions[0].GetID()))
+		require.Equal(t, i(16800), k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+		require.Equal(t, i(0), k.liquidatorKeeper.GetBadDebt(ctx))
+	}
+	auction.BeginBlocker(ctx.WithBlockHeight(6), k.auctionKeeper)
+
+	// Check the last auction recorded the debt as bad debt rather than pooling the collateral again
+	require.Empty(t, k.liquidatorKeeper.GetAuctions(ctx).CollateralAuctions)
+	require.Equal(t, i(0), k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+	require.Equal(t, i(0), k.liquidatorKeeper.GetInFlightDebt(ctx, "btc"))
+	require.Equal(t, i(16000), k.liquidatorKeeper.GetBadDebt(ctx))
+	require.Equal(t, i(16000), k.liquidatorKeeper.GetCollateralBadDebt(ctx, "btc"))
+	records := k.liquidatorKeeper.GetBadDebtRecords(ctx)
+	require.Len(t, records, 1)
+	require.Equal(t, i(16000), records[0].SeizedDebt)
+	require.Equal(t, i(0), records[0].AmountRaised)
+	require.Equal(t, cs(c("btc", 3)), k.cdpKeeper.GetCoins(ctx, k.cdpKeeper.GetLiquidatorAccountAddress()))
+}
+
+func TestKeeper_LiquidationLimits(t *testing.T) {
+	_, addrs := mock.GeneratePrivKeyAddressPairs(2)
+
+	tests := []struct {
+		name             string
+		collateralLimit  sdk.Int
+		globalLimit      sdk.Int
+		expectSecondPass bool
+	}{
+		{"underLimits", i(100000), i(100000), true},
+		{"overCollateralLimit", i(30000), i(100000), false},
+		{"overGlobalLimit", i(100000), i(30000), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			ctx, k := setupTestKeepers()
+			auction.InitGenesis(ctx, k.auctionKeeper, auction.DefaultGenesisState())
+			cdp.InitGenesis(ctx, k.cdpKeeper, cdp.DefaultGenesisState())
+			genesis := DefaultGenesisState()
+			genesis.LiquidatorModuleParams.GlobalLiquidationLimit = tc.globalLimit
+			genesis.LiquidatorModuleParams.CollateralParams[0].AuctionSize = i(10) // btc
+			genesis.LiquidatorModuleParams.CollateralParams[0].LiquidationLimit = tc.collateralLimit
+			InitGenesis(ctx, k.liquidatorKeeper, genesis)
+			pricefeed.InitGenesis(ctx, k.pricefeedKeeper, pricefeed.GenesisState{Assets: []pricefeed.Asset{{AssetCode: "btc", Description: "a description"}}, Oracles: []pricefeed.Oracle{{OracleAddress: addrs[0].String()}}})
+			k.pricefeedKeeper.SetPrice(ctx, addrs[0], "btc", sdk.MustNewDecFromStr("8000.00"), i(999999999))
+			k.pricefeedKeeper.SetCurrentPrices(ctx)
+			for _, addr := range addrs {
+				k.bankKeeper.AddCoins(ctx, addr, cs(c("btc", 100)))
+				require.NoError(t, k.cdpKeeper.ModifyCDP(ctx, addr, "btc", i(10), i(50000)))
+			}
+			k.pricefeedKeeper.SetPrice(ctx, addrs[0], "btc", sdk.MustNewDecFromStr("7000.00"), i(999999999))
+			k.pricefeedKeeper.SetCurrentPrices(ctx)
 
-	// Check CDP
+			// Liquidate both CDPs, each auction raises up to 27999 usdx
+			auctionID, err := k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx, addrs[0], "btc")
+			require.NoError(t, err)
+			require.Equal(t, i(27999), k.liquidatorKeeper.GetInFlightDebt(ctx, "btc"))
+			require.Equal(t, i(27999), k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+			_, err = k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx, addrs[1], "btc")
+			if tc.expectSecondPass {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			cdp, _ := k.cdpKeeper.GetCDP(ctx, addrs[1], "btc")
+			require.Equal(t, i(10), cdp.CollateralAmount)
+
+			// Close the first auction, releasing the limits
+			require.NoError(t, k.auctionKeeper.CloseAuction(ctx.WithBlockTime(ctx.BlockHeader().Time.Add(auction.DefaultMaxAuctionDuration)), auctionID))
+			require.Equal(t, i(0), k.liquidatorKeeper.GetInFlightDebt(ctx, "btc"))
+			require.Equal(t, i(0), k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+			_, err = k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx, addrs[1], "btc")
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestHooks_AfterAuctionClosed(t *testing.T) {
+	_, addrs := mock.GeneratePrivKeyAddressPairs(2)
+	owner, bidder := addrs[0], addrs[1]
+
+	// The collateral auction sells 4 btc to raise a max of 27999 usdx, covering 26666 of seized debt
+	tests := []struct {
+		name               string
+		bid                sdk.Coin
+		lot                sdk.Coin
+		expectedBadDebt    sdk.Int
+		expectedSeizedDebt sdk.Int
+		expectedSurplus    sdk.Int
+	}{
+		{"underMaxBid", c("usdx", 20000), c("btc", 4), i(6666), i(6666), i(0)},
+		{"atMaxBid", c("usdx", 27999), c("btc", 3), i(0), i(0), i(1333)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			ctx, k := setupTestKeepers()
+			auction.InitGenesis(ctx, k.auctionKeeper, auction.DefaultGenesisState())
+			cdp.InitGenesis(ctx, k.cdpKeeper, cdp.DefaultGenesisState())
+			genesis := DefaultGenesisState()
+			genesis.LiquidatorModuleParams.CollateralParams[0].AuctionSize = i(10) // btc
+			InitGenesis(ctx, k.liquidatorKeeper, genesis)
+			pricefeed.InitGenesis(ctx, k.pricefeedKeeper, pricefeed.GenesisState{Assets: []pricefeed.Asset{{AssetCode: "btc", Description: "a description"}}, Oracles: []pricefeed.Oracle{{OracleAddress: owner.String()}}})
+			k.pricefeedKeeper.SetPrice(ctx, owner, "btc", sdk.MustNewDecFromStr("8000.00"), i(999999999))
+			k.pricefeedKeeper.SetCurrentPrices(ctx)
+			k.bankKeeper.AddCoins(ctx, owner, cs(c("btc", 100)))
+			k.bankKeeper.AddCoins(ctx, bidder, cs(c("usdx", 100000)))
+			require.NoError(t, k.cdpKeeper.ModifyCDP(ctx, owner, "btc", i(10), i(50000)))
+			k.pricefeedKeeper.SetPrice(ctx, owner, "btc", sdk.MustNewDecFromStr("7000.00"), i(999999999))
+			k.pricefeedKeeper.SetCurrentPrices(ctx)
+
+			auctionID, err := k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx, owner, "btc")
+			require.NoError(t, err)
+			require.NoError(t, k.auctionKeeper.PlaceBid(ctx, auctionID, bidder, tc.bid, tc.lot))
+
+			// Run test function
+			require.NoError(t, k.auctionKeeper.CloseAuction(ctx.WithBlockTime(ctx.BlockHeader().Time.Add(auction.DefaultMaxAuctionDuration)), auctionID))
+
+			// Check
+			require.Equal(t, tc.expectedBadDebt, k.liquidatorKeeper.GetBadDebt(ctx))
+			require.Equal(t, tc.expectedBadDebt, k.liquidatorKeeper.GetCollateralBadDebt(ctx, "btc"))
+			records := k.liquidatorKeeper.GetBadDebtRecords(ctx)
+			if tc.expectedBadDebt.IsZero() {
+				require.Empty(t, records)
+			} else {
+				require.Equal(t, BadDebtRecords{{auctionID, "btc", i(26666), tc.bid.Amount, tc.expectedBadDebt, ctx.BlockHeight()}}, records)
+			}
+			require.Equal(t, tc.expectedSeizedDebt, k.liquidatorKeeper.GetSeizedDebt(ctx).Total)
+			require.Equal(t, tc.expectedSurplus, k.cdpKeeper.GetCoins(ctx, k.cdpKeeper.GetLiquidatorAccountAddress()).AmountOf("usdx"))
+			require.Equal(t, tc.expectedSurplus, k.liquidatorKeeper.GetSurplus(ctx))
+			require.Equal(t, i(50000).Sub(tc.bid.Amount).Add(tc.expectedSurplus), k.cdpKeeper.GetGlobalDebt(ctx))
+			require.Equal(t, i(0), k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+		})
+	}
+}
+
+func TestHooks_AfterAuctionSplit(t *testing.T) {
+	_, addrs := mock.GeneratePrivKeyAddressPairs(2)
+	owner, bidder := addrs[0], addrs[1]
+
+	// Setup, with a collateral auction selling 4 btc to raise a max of 27999 usdx, covering 26666 of seized debt
+	ctx, k := setupTestKeepers()
+	auction.InitGenesis(ctx, k.auctionKeeper, auction.DefaultGenesisState())
+	cdp.InitGenesis(ctx, k.cdpKeeper, cdp.DefaultGenesisState())
+	genesis := DefaultGenesisState()
+	genesis.LiquidatorModuleParams.CollateralParams[0].AuctionSize = i(10) // btc
+	InitGenesis(ctx, k.liquidatorKeeper, genesis)
+	pricefeed.InitGenesis(ctx, k.pricefeedKeeper, pricefeed.GenesisState{Assets: []pricefeed.Asset{{AssetCode: "btc", Description: "a description"}}, Oracles: []pricefeed.Oracle{{OracleAddress: owner.String()}}})
+	k.pricefeedKeeper.SetPrice(ctx, owner, "btc", sdk.MustNewDecFromStr("8000.00"), i(999999999))
+	k.pricefeedKeeper.SetCurrentPrices(ctx)
+	k.bankKeeper.AddCoins(ctx, owner, cs(c("btc", 100)))
+	k.bankKeeper.AddCoins(ctx, bidder, cs(c("usdx", 100000)))
+	require.NoError(t, k.cdpKeeper.ModifyCDP(ctx, owner, "btc", i(10), i(50000)))
+	k.pricefeedKeeper.SetPrice(ctx, owner, "btc", sdk.MustNewDecFromStr("7000.00"), i(999999999))
+	k.pricefeedKeeper.SetCurrentPrices(ctx)
+	auctionID, err := k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx, owner, "btc")
 	require.NoError(t, err)
-	cdp, found := k.cdpKeeper.GetCDP(ctx, addrs[0], "btc")
+
+	// Run test function, bidding the full share of the max bid for 1 btc
+	childID, err := k.auctionKeeper.PlacePartialBid(ctx, auctionID, bidder, c("usdx", 6999), c("btc", 1))
+	require.NoError(t, err)
+
+	// Check the seized debt is shared between the auctions in proportion to their max bids
+	parentDebt, found := k.liquidatorKeeper.getCollateralAuctionDebt(ctx, auctionID)
 	require.True(t, found)
-	require.Equal(t, cdp.CollateralAmount, i(2)) // original amount - params.CollateralAuctionSize
-	require.Equal(t, cdp.Debt, i(10667))         // original debt scaled by amount of collateral removed
-	// Check auction exists
-	_, found = k.auctionKeeper.GetAuction(ctx, auctionID)
+	childDebt, found := k.liquidatorKeeper.getCollateralAuctionDebt(ctx, childID)
 	require.True(t, found)
-	// TODO check auction values are correct?
+	require.Equal(t, i(20001), parentDebt)
+	require.Equal(t, i(6665), childDebt)
+	require.Len(t, k.liquidatorKeeper.GetAuctions(ctx).CollateralAuctions, 2)
+
+	// Check closing both auctions settles the debt the child raised and records the rest as bad debt
+	closeCtx := ctx.WithBlockTime(ctx.BlockHeader().Time.Add(auction.DefaultMaxAuctionDuration))
+	require.NoError(t, k.auctionKeeper.CloseAuction(closeCtx, childID))
+	require.NoError(t, k.auctionKeeper.CloseAuction(closeCtx, auctionID))
+	require.Equal(t, parentDebt, k.liquidatorKeeper.GetBadDebt(ctx))
+	require.Equal(t, i(0), k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+	require.Equal(t, i(50000).Sub(i(6999)).Add(k.liquidatorKeeper.GetSurplus(ctx)), k.cdpKeeper.GetGlobalDebt(ctx))
+}
+
+func TestProposalHandler_FreezeCancelAuctions(t *testing.T) {
+	_, addrs := mock.GeneratePrivKeyAddressPairs(4)
+	owner1, owner2, owner3, bidder := addrs[0], addrs[1], addrs[2], addrs[3]
+
+	tests := []struct {
+		name        string
+		auctionType string
+	}{
+		{"forwardReverse", auction.ForwardReverseAuctionType},
+		{"batch", auction.BatchAuctionType},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup, with CDPs that are fully liquidated when the price drops to 5000
+			ctx, k := setupTestKeepers()
+			auction.InitGenesis(ctx, k.auctionKeeper, auction.DefaultGenesisState())
+			cdp.InitGenesis(ctx, k.cdpKeeper, cdp.DefaultGenesisState())
+			genesis := DefaultGenesisState()
+			genesis.LiquidatorModuleParams.CollateralParams[0].AuctionSize = i(10)
+			genesis.LiquidatorModuleParams.CollateralParams[0].AuctionType = tc.auctionType
+			genesis.LiquidatorModuleParams.CollateralParams[0].BatchAuctionParams = BatchAuctionParams{Window: 2}
+			InitGenesis(ctx, k.liquidatorKeeper, genesis)
+			pricefeed.InitGenesis(ctx, k.pricefeedKeeper, pricefeed.GenesisState{Assets: []pricefeed.Asset{{AssetCode: "btc", Description: "a description"}}, Oracles: []pricefeed.Oracle{{OracleAddress: owner1.String()}}})
+			k.pricefeedKeeper.SetPrice(ctx, owner1, "btc", sdk.MustNewDecFromStr("8000.00"), i(999999999))
+			k.pricefeedKeeper.SetCurrentPrices(ctx)
+			for _, owner := range []sdk.AccAddress{owner1, owner2, owner3} {
+				k.bankKeeper.AddCoins(ctx, owner, cs(c("btc", 100)))
+				require.NoError(t, k.cdpKeeper.ModifyCDP(ctx, owner, "btc", i(3), i(16000)))
+			}
+			k.bankKeeper.AddCoins(ctx, bidder, cs(c("usdx", 100000)))
+			k.pricefeedKeeper.SetPrice(ctx, owner1, "btc", sdk.MustNewDecFromStr("5000.00"), i(999999999))
+			k.pricefeedKeeper.SetCurrentPrices(ctx)
+
+			// Liquidate two CDPs in the bad blocks, with a bid on the first auction, and one CDP later on
+			auctionID1, err := k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx.WithBlockHeight(5), owner1, "btc")
+			require.NoError(t, err)
+			auctionID2, err := k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx.WithBlockHeight(6), owner2, "btc")
+			require.NoError(t, err)
+			if tc.auctionType == auction.BatchAuctionType {
+				require.Equal(t, auctionID1, auctionID2, "collateral is pooled into one auction")
+				require.NoError(t, k.auctionKeeper.PlaceBatchOrder(ctx.WithBlockHeight(6), auctionID1, bidder, i(2), sdk.MustNewDecFromStr("6000")))
+			} else {
+				require.NoError(t, k.auctionKeeper.PlaceBid(ctx, auctionID1, bidder, c("usdx", 10000), c("btc", 3)))
+			}
+			auctionID3, err := k.liquidatorKeeper.SeizeAndStartCollateralAuction(ctx.WithBlockHeight(20), owner3, "btc")
+			require.NoError(t, err)
+			require.Equal(t, i(3*16800), k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+
+			// Run test function, freezing and cancelling the auctions for the liquidations in the bad blocks
+			handler := NewProposalHandler(k.liquidatorKeeper)
+			require.NoError(t, handler(ctx, NewFreezeAuctionsProposal("title", "description", nil, 5, 6)))
+			require.True(t, k.auctionKeeper.IsAuctionFrozen(ctx, auctionID1))
+			require.True(t, k.auctionKeeper.IsAuctionFrozen(ctx, auctionID2))
+			require.False(t, k.auctionKeeper.IsAuctionFrozen(ctx, auctionID3))
+			require.NoError(t, handler(ctx, NewCancelAuctionsProposal("title", "description", nil, 5, 6)))
+
+			// Check the bid was refunded and the CDPs restored, with only the last liquidation still in progress
+			for _, auctionID := range []auction.ID{auctionID1, auctionID2} {
+				_, found := k.auctionKeeper.GetAuction(ctx, auctionID)
+				require.False(t, found)
+				require.Empty(t, k.liquidatorKeeper.getCollateralSeizures(ctx, auctionID))
+			}
+			_, found := k.auctionKeeper.GetAuction(ctx, auctionID3)
+			require.True(t, found)
+			require.Equal(t, cs(c("usdx", 100000)), k.bankKeeper.GetCoins(ctx, bidder))
+			for _, owner := range []sdk.AccAddress{owner1, owner2} {
+				restored, found := k.cdpKeeper.GetCDP(ctx, owner, "btc")
+				require.True(t, found)
+				require.Equal(t, i(3), restored.CollateralAmount)
+				require.Equal(t, i(16000), restored.Debt)
+			}
+			require.True(t, k.bankKeeper.GetCoins(ctx, k.cdpKeeper.GetLiquidatorAccountAddress()).AmountOf("btc").IsZero())
+			require.Equal(t, i(16000), k.liquidatorKeeper.GetSeizedDebt(ctx).Total)
+			require.Equal(t, i(16800), k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+			require.Equal(t, i(48000), k.cdpKeeper.GetGlobalDebt(ctx))
+
+			// Check auctions that no longer exist are skipped, without stopping the others from being cancelled
+			require.NoError(t, handler(ctx, NewFreezeAuctionsProposal("title", "description", []auction.ID{auctionID1, auctionID3}, 0, 0)))
+			require.True(t, k.auctionKeeper.IsAuctionFrozen(ctx, auctionID3))
+			require.NoError(t, handler(ctx, NewCancelAuctionsProposal("title", "description", []auction.ID{auctionID1, auctionID3}, 0, 0)))
+			_, found = k.auctionKeeper.GetAuction(ctx, auctionID3)
+			require.False(t, found)
+			require.Equal(t, i(0), k.liquidatorKeeper.GetTotalInFlightDebt(ctx))
+		})
+	}
+}
+
+func TestAuctionsProposal_ValidateBasic(t *testing.T) {
+	tests := []struct {
+		name       string
+		proposal   CancelAuctionsProposal
+		expectPass bool
+	}{
+		{"byID", NewCancelAuctionsProposal("title", "description", []auction.ID{1, 2}, 0, 0), true},
+		{"byHeight", NewCancelAuctionsProposal("title", "description", nil, 5, 5), true},
+		{"byIDAndHeight", NewCancelAuctionsProposal("title", "description", []auction.ID{1}, 5, 10), true},
+		{"noAuctions", NewCancelAuctionsProposal("title", "description", nil, 0, 0), false},
+		{"repeatedID", NewCancelAuctionsProposal("title", "description", []auction.ID{1, 1}, 0, 0), false},
+		{"zeroStartHeight", NewCancelAuctionsProposal("title", "description", nil, 0, 10), false},
+		{"endBeforeStart", NewCancelAuctionsProposal("title", "description", nil, 10, 5), false},
+		{"noTitle", NewCancelAuctionsProposal("", "description", []auction.ID{1}, 0, 0), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectPass {
+				require.NoError(t, tc.proposal.ValidateBasic())
+			} else {
+				require.Error(t, tc.proposal.ValidateBasic())
+			}
+		})
+	}
 }
 
 func TestKeeper_StartDebtAuction(t *testing.T) {
 	// Setup
 	ctx, k := setupTestKeepers()
+	auction.InitGenesis(ctx, k.auctionKeeper, auction.DefaultGenesisState())
 	InitGenesis(ctx, k.liquidatorKeeper, DefaultGenesisState())
 	initSDebt := SeizedDebt{i(2000), i(0)}
 	k.liquidatorKeeper.setSeizedDebt(ctx, initSDebt)
@@ -90,6 +690,34 @@ func TestKeeper_StartDebtAuction(t *testing.T) {
 // 	require.True(t, found)
 // }
 
+func TestKeeper_GetAuctions(t *testing.T) {
+	// Setup
+	_, addrs := mock.GeneratePrivKeyAddressPairs(1)
+	seller := addrs[0]
+	ctx, k := setupTestKeepers()
+	auction.InitGenesis(ctx, k.auctionKeeper, auction.DefaultGenesisState())
+	InitGenesis(ctx, k.liquidatorKeeper, DefaultGenesisState())
+	k.liquidatorKeeper.setSeizedDebt(ctx, SeizedDebt{i(2000), i(0)})
+	k.bankKeeper.AddCoins(ctx, seller, cs(c("btc", 10)))
+	liquidatorAddress := k.cdpKeeper.GetLiquidatorAccountAddress()
+
+	debtAuctionID, err := k.liquidatorKeeper.StartDebtAuction(ctx)
+	require.NoError(t, err)
+	collateralAuctionID, err := k.auctionKeeper.StartForwardReverseAuction(ctx, liquidatorAddress, c("btc", 0), c("usdx", 100), sdk.ZeroInt(), seller)
+	require.NoError(t, err)
+	_, err = k.auctionKeeper.StartForwardAuction(ctx, seller, c("btc", 1), c("usdx", 0)) // not started by the liquidator
+	require.NoError(t, err)
+
+	// Run test function
+	auctions := k.liquidatorKeeper.GetAuctions(ctx)
+
+	// Check
+	require.Len(t, auctions.CollateralAuctions, 1)
+	require.Equal(t, collateralAuctionID, auctions.CollateralAuctions[0].GetID())
+	require.Len(t, auctions.DebtAuctions, 1)
+	require.Equal(t, debtAuctionID, auctions.DebtAuctions[0].GetID())
+}
+
 func TestKeeper_partialSeizeCDP(t *testing.T) {
 	// Setup
 	ctx, k := setupTestKeepers()
@@ -98,7 +726,7 @@ func TestKeeper_partialSeizeCDP(t *testing.T) {
 
 	cdp.InitGenesis(ctx, k.cdpKeeper, cdp.DefaultGenesisState())
 	InitGenesis(ctx, k.liquidatorKeeper, DefaultGenesisState())
-	pricefeed.InitGenesis(ctx, k.pricefeedKeeper, pricefeed.GenesisState{Assets: []pricefeed.Asset{{"btc", "a description"}}})
+	pricefeed.InitGenesis(ctx, k.pricefeedKeeper, pricefeed.GenesisState{Assets: []pricefeed.Asset{{AssetCode: "btc", Description: "a description"}}, Oracles: []pricefeed.Oracle{{OracleAddress: addrs[0].String()}}})
 	k.pricefeedKeeper.SetPrice(ctx, addrs[0], "btc", sdk.MustNewDecFromStr("8000.00"), i(999999999))
 	k.pricefeedKeeper.SetCurrentPrices(ctx)
 	k.bankKeeper.AddCoins(ctx, addrs[0], cs(c("btc", 100)))
@@ -131,3 +759,20 @@ func TestKeeper_GetSetSeizedDebt(t *testing.T) {
 	// Check
 	require.Equal(t, debt, readDebt)
 }
+
+func TestQuerier_SeizedDebt(t *testing.T) {
+	// Setup
+	ctx, k := setupTestKeepers()
+	k.liquidatorKeeper.setSeizedDebt(ctx, SeizedDebt{i(1000), i(300)})
+	querier := NewQuerier(k.liquidatorKeeper)
+
+	// Run test function
+	bz, err := querier(ctx, []string{QueryGetSeizedDebt}, abci.RequestQuery{})
+	require.NoError(t, err)
+
+	// Check the available debt is included in the response
+	var res SeizedDebtResponse
+	require.NoError(t, k.liquidatorKeeper.cdc.UnmarshalJSON(bz, &res))
+	require.Equal(t, SeizedDebtResponse{i(1000), i(300), i(700)}, res)
+	require.Contains(t, string(bz), `"available": "700"`)
+}
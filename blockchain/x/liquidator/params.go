@@ -1,8 +1,12 @@
 package liquidator
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
+
+	"github.com/kava-labs/kava-devnet/blockchain/x/auction"
 )
 
 /*
@@ -17,13 +21,33 @@ This approach will be awkward if we ever need to write individual parameters (be
 type LiquidatorModuleParams struct {
 	DebtAuctionSize sdk.Int
 	//SurplusAuctionSize sdk.Int
-	CollateralParams []CollateralParams
+	GlobalLiquidationLimit sdk.Int // Max amount of stable coin that can be being raised by collateral auctions at once, across all collateral types. Known as Hole in Maker.
+	CollateralParams       []CollateralParams
 }
 
 type CollateralParams struct {
-	Denom       string  // Coin name of collateral type
-	AuctionSize sdk.Int // Max amount of collateral to sell off in any one auction. Known as lump in Maker.
-	// LiquidationPenalty
+	Denom              string  // Coin name of collateral type
+	AuctionSize        sdk.Int // Max amount of collateral to sell off in any one auction. Known as lump in Maker.
+	LiquidationPenalty sdk.Dec // Fraction of seized debt added on top when raising stable coin in an auction. Known as chop in Maker.
+	TargetRatio        sdk.Dec // Collateral ratio that a partial liquidation aims to restore a CDP to. Should be above the cdp module's LiquidationRatio.
+	FloorRatio         sdk.Dec // Collateral ratio below which CDPs are fully liquidated rather than partially.
+	LiquidationLimit   sdk.Int // Max amount of stable coin that can be being raised by collateral auctions for this collateral type at once. Known as hole in Maker.
+	AuctionType        string  // Type of auction seized collateral is sold in, either auction.ForwardReverseAuctionType (used if empty), auction.DutchAuctionType or auction.BatchAuctionType.
+	DutchAuctionParams DutchAuctionParams
+	BatchAuctionParams BatchAuctionParams
+}
+
+// DutchAuctionParams configure the dutch auctions for a collateral type. Prices are set relative to the pricefeed price when an auction starts.
+type DutchAuctionParams struct {
+	StartMarkup sdk.Dec            // Fraction above the pricefeed price that the auction price starts at. Known as buf in Maker.
+	ResetRatio  sdk.Dec            // Fraction of the pricefeed price below which the auction restarts. Known as cusp in Maker.
+	PriceCurve  auction.PriceCurve // How the auction price decays over time. Known as calc in Maker.
+}
+
+// BatchAuctionParams configure the batch auctions for a collateral type.
+type BatchAuctionParams struct {
+	Window     int64 // Number of blocks seized collateral is pooled for before the batch auction selling it clears.
+	MaxRepools int64 // Number of times unsold collateral is pooled into a new batch auction before the debt it didn't cover is recorded as bad debt.
 }
 
 var moduleParamsKey = []byte("LiquidatorModuleParams")
@@ -34,6 +58,54 @@ func createParamsKeyTable() params.KeyTable {
 	)
 }
 
+// Implement fmt.Stringer interface for cli querying
+func (p LiquidatorModuleParams) String() string {
+	out := fmt.Sprintf(`Params:
+	Debt Auction Size:        %s
+	Global Liquidation Limit: %s
+	Collateral Params:`,
+		p.DebtAuctionSize,
+		p.GlobalLiquidationLimit,
+	)
+	for _, cp := range p.CollateralParams {
+		out += fmt.Sprintf(`
+		%s
+			Auction Size:        %s
+			Liquidation Penalty: %s
+			Target Ratio:        %s
+			Floor Ratio:         %s
+			Liquidation Limit:   %s
+			Auction Type:        %s`,
+			cp.Denom,
+			cp.AuctionSize,
+			cp.LiquidationPenalty,
+			cp.TargetRatio,
+			cp.FloorRatio,
+			cp.LiquidationLimit,
+			cp.auctionType(),
+		)
+		if cp.auctionType() == auction.DutchAuctionType {
+			out += fmt.Sprintf(`
+			Start Markup:        %s
+			Reset Ratio:         %s
+			Price Curve:         %s`,
+				cp.DutchAuctionParams.StartMarkup,
+				cp.DutchAuctionParams.ResetRatio,
+				cp.DutchAuctionParams.PriceCurve,
+			)
+		}
+		if cp.auctionType() == auction.BatchAuctionType {
+			out += fmt.Sprintf(`
+			Window:              %d blocks
+			Max Repools:         %d`,
+				cp.BatchAuctionParams.Window,
+				cp.BatchAuctionParams.MaxRepools,
+			)
+		}
+	}
+	return out
+}
+
 // Helper methods to search the list of collateral params for a particular denom. Wouldn't be needed if amino supported maps.
 
 func (p LiquidatorModuleParams) GetCollateralParams(collateralDenom string) CollateralParams {
@@ -46,3 +118,11 @@ func (p LiquidatorModuleParams) GetCollateralParams(collateralDenom string) Coll
 	// panic if not found, to be safe
 	panic("collateral params not found in module params")
 }
+
+// auctionType returns the type of auction used to sell this collateral, defaulting to forward reverse auctions.
+func (cp CollateralParams) auctionType() string {
+	if cp.AuctionType == "" {
+		return auction.ForwardReverseAuctionType
+	}
+	return cp.AuctionType
+}
@@ -0,0 +1,139 @@
+package liquidator
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+
+	"github.com/kava-labs/kava-devnet/blockchain/x/auction"
+)
+
+const (
+	// DefaultCodespace is the codespace for errors from validating liquidator proposals
+	DefaultCodespace sdk.CodespaceType = ModuleName
+
+	ProposalTypeFreezeAuctions = "FreezeAuctions"
+	ProposalTypeCancelAuctions = "CancelAuctions"
+)
+
+var _ gov.Content = FreezeAuctionsProposal{}
+var _ gov.Content = CancelAuctionsProposal{}
+
+func init() {
+	gov.RegisterProposalType(ProposalTypeFreezeAuctions)
+	gov.RegisterProposalTypeCodec(FreezeAuctionsProposal{}, "liquidator/FreezeAuctionsProposal")
+	gov.RegisterProposalType(ProposalTypeCancelAuctions)
+	gov.RegisterProposalTypeCodec(CancelAuctionsProposal{}, "liquidator/CancelAuctionsProposal")
+}
+
+// FreezeAuctionsProposal stops auctions from accepting bids or closing, so they can be cancelled.
+// Auctions are chosen by ID, and by the block heights CDPs were liquidated at: if EndHeight is set, the liquidator's collateral auctions selling collateral seized between StartHeight and EndHeight (inclusive) are frozen too.
+type FreezeAuctionsProposal struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	AuctionIDs  []auction.ID `json:"auction_ids"`
+	StartHeight int64        `json:"start_height"`
+	EndHeight   int64        `json:"end_height"`
+}
+
+// NewFreezeAuctionsProposal creates a new FreezeAuctionsProposal
+func NewFreezeAuctionsProposal(title, description string, auctionIDs []auction.ID, startHeight, endHeight int64) FreezeAuctionsProposal {
+	return FreezeAuctionsProposal{title, description, auctionIDs, startHeight, endHeight}
+}
+
+// GetTitle implements gov.Content
+func (p FreezeAuctionsProposal) GetTitle() string { return p.Title }
+
+// GetDescription implements gov.Content
+func (p FreezeAuctionsProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute implements gov.Content
+func (p FreezeAuctionsProposal) ProposalRoute() string { return ModuleName }
+
+// ProposalType implements gov.Content
+func (p FreezeAuctionsProposal) ProposalType() string { return ProposalTypeFreezeAuctions }
+
+// ValidateBasic implements gov.Content
+func (p FreezeAuctionsProposal) ValidateBasic() sdk.Error {
+	err := gov.ValidateAbstract(DefaultCodespace, p)
+	if err != nil {
+		return err
+	}
+	return validateAuctionSelection(p.AuctionIDs, p.StartHeight, p.EndHeight)
+}
+
+func (p FreezeAuctionsProposal) String() string {
+	return fmt.Sprintf(`Freeze Auctions Proposal:
+  Title:        %s
+  Description:  %s
+  Auction IDs:  %v
+  Start Height: %d
+  End Height:   %d
+`, p.Title, p.Description, p.AuctionIDs, p.StartHeight, p.EndHeight)
+}
+
+// CancelAuctionsProposal cancels auctions, refunding their bids and returning their lots.
+// The collateral and debt seized for the liquidator's collateral auctions is restored to the CDPs it was seized from.
+// Auctions are chosen the same way as for FreezeAuctionsProposal, and don't need to have been frozen first.
+type CancelAuctionsProposal struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	AuctionIDs  []auction.ID `json:"auction_ids"`
+	StartHeight int64        `json:"start_height"`
+	EndHeight   int64        `json:"end_height"`
+}
+
+// NewCancelAuctionsProposal creates a new CancelAuctionsProposal
+func NewCancelAuctionsProposal(title, description string, auctionIDs []auction.ID, startHeight, endHeight int64) CancelAuctionsProposal {
+	return CancelAuctionsProposal{title, description, auctionIDs, startHeight, endHeight}
+}
+
+// GetTitle implements gov.Content
+func (p CancelAuctionsProposal) GetTitle() string { return p.Title }
+
+// GetDescription implements gov.Content
+func (p CancelAuctionsProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute implements gov.Content
+func (p CancelAuctionsProposal) ProposalRoute() string { return ModuleName }
+
+// ProposalType implements gov.Content
+func (p CancelAuctionsProposal) ProposalType() string { return ProposalTypeCancelAuctions }
+
+// ValidateBasic implements gov.Content
+func (p CancelAuctionsProposal) ValidateBasic() sdk.Error {
+	err := gov.ValidateAbstract(DefaultCodespace, p)
+	if err != nil {
+		return err
+	}
+	return validateAuctionSelection(p.AuctionIDs, p.StartHeight, p.EndHeight)
+}
+
+func (p CancelAuctionsProposal) String() string {
+	return fmt.Sprintf(`Cancel Auctions Proposal:
+  Title:        %s
+  Description:  %s
+  Auction IDs:  %v
+  Start Height: %d
+  End Height:   %d
+`, p.Title, p.Description, p.AuctionIDs, p.StartHeight, p.EndHeight)
+}
+
+// validateAuctionSelection checks a proposal chooses some auctions, without repeating any IDs, and that any height range is valid.
+func validateAuctionSelection(auctionIDs []auction.ID, startHeight int64, endHeight int64) sdk.Error {
+	if len(auctionIDs) == 0 && endHeight == 0 {
+		return sdk.ErrInternal("proposal must choose auctions by ID or by height")
+	}
+	seen := make(map[auction.ID]bool)
+	for _, auctionID := range auctionIDs {
+		if seen[auctionID] {
+			return sdk.ErrInternal(fmt.Sprintf("auction %d is repeated", auctionID))
+		}
+		seen[auctionID] = true
+	}
+	if (startHeight != 0 || endHeight != 0) && (startHeight <= 0 || endHeight < startHeight) {
+		return sdk.ErrInternal(fmt.Sprintf("invalid height range %d to %d, start height must be positive and not after end height", startHeight, endHeight))
+	}
+	return nil
+}
@@ -7,7 +7,15 @@ import (
 )
 
 const (
-	QueryGetOutstandingDebt = "outstanding_debt" // Get the outstanding seized debt
+	QueryGetOutstandingDebt    = "outstanding_debt"    // Get the outstanding seized debt
+	QueryGetBadDebt            = "bad_debt"            // Get the total bad debt from collateral auctions
+	QueryGetCollateralBadDebt  = "collateral_bad_debt" // Get the bad debt from collateral auctions of one collateral type
+	QueryGetUnderwaterAuctions = "underwater_auctions" // Get the collateral auctions that closed without covering their debt
+	QueryGetSeizedDebt         = "seized_debt"         // Get the full seized debt record
+	QueryGetModuleAccount      = "module_account"      // Get the coins held by the liquidator module account
+	QueryGetSurplus            = "surplus"             // Get the stable coin held in excess of the seized debt
+	QueryGetAuctions           = "auctions"            // Get the open auctions started by the liquidator
+	QueryGetParams             = "params"              // Get the liquidator params
 )
 
 func NewQuerier(keeper Keeper) sdk.Querier {
@@ -15,8 +23,22 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 		switch path[0] {
 		case QueryGetOutstandingDebt:
 			return queryGetOutstandingDebt(ctx, path[1:], req, keeper)
-		// case QueryGetSurplus:
-		// 	return queryGetSurplus()
+		case QueryGetBadDebt:
+			return queryGetBadDebt(ctx, req, keeper)
+		case QueryGetCollateralBadDebt:
+			return queryGetCollateralBadDebt(ctx, path[1:], req, keeper)
+		case QueryGetUnderwaterAuctions:
+			return queryGetUnderwaterAuctions(ctx, req, keeper)
+		case QueryGetSeizedDebt:
+			return queryGetSeizedDebt(ctx, req, keeper)
+		case QueryGetModuleAccount:
+			return queryGetModuleAccount(ctx, req, keeper)
+		case QueryGetSurplus:
+			return queryGetSurplus(ctx, req, keeper)
+		case QueryGetAuctions:
+			return queryGetAuctions(ctx, req, keeper)
+		case QueryGetParams:
+			return queryGetParams(ctx, req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown liquidator query endpoint")
 		}
@@ -42,6 +64,73 @@ func queryGetOutstandingDebt(ctx sdk.Context, path []string, req abci.RequestQue
 	// Encode and return
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, oustandingDebt)
 	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
+func queryGetBadDebt(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetBadDebt(ctx))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryGetCollateralBadDebt(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	if len(path) == 0 {
+		return nil, sdk.ErrUnknownRequest("collateral denom not specified")
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetCollateralBadDebt(ctx, path[0]))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryGetUnderwaterAuctions(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetBadDebtRecords(ctx))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryGetSeizedDebt(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, NewSeizedDebtResponse(keeper.GetSeizedDebt(ctx)))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryGetModuleAccount(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.bankKeeper.GetCoins(ctx, keeper.cdpKeeper.GetLiquidatorAccountAddress()))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryGetSurplus(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetSurplus(ctx))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryGetAuctions(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetAuctions(ctx))
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
+
+func queryGetParams(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetParams(ctx))
+	if err != nil {
 		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
 	}
 	return bz, nil
@@ -72,7 +72,7 @@ func setupTestKeepers() (sdk.Context, keepers) {
 		paramsKeeper.Subspace(bank.DefaultParamspace),
 		bank.DefaultCodespace,
 	)
-	pricefeedKeeper := pricefeed.NewKeeper(keyPriceFeed, cdc, pricefeed.DefaultCodespace)
+	pricefeedKeeper := pricefeed.NewKeeper(keyPriceFeed, cdc, paramsKeeper.Subspace("pricefeedSubspace"), pricefeed.DefaultCodespace)
 	cdpKeeper := cdp.NewKeeper(
 		cdc,
 		keyCDP,
@@ -80,15 +80,17 @@ func setupTestKeepers() (sdk.Context, keepers) {
 		pricefeedKeeper,
 		bankKeeper,
 	)
-	auctionKeeper := auction.NewKeeper(cdc, cdpKeeper, keyAuction) // Note: cdp keeper stands in for bank keeper
+	auctionKeeper := auction.NewKeeper(cdc, cdpKeeper, keyAuction, paramsKeeper.Subspace("auctionSubspace")) // Note: cdp keeper stands in for bank keeper
 	liquidatorKeeper := NewKeeper(
 		cdc,
 		keyLiquidator,
 		paramsKeeper.Subspace("liquidatorSubspace"),
 		cdpKeeper,
-		auctionKeeper,
+		&auctionKeeper, // by reference, so the liquidator's auction keeper has the hooks set below
 		cdpKeeper,
+		pricefeedKeeper,
 	) // Note: cdp keeper stands in for bank keeper
+	auctionKeeper.SetHooks(liquidatorKeeper.Hooks())
 
 	// Create context
 	ctx := sdk.NewContext(ms, abci.Header{ChainID: "testchain"}, false, log.NewNopLogger())
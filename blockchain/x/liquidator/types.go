@@ -1,15 +1,29 @@
 package liquidator
 
 import (
+	"fmt"
+	"strings"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/kava-labs/kava-devnet/blockchain/x/auction"
 )
 
 type SeizedDebt struct {
-	Total         sdk.Int // Total debt seized from CDPs. Known as Awe in maker.
-	SentToAuction sdk.Int // Portion of seized debt that has had a (reverse) auction was started for it. Known as Ash in maker.
+	Total         sdk.Int `json:"total"`           // Total debt seized from CDPs. Known as Awe in maker.
+	SentToAuction sdk.Int `json:"sent_to_auction"` // Portion of seized debt that has had a (reverse) auction was started for it. Known as Ash in maker.
 	// SentToAuction should always be < Total
 }
 
+func (sd SeizedDebt) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Seized Debt:
+  Total:           %s
+  Sent To Auction: %s
+  Available:       %s`,
+		sd.Total, sd.SentToAuction, sd.Available(),
+	))
+}
+
 // Available gets the seized debt that has not been sent for auction. Known as Woe in maker.
 func (sd SeizedDebt) Available() sdk.Int {
 	return sd.Total.Sub(sd.SentToAuction)
@@ -26,3 +40,138 @@ func (sd SeizedDebt) Settle(amount sdk.Int) (SeizedDebt, sdk.Error) {
 	sd.SentToAuction = sdk.MaxInt(sd.SentToAuction.Sub(amount), sdk.ZeroInt())
 	return sd, nil
 }
+
+// SeizedDebtResponse is the seized debt record returned by queries, with the available debt included as a field
+type SeizedDebtResponse struct {
+	Total         sdk.Int `json:"total"`
+	SentToAuction sdk.Int `json:"sent_to_auction"`
+	Available     sdk.Int `json:"available"`
+}
+
+// NewSeizedDebtResponse creates a SeizedDebtResponse from a seized debt record
+func NewSeizedDebtResponse(sd SeizedDebt) SeizedDebtResponse {
+	return SeizedDebtResponse{sd.Total, sd.SentToAuction, sd.Available()}
+}
+
+func (r SeizedDebtResponse) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Seized Debt:
+  Total:           %s
+  Sent To Auction: %s
+  Available:       %s`,
+		r.Total, r.SentToAuction, r.Available,
+	))
+}
+
+// BadDebtRecord records a collateral auction that closed without raising enough stable coin to cover the debt seized for it.
+type BadDebtRecord struct {
+	AuctionID       auction.ID `json:"auction_id"`
+	CollateralDenom string     `json:"collateral_denom"`
+	SeizedDebt      sdk.Int    `json:"seized_debt"`   // debt seized from the CDP when the auction was started
+	AmountRaised    sdk.Int    `json:"amount_raised"` // stable coin raised by the winning bid
+	BadDebt         sdk.Int    `json:"bad_debt"`      // seized debt not covered by the auction
+	Height          int64      `json:"height"`        // block height the auction closed at
+}
+
+func (r BadDebtRecord) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Auction %d:
+  Collateral Denom: %s
+  Seized Debt:      %s
+  Amount Raised:    %s
+  Bad Debt:         %s
+  Height:           %d`,
+		r.AuctionID, r.CollateralDenom, r.SeizedDebt, r.AmountRaised, r.BadDebt, r.Height,
+	))
+}
+
+type BadDebtRecords []BadDebtRecord
+
+func (rs BadDebtRecords) String() string {
+	out := ""
+	for _, r := range rs {
+		out += r.String() + "\n"
+	}
+	return out
+}
+
+// LiquidatorAuctions are the open auctions started by the liquidator, grouped by what they are for.
+type LiquidatorAuctions struct {
+	CollateralAuctions []auction.Auction `json:"collateral_auctions"` // forward reverse auctions selling seized collateral
+	DebtAuctions       []auction.Auction `json:"debt_auctions"`       // reverse auctions selling minted gov coin
+}
+
+func (la LiquidatorAuctions) String() string {
+	out := "Collateral Auctions:\n"
+	for _, a := range la.CollateralAuctions {
+		out += a.String() + "\n"
+	}
+	out += "Debt Auctions:\n"
+	for _, a := range la.DebtAuctions {
+		out += a.String() + "\n"
+	}
+	return out
+}
+
+// CollateralSeizure records collateral and debt seized from a CDP for a collateral auction, so they can be restored to the CDP if the auction is cancelled.
+// Batch auctions pool collateral from many CDPs, so they can have many seizures. Seizures are also used to find the auctions for liquidations in a range of blocks.
+type CollateralSeizure struct {
+	Owner      sdk.AccAddress `json:"owner"`      // owner of the CDP
+	Collateral sdk.Int        `json:"collateral"` // collateral seized that is still in the auction
+	Debt       sdk.Int        `json:"debt"`       // debt seized that the auction is still covering
+	Height     int64          `json:"height"`     // block height the CDP was liquidated at
+}
+
+type CollateralSeizures []CollateralSeizure
+
+// split moves a share of each seizure into a new set of seizures, for collateral that moves to another auction along with the debt it covers.
+// The shares are collateral/totalCollateral of each seizure's collateral and debt/totalDebt of its debt, rounded down.
+func (ss CollateralSeizures) split(collateral, totalCollateral, debt, totalDebt sdk.Int) (moved CollateralSeizures, kept CollateralSeizures) {
+	for _, s := range ss {
+		m := s
+		m.Collateral = share(s.Collateral, collateral, totalCollateral)
+		m.Debt = share(s.Debt, debt, totalDebt)
+		s.Collateral = s.Collateral.Sub(m.Collateral)
+		s.Debt = s.Debt.Sub(m.Debt)
+		moved = append(moved, m)
+		kept = append(kept, s)
+	}
+	return moved, kept
+}
+
+// distribute shares an amount between the seizures in proportion to the weight of each, rounding down, with the remainder going to the last seizure.
+// If the weights are all zero, the last seizure gets the whole amount.
+func (ss CollateralSeizures) distribute(amount sdk.Int, weight func(CollateralSeizure) sdk.Int) []sdk.Int {
+	totalWeight := sdk.ZeroInt()
+	for _, s := range ss {
+		totalWeight = totalWeight.Add(weight(s))
+	}
+	shares := make([]sdk.Int, len(ss))
+	remaining := amount
+	for j, s := range ss {
+		shares[j] = sdk.ZeroInt()
+		if j == len(ss)-1 {
+			shares[j] = remaining
+		} else if totalWeight.IsPositive() {
+			shares[j] = share(amount, weight(s), totalWeight)
+			remaining = remaining.Sub(shares[j])
+		}
+	}
+	return shares
+}
+
+// seizedBetween returns true if any of the seizures were made between two block heights, inclusive
+func (ss CollateralSeizures) seizedBetween(startHeight, endHeight int64) bool {
+	for _, s := range ss {
+		if s.Height >= startHeight && s.Height <= endHeight {
+			return true
+		}
+	}
+	return false
+}
+
+// share returns amount*numerator/denominator rounded down, or zero if the denominator is zero
+func share(amount, numerator, denominator sdk.Int) sdk.Int {
+	if denominator.IsZero() {
+		return sdk.ZeroInt()
+	}
+	return amount.Mul(numerator).Quo(denominator)
+}
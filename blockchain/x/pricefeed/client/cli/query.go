@@ -70,3 +70,28 @@ func GetCmdAssets(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdOracles queries the oracles allowed to post prices, for all assets or for one asset
+func GetCmdOracles(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "oracles [assetCode]",
+		Short: "get the oracles allowed to post prices, and the block height each was added at",
+		Long:  "Get the global oracle list, or if an asset code is given, the oracles allowed to post prices for that asset.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, pricefeed.QueryOracles)
+			if len(args) == 1 {
+				route = fmt.Sprintf("%s/%s", route, args[0])
+			}
+			res, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				fmt.Printf("could not get oracles")
+				return nil
+			}
+			var out pricefeed.Oracles
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
@@ -2,12 +2,15 @@ package cli
 
 import (
 	"fmt"
+	"io/ioutil"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/kava-labs/kava-devnet/blockchain/x/pricefeed"
 	"github.com/spf13/cobra"
 )
@@ -43,3 +46,229 @@ func GetCmdPostPrice(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// OracleProposalJSON is the contents of a proposal file for adding, removing or replacing an oracle
+type OracleProposalJSON struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Oracle      sdk.AccAddress `json:"oracle"`
+	NewOracle   sdk.AccAddress `json:"new_oracle"` // only used when replacing an oracle
+	Deposit     sdk.Coins      `json:"deposit"`
+}
+
+const oracleProposalExample = `
+Where proposal.json contains:
+
+{
+  "title": "%s oracle",
+  "description": "%s",
+  "oracle": "kava1...",%s
+  "deposit": [
+    {
+      "denom": "kava",
+      "amount": "10000"
+    }
+  ]
+}
+`
+
+// GetCmdSubmitAddOracleProposal cli command for submitting a proposal to add an oracle.
+func GetCmdSubmitAddOracleProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add-oracle [proposal-file]",
+		Short: "Submit a proposal to add a pricefeed oracle",
+		Long: strings.TrimSpace(`Submit a proposal to add an oracle to the pricefeed, along with an initial deposit.
+The proposal details must be supplied via a JSON file.
+` + fmt.Sprintf(oracleProposalExample, "Add", "Add a new price provider", "")),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitOracleProposal(cdc, args[0], func(p OracleProposalJSON) gov.Content {
+				return pricefeed.NewAddOracleProposal(p.Title, p.Description, p.Oracle)
+			})
+		},
+	}
+}
+
+// GetCmdSubmitRemoveOracleProposal cli command for submitting a proposal to remove an oracle.
+func GetCmdSubmitRemoveOracleProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove-oracle [proposal-file]",
+		Short: "Submit a proposal to remove a pricefeed oracle",
+		Long: strings.TrimSpace(`Submit a proposal to remove an oracle from the pricefeed, along with an initial deposit. The prices it has posted are deleted.
+The proposal details must be supplied via a JSON file.
+` + fmt.Sprintf(oracleProposalExample, "Remove", "The oracle's key has been compromised", "")),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitOracleProposal(cdc, args[0], func(p OracleProposalJSON) gov.Content {
+				return pricefeed.NewRemoveOracleProposal(p.Title, p.Description, p.Oracle)
+			})
+		},
+	}
+}
+
+// GetCmdSubmitReplaceOracleProposal cli command for submitting a proposal to replace an oracle.
+func GetCmdSubmitReplaceOracleProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "replace-oracle [proposal-file]",
+		Short: "Submit a proposal to replace a pricefeed oracle",
+		Long: strings.TrimSpace(`Submit a proposal to replace an oracle in the pricefeed with a new one, along with an initial deposit. The prices posted by the old oracle are deleted.
+The proposal details must be supplied via a JSON file.
+` + fmt.Sprintf(oracleProposalExample, "Replace", "Rotate the oracle's key", `
+  "new_oracle": "kava1...",`)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return submitOracleProposal(cdc, args[0], func(p OracleProposalJSON) gov.Content {
+				return pricefeed.NewReplaceOracleProposal(p.Title, p.Description, p.Oracle, p.NewOracle)
+			})
+		},
+	}
+}
+
+// AssetOraclesProposalJSON is the contents of a proposal file for setting the oracles of an asset
+type AssetOraclesProposalJSON struct {
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	AssetCode   string           `json:"asset_code"`
+	Oracles     []sdk.AccAddress `json:"oracles"`
+	Deposit     sdk.Coins        `json:"deposit"`
+}
+
+// GetCmdSubmitAssetOraclesProposal cli command for submitting a proposal to set the oracles of an asset.
+func GetCmdSubmitAssetOraclesProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "asset-oracles [proposal-file]",
+		Short: "Submit a proposal to set the oracles allowed to post prices for an asset",
+		Long: strings.TrimSpace(`Submit a proposal to set the oracles allowed to post prices for an asset, instead of the global oracle list, along with an initial deposit.
+Prices posted for the asset by any other oracles are deleted. An empty list of oracles makes the asset use the global oracle list again.
+The proposal details must be supplied via a JSON file.
+
+Where proposal.json contains:
+
+{
+  "title": "XRP oracles",
+  "description": "Only allow XRP data providers to post XRP prices",
+  "asset_code": "xrp",
+  "oracles": ["kava1...", "kava1..."],
+  "deposit": [
+    {
+      "denom": "kava",
+      "amount": "10000"
+    }
+  ]
+}
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			var proposal AssetOraclesProposalJSON
+			contents, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			err = cdc.UnmarshalJSON(contents, &proposal)
+			if err != nil {
+				return err
+			}
+
+			content := pricefeed.NewAssetOraclesProposal(proposal.Title, proposal.Description, proposal.AssetCode, proposal.Oracles)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// AssetQuorumProposalJSON is the contents of a proposal file for setting the quorum of an asset
+type AssetQuorumProposalJSON struct {
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	AssetCode       string    `json:"asset_code"`
+	MinPosts        int       `json:"min_posts"`
+	MinPostFraction sdk.Dec   `json:"min_post_fraction"`
+	Deposit         sdk.Coins `json:"deposit"`
+}
+
+// GetCmdSubmitAssetQuorumProposal cli command for submitting a proposal to set the quorum of an asset.
+func GetCmdSubmitAssetQuorumProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "asset-quorum [proposal-file]",
+		Short: "Submit a proposal to set how many oracles must post prices to update an asset's price",
+		Long: strings.TrimSpace(`Submit a proposal to set the quorum for an asset, along with an initial deposit.
+The current price is only updated when at least min_posts oracles, and at least min_post_fraction of the asset's oracles, have unexpired prices. Otherwise the previous price is kept and marked stale.
+The proposal details must be supplied via a JSON file.
+
+Where proposal.json contains:
+
+{
+  "title": "BTC quorum",
+  "description": "Require a majority of oracles to post BTC prices",
+  "asset_code": "btc",
+  "min_posts": "2",
+  "min_post_fraction": "0.51",
+  "deposit": [
+    {
+      "denom": "kava",
+      "amount": "10000"
+    }
+  ]
+}
+`),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(cdc)
+
+			var proposal AssetQuorumProposalJSON
+			contents, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			err = cdc.UnmarshalJSON(contents, &proposal)
+			if err != nil {
+				return err
+			}
+
+			content := pricefeed.NewAssetQuorumProposal(proposal.Title, proposal.Description, proposal.AssetCode, proposal.MinPosts, proposal.MinPostFraction)
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// submitOracleProposal reads a proposal file and submits the proposal content made from it
+func submitOracleProposal(cdc *codec.Codec, proposalFile string, makeContent func(OracleProposalJSON) gov.Content) error {
+	txBldr := authtxb.NewTxBuilderFromCLI().WithTxEncoder(utils.GetTxEncoder(cdc))
+	cliCtx := context.NewCLIContext().
+		WithCodec(cdc).
+		WithAccountDecoder(cdc)
+
+	var proposal OracleProposalJSON
+	contents, err := ioutil.ReadFile(proposalFile)
+	if err != nil {
+		return err
+	}
+	err = cdc.UnmarshalJSON(contents, &proposal)
+	if err != nil {
+		return err
+	}
+
+	msg := gov.NewMsgSubmitProposal(makeContent(proposal), proposal.Deposit, cliCtx.GetFromAddress())
+	err = msg.ValidateBasic()
+	if err != nil {
+		return err
+	}
+	return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+}
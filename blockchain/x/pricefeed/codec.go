@@ -7,6 +7,11 @@ import (
 // RegisterCodec registers concrete types on the Amino codec
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgPostPrice{}, "pricefeed/MsgPostPrice", nil)
+	cdc.RegisterConcrete(AddOracleProposal{}, "pricefeed/AddOracleProposal", nil)
+	cdc.RegisterConcrete(RemoveOracleProposal{}, "pricefeed/RemoveOracleProposal", nil)
+	cdc.RegisterConcrete(ReplaceOracleProposal{}, "pricefeed/ReplaceOracleProposal", nil)
+	cdc.RegisterConcrete(AssetOraclesProposal{}, "pricefeed/AssetOraclesProposal", nil)
+	cdc.RegisterConcrete(AssetQuorumProposal{}, "pricefeed/AssetQuorumProposal", nil)
 }
 
 // generic sealed codec to be used throughout module
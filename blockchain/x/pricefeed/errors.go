@@ -20,6 +20,12 @@ const (
 	CodeInvalidAsset sdk.CodeType = 4
 	// CodeInvalidOracle error code for invalid oracle
 	CodeInvalidOracle sdk.CodeType = 5
+	// CodeDuplicateOracle error code for adding an oracle that already exists
+	CodeDuplicateOracle sdk.CodeType = 6
+	// CodeLastAssetOracle error code for removing the last oracle of an asset with its own oracles
+	CodeLastAssetOracle sdk.CodeType = 7
+	// CodeInvalidQuorum error code for a negative min posts or a min post fraction outside 0 to 1
+	CodeInvalidQuorum sdk.CodeType = 8
 )
 
 // ErrEmptyInput Error constructor
@@ -46,3 +52,18 @@ func ErrInvalidAsset(codespace sdk.CodespaceType) sdk.Error {
 func ErrInvalidOracle(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidOracle, fmt.Sprintf("Oracle does not exist or not authorized."))
 }
+
+// ErrDuplicateOracle Error constructor for adding an oracle that is already in the oracle list
+func ErrDuplicateOracle(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeDuplicateOracle, fmt.Sprintf("Oracle already exists."))
+}
+
+// ErrLastAssetOracle Error constructor for removing the last oracle of an asset with its own oracles
+func ErrLastAssetOracle(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeLastAssetOracle, fmt.Sprintf("Oracle is the last oracle of an asset, set the asset's oracles first."))
+}
+
+// ErrInvalidQuorum Error constructor for asset quorums with negative min posts or a min post fraction outside 0 to 1
+func ErrInvalidQuorum(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidQuorum, fmt.Sprintf("Min posts must not be negative and min post fraction must be between 0 and 1."))
+}
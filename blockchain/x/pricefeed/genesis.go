@@ -15,14 +15,41 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, genState GenesisState) {
 	}
 
 	for _, oracle := range genState.Oracles {
-		keeper.AddOracle(ctx, oracle.OracleAddress)
+		err := keeper.AddOracle(ctx, oracle.OracleAddress)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	for _, asset := range genState.Assets {
+		if asset.MinPosts != 0 || !asset.MinPostFraction.IsNil() {
+			minPostFraction := asset.MinPostFraction
+			if minPostFraction.IsNil() {
+				minPostFraction = sdk.ZeroDec()
+			}
+			err := keeper.SetAssetQuorum(ctx, asset.AssetCode, asset.MinPosts, minPostFraction)
+			if err != nil {
+				panic(err)
+			}
+		}
+		if len(asset.Oracles) == 0 {
+			continue
+		}
+		var addresses []string
+		for _, oracle := range asset.Oracles {
+			addresses = append(addresses, oracle.OracleAddress)
+		}
+		err := keeper.SetAssetOracles(ctx, asset.AssetCode, addresses)
+		if err != nil {
+			panic(err)
+		}
 	}
 }
 
 // DefaultGenesisState returns a default genesis state
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		[]Asset{{"btc", "a description"}, {"xrp", "the standard"}},
+		[]Asset{{AssetCode: "btc", Description: "a description"}, {AssetCode: "xrp", Description: "the standard"}},
 		[]Oracle{}}
 }
 
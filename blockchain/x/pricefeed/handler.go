@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 )
 
 // NewHandler handles all pricefeed type messages
@@ -19,6 +20,31 @@ func NewHandler(k Keeper) sdk.Handler {
 	}
 }
 
+// NewProposalHandler handles the pricefeed's governance proposals, which change the oracle list, and the oracles and quorum of each asset.
+func NewProposalHandler(k Keeper) gov.Handler {
+	return func(ctx sdk.Context, content gov.Content) sdk.Error {
+		switch c := content.(type) {
+		case AddOracleProposal:
+			return k.AddOracle(ctx, c.Oracle.String())
+		case RemoveOracleProposal:
+			return k.RemoveOracle(ctx, c.Oracle.String())
+		case ReplaceOracleProposal:
+			return k.ReplaceOracle(ctx, c.OldOracle.String(), c.NewOracle.String())
+		case AssetOraclesProposal:
+			var addresses []string
+			for _, oracle := range c.Oracles {
+				addresses = append(addresses, oracle.String())
+			}
+			return k.SetAssetOracles(ctx, c.AssetCode, addresses)
+		case AssetQuorumProposal:
+			return k.SetAssetQuorum(ctx, c.AssetCode, c.MinPosts, c.MinPostFraction)
+		default:
+			errMsg := fmt.Sprintf("unrecognized pricefeed proposal content type: %T", c)
+			return sdk.ErrUnknownRequest(errMsg)
+		}
+	}
+}
+
 // price feed questions:
 // do proposers need to post the round in the message? If not, how do we determine the round?
 
@@ -45,6 +71,5 @@ func EndBlocker(ctx sdk.Context, k Keeper) sdk.Tags {
 	// which seems preferable to having state storage values change in response to multiple transactions
 	// which occur during a block
 	//TODO use an iterator and update the prices for all assets in the store
-	k.SetCurrentPrices(ctx)
-	return sdk.Tags{}
+	return k.SetCurrentPrices(ctx)
 }
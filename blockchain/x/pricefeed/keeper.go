@@ -5,6 +5,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 // TODO refactor constants to app.go
@@ -32,36 +33,156 @@ const (
 
 	// Store Prefix for the assets in the pricefeed system
 	AssetPrefix = StoreKey + ":assets"
-
-	// OraclePrefix store prefix for the oracle accounts
-	OraclePrefix = StoreKey + ":oracles"
 )
 
 // Keeper struct for pricefeed module
 type Keeper struct {
-	storeKey  sdk.StoreKey
-	cdc       *codec.Codec
-	codespace sdk.CodespaceType
+	storeKey       sdk.StoreKey
+	cdc            *codec.Codec
+	paramsSubspace params.Subspace
+	codespace      sdk.CodespaceType
 }
 
 // NewKeeper returns a new keeper for the pricefeed modle
-func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, codespace sdk.CodespaceType) Keeper {
+func NewKeeper(storeKey sdk.StoreKey, cdc *codec.Codec, paramsSubspace params.Subspace, codespace sdk.CodespaceType) Keeper {
 	return Keeper{
-		storeKey:  storeKey,
-		cdc:       cdc,
-		codespace: codespace,
+		storeKey:       storeKey,
+		cdc:            cdc,
+		paramsSubspace: paramsSubspace.WithKeyTable(ParamKeyTable()),
+		codespace:      codespace,
 	}
 }
 
-// AddOracle adds an Oracle to the store
-func (k Keeper) AddOracle(ctx sdk.Context, address string) {
-
+// AddOracle adds an Oracle to the oracle list, recording the current block height
+func (k Keeper) AddOracle(ctx sdk.Context, address string) sdk.Error {
+	_, found := k.GetOracle(ctx, address)
+	if found {
+		return ErrDuplicateOracle(k.codespace)
+	}
 	oracles := k.GetOracles(ctx)
-	oracles = append(oracles, Oracle{OracleAddress: address})
+	oracles = append(oracles, Oracle{OracleAddress: address, AddedHeight: ctx.BlockHeight()})
+	k.setOracles(ctx, oracles)
+	return nil
+}
+
+// RemoveOracle removes an Oracle from the oracle list and the oracle lists of all assets, and deletes the prices it posted.
+// An asset's last oracle of its own can't be removed, as the asset would silently start using the global oracle list. Its oracles have to be set with SetAssetOracles first.
+func (k Keeper) RemoveOracle(ctx sdk.Context, address string) sdk.Error {
+	oracles, found := k.GetOracles(ctx).remove(address)
+	assets := k.GetAssets(ctx)
+	for i := range assets {
+		assetOracles, assetFound := assets[i].Oracles.remove(address)
+		if assetFound {
+			if len(assetOracles) == 0 {
+				return ErrLastAssetOracle(k.codespace)
+			}
+			assets[i].Oracles = assetOracles
+			found = true
+		}
+	}
+	if !found {
+		return ErrInvalidOracle(k.codespace)
+	}
+	k.setOracles(ctx, oracles)
+	k.setAssets(ctx, assets)
+	k.deleteUnauthorizedRawPrices(ctx)
+	return nil
+}
+
+// ReplaceOracle swaps an Oracle for a new one in the oracle list and the oracle lists of all assets, and deletes the prices the old one posted.
+// The new oracle keeps the old one's place in the lists, with the current block height as the height it was added.
+func (k Keeper) ReplaceOracle(ctx sdk.Context, oldAddress string, newAddress string) sdk.Error {
+	if k.isOracle(ctx, newAddress) {
+		return ErrDuplicateOracle(k.codespace)
+	}
+	newOracle := Oracle{OracleAddress: newAddress, AddedHeight: ctx.BlockHeight()}
+	oracles, found := k.GetOracles(ctx).replace(oldAddress, newOracle)
+	if found {
+		k.setOracles(ctx, oracles)
+	}
+	assets := k.GetAssets(ctx)
+	for i := range assets {
+		assetOracles, assetFound := assets[i].Oracles.replace(oldAddress, newOracle)
+		if assetFound {
+			assets[i].Oracles = assetOracles
+			found = true
+		}
+	}
+	if !found {
+		return ErrInvalidOracle(k.codespace)
+	}
+	k.setAssets(ctx, assets)
+	k.deleteUnauthorizedRawPrices(ctx)
+	return nil
+}
+
+// SetAssetOracles sets the oracles allowed to post prices for an asset, instead of the global oracle list, and deletes the prices posted by any other oracles.
+// Oracles already allowed for the asset keep the height they were added at. An empty list makes the asset use the global oracle list again.
+func (k Keeper) SetAssetOracles(ctx sdk.Context, assetCode string, addresses []string) sdk.Error {
+	assets := k.GetAssets(ctx)
+	for i := range assets {
+		if assets[i].AssetCode != assetCode {
+			continue
+		}
+		var oracles Oracles
+		for _, address := range addresses {
+			if _, found := oracles.get(address); found {
+				return ErrDuplicateOracle(k.codespace)
+			}
+			oracle, found := assets[i].Oracles.get(address)
+			if !found {
+				oracle = Oracle{OracleAddress: address, AddedHeight: ctx.BlockHeight()}
+			}
+			oracles = append(oracles, oracle)
+		}
+		assets[i].Oracles = oracles
+		k.setAssets(ctx, assets)
+		k.deleteUnauthorizedRawPrices(ctx)
+		return nil
+	}
+	return ErrInvalidAsset(k.codespace)
+}
+
+func (k Keeper) setOracles(ctx sdk.Context, oracles Oracles) {
+	k.paramsSubspace.Set(ctx, ParamStoreKeyOracleList, oracles)
+}
+
+// isOracle returns true if an address is in the oracle list or the oracle list of any asset
+func (k Keeper) isOracle(ctx sdk.Context, address string) bool {
+	if _, found := k.GetOracle(ctx, address); found {
+		return true
+	}
+	for _, asset := range k.GetAssets(ctx) {
+		if _, found := asset.Oracles.get(address); found {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteUnauthorizedRawPrices removes the prices posted by oracles that are no longer allowed to post prices for an asset, from the raw pricefeed of every asset
+func (k Keeper) deleteUnauthorizedRawPrices(ctx sdk.Context) {
 	store := ctx.KVStore(k.storeKey)
-	store.Set(
-		[]byte(OraclePrefix), k.cdc.MustMarshalBinaryBare(oracles),
-	)
+	for _, asset := range k.GetAssets(ctx) {
+		oracles := k.assetOracles(ctx, asset)
+		prices := k.GetRawPrices(ctx, asset.AssetCode)
+		var keptPrices []PostedPrice
+		for _, price := range prices {
+			if _, found := oracles.get(price.OracleAddress); found {
+				keptPrices = append(keptPrices, price)
+			}
+		}
+		if len(keptPrices) == len(prices) {
+			continue
+		}
+		if len(keptPrices) == 0 {
+			store.Delete([]byte(RawPriceFeedPrefix + asset.AssetCode))
+			continue
+		}
+		store.Set(
+			[]byte(RawPriceFeedPrefix+asset.AssetCode), k.cdc.MustMarshalBinaryBare(keptPrices),
+		)
+	}
 }
 
 // AddAsset adds an asset to the store
@@ -71,7 +192,39 @@ func (k Keeper) AddAsset(
 	desc string,
 ) {
 	assets := k.GetAssets(ctx)
-	assets = append(assets, Asset{AssetCode: assetCode, Description: desc})
+	assets = append(assets, Asset{AssetCode: assetCode, Description: desc, MinPostFraction: sdk.ZeroDec()})
+	k.setAssets(ctx, assets)
+}
+
+// SetAssetQuorum sets the minimum number of unexpired oracle prices, and the minimum fraction of the asset's oracles with unexpired prices, needed to update an asset's current price
+func (k Keeper) SetAssetQuorum(ctx sdk.Context, assetCode string, minPosts int, minPostFraction sdk.Dec) sdk.Error {
+	assets := k.GetAssets(ctx)
+	for i := range assets {
+		if assets[i].AssetCode == assetCode {
+			assets[i].MinPosts = minPosts
+			assets[i].MinPostFraction = minPostFraction
+			k.setAssets(ctx, assets)
+			return nil
+		}
+	}
+	return ErrInvalidAsset(k.codespace)
+}
+
+// quorum returns the number of unexpired oracle prices needed to update an asset's current price, which is always at least one
+func (k Keeper) quorum(ctx sdk.Context, asset Asset) int {
+	quorum := 1
+	if asset.MinPosts > quorum {
+		quorum = asset.MinPosts
+	}
+	// round up, so that the fraction of oracles is at least the min fraction
+	fractionQuorum := int(asset.MinPostFraction.MulInt64(int64(len(k.assetOracles(ctx, asset)))).Ceil().RoundInt64())
+	if fractionQuorum > quorum {
+		quorum = fractionQuorum
+	}
+	return quorum
+}
+
+func (k Keeper) setAssets(ctx sdk.Context, assets []Asset) {
 	store := ctx.KVStore(k.storeKey)
 	store.Set(
 		[]byte(AssetPrefix), k.cdc.MustMarshalBinaryBare(assets),
@@ -117,81 +270,122 @@ func (k Keeper) SetPrice(
 
 }
 
-// SetCurrentPrices updates the price of an asset to the meadian of all valid oracle inputs
-func (k Keeper) SetCurrentPrices(ctx sdk.Context) sdk.Error {
-	assets := k.GetAssets(ctx)
-	for _, v := range assets {
-		assetCode := v.AssetCode
-		prices := k.GetRawPrices(ctx, assetCode)
-		var notExpiredPrices []CurrentPrice
-		// filter out expired prices
-		for _, v := range prices {
-			if v.Expiry.GTE(sdk.NewInt(ctx.BlockHeight())) {
-				notExpiredPrices = append(notExpiredPrices, CurrentPrice{
-					AssetCode: v.AssetCode,
-					Price:     v.Price,
-					Expiry:    v.Expiry,
-				})
+// SetCurrentPrices updates the price of each asset to the median of all valid oracle inputs.
+// Each asset is computed on its own, so an asset without enough valid prices keeps its previous price, marked stale or missing, without affecting other assets.
+// It returns tags for the assets whose price status changed.
+func (k Keeper) SetCurrentPrices(ctx sdk.Context) sdk.Tags {
+	resTags := sdk.EmptyTags()
+	store := ctx.KVStore(k.storeKey)
+	for _, asset := range k.GetAssets(ctx) {
+		previous, found := k.getCurrentPrice(ctx, asset.AssetCode)
+		if !found {
+			previous = CurrentPrice{
+				AssetCode: asset.AssetCode,
+				Price:     sdk.ZeroDec(),
+				Expiry:    sdk.ZeroInt(),
 			}
 		}
-		l := len(notExpiredPrices)
-		var medianPrice sdk.Dec
-		var expiry sdk.Int
-		// TODO make threshold for acceptance (ie. require 51% of oracles to have posted valid prices
-		if l == 0 {
-			// Error if there are no valid prices in the raw pricefeed
-			return ErrNoValidPrice(k.codespace)
-		} else if l == 1 {
-			// Return immediately if there's only one price
-			medianPrice = notExpiredPrices[0].Price
-			expiry = notExpiredPrices[0].Expiry
-		} else {
-			// sort the prices
-			sort.Slice(notExpiredPrices, func(i, j int) bool {
-				return notExpiredPrices[i].Price.LT(notExpiredPrices[j].Price)
-			})
-			// If there's an even number of prices
-			if l%2 == 0 {
-				// TODO make sure this is safe.
-				// Since it's a price and not a blance, division with precision loss is OK.
-				price1 := notExpiredPrices[l/2-1].Price
-				price2 := notExpiredPrices[l/2].Price
-				sum := price1.Add(price2)
-				divsor, _ := sdk.NewDecFromStr("2")
-				medianPrice = sum.Quo(divsor)
-				// TODO Check if safe, makes sense
-				// Takes the average of the two expiries rounded down to the nearest Int.
-				expiry = notExpiredPrices[l/2-1].Expiry.Add(notExpiredPrices[l/2].Expiry).Quo(sdk.NewInt(2))
-			} else {
-				// integer division, so we'll get an integer back, rounded down
-				medianPrice = notExpiredPrices[l/2].Price
-				expiry = notExpiredPrices[l/2].Expiry
-			}
+		currentPrice := k.calculateCurrentPrice(ctx, asset, previous)
+		store.Set(
+			[]byte(CurrentPricePrefix+asset.AssetCode), k.cdc.MustMarshalBinaryBare(currentPrice),
+		)
+		if currentPrice.Status != previous.Status {
+			// tag the asset and status together, so indexers can match each status to its asset
+			resTags = resTags.AppendTag(TagPriceStatus, asset.AssetCode+":"+currentPrice.Status)
 		}
+	}
+	return resTags
+}
 
-		store := ctx.KVStore(k.storeKey)
-		currentPrice := CurrentPrice{
-			AssetCode: assetCode,
-			Price:     medianPrice,
-			Expiry:    expiry,
+// calculateCurrentPrice returns the new current price of an asset, or its previous price marked stale or missing if there aren't enough valid prices
+func (k Keeper) calculateCurrentPrice(ctx sdk.Context, asset Asset, previous CurrentPrice) CurrentPrice {
+	oracles := k.assetOracles(ctx, asset)
+	prices := k.GetRawPrices(ctx, asset.AssetCode)
+	var notExpiredPrices []CurrentPrice
+	// filter out expired prices, and prices from oracles not allowed to post for this asset
+	for _, v := range prices {
+		if _, found := oracles.get(v.OracleAddress); !found {
+			continue
+		}
+		if v.Expiry.GTE(sdk.NewInt(ctx.BlockHeight())) {
+			notExpiredPrices = append(notExpiredPrices, CurrentPrice{
+				AssetCode: v.AssetCode,
+				Price:     v.Price,
+				Expiry:    v.Expiry,
+			})
+		}
+	}
+	l := len(notExpiredPrices)
+	var medianPrice sdk.Dec
+	var expiry sdk.Int
+	if l == 0 {
+		// Keep the previous price, marked as missing, if there are no valid prices in the raw pricefeed
+		previous.Status = PriceMissing
+		return previous
+	} else if l < k.quorum(ctx, asset) {
+		// Keep the previous price, marked as stale, until enough oracles have posted prices
+		previous.Status = PriceStale
+		return previous
+	} else if l == 1 {
+		// Return immediately if there's only one price
+		medianPrice = notExpiredPrices[0].Price
+		expiry = notExpiredPrices[0].Expiry
+	} else {
+		// sort the prices
+		sort.Slice(notExpiredPrices, func(i, j int) bool {
+			return notExpiredPrices[i].Price.LT(notExpiredPrices[j].Price)
+		})
+		// If there's an even number of prices
+		if l%2 == 0 {
+			// TODO make sure this is safe.
+			// Since it's a price and not a blance, division with precision loss is OK.
+			price1 := notExpiredPrices[l/2-1].Price
+			price2 := notExpiredPrices[l/2].Price
+			sum := price1.Add(price2)
+			divsor, _ := sdk.NewDecFromStr("2")
+			medianPrice = sum.Quo(divsor)
+			// TODO Check if safe, makes sense
+			// Takes the average of the two expiries rounded down to the nearest Int.
+			expiry = notExpiredPrices[l/2-1].Expiry.Add(notExpiredPrices[l/2].Expiry).Quo(sdk.NewInt(2))
+		} else {
+			// integer division, so we'll get an integer back, rounded down
+			medianPrice = notExpiredPrices[l/2].Price
+			expiry = notExpiredPrices[l/2].Expiry
 		}
-		store.Set(
-			[]byte(CurrentPricePrefix+assetCode), k.cdc.MustMarshalBinaryBare(currentPrice),
-		)
 	}
 
-	return nil
+	return CurrentPrice{
+		AssetCode:     asset.AssetCode,
+		Price:         medianPrice,
+		Expiry:        expiry,
+		Status:        PriceFresh,
+		UpdatedHeight: ctx.BlockHeight(),
+	}
 }
 
-// GetOracles returns the oracles in the pricefeed store
-func (k Keeper) GetOracles(ctx sdk.Context) []Oracle {
-	store := ctx.KVStore(k.storeKey)
-	bz := store.Get([]byte(OraclePrefix))
-	var oracles []Oracle
-	k.cdc.MustUnmarshalBinaryBare(bz, &oracles)
+// GetOracles returns the oracle list from the params store
+func (k Keeper) GetOracles(ctx sdk.Context) Oracles {
+	var oracles Oracles
+	k.paramsSubspace.GetIfExists(ctx, ParamStoreKeyOracleList, &oracles)
 	return oracles
 }
 
+// GetAssetOracles returns the oracles allowed to post prices for an asset, which is the global oracle list unless the asset has its own
+func (k Keeper) GetAssetOracles(ctx sdk.Context, assetCode string) Oracles {
+	asset, found := k.GetAsset(ctx, assetCode)
+	if !found {
+		return nil
+	}
+	return k.assetOracles(ctx, asset)
+}
+
+func (k Keeper) assetOracles(ctx sdk.Context, asset Asset) Oracles {
+	if len(asset.Oracles) != 0 {
+		return asset.Oracles
+	}
+	return k.GetOracles(ctx)
+}
+
 // GetAssets returns the assets in the pricefeed store
 func (k Keeper) GetAssets(ctx sdk.Context) []Asset {
 	store := ctx.KVStore(k.storeKey)
@@ -237,6 +431,18 @@ func (k Keeper) GetCurrentPrice(ctx sdk.Context, assetCode string) CurrentPrice
 	return price
 }
 
+// getCurrentPrice fetches the current price of an asset, returning false if it has never been set
+func (k Keeper) getCurrentPrice(ctx sdk.Context, assetCode string) (CurrentPrice, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get([]byte(CurrentPricePrefix + assetCode))
+	if bz == nil {
+		return CurrentPrice{}, false
+	}
+	var price CurrentPrice
+	k.cdc.MustUnmarshalBinaryBare(bz, &price)
+	return price, true
+}
+
 // GetRawPrices fetches the set of all prices posted by oracles for an asset
 func (k Keeper) GetRawPrices(ctx sdk.Context, assetCode string) []PostedPrice {
 	store := ctx.KVStore(k.storeKey)
@@ -246,15 +452,15 @@ func (k Keeper) GetRawPrices(ctx sdk.Context, assetCode string) []PostedPrice {
 	return prices
 }
 
-// ValidatePostPrice makes sure the person posting the price is an oracle
+// ValidatePostPrice makes sure the person posting the price is an oracle allowed to post prices for the asset
 func (k Keeper) ValidatePostPrice(ctx sdk.Context, msg MsgPostPrice) sdk.Error {
 	// TODO implement this
 
-	_, assetFound := k.GetAsset(ctx, msg.AssetCode)
+	asset, assetFound := k.GetAsset(ctx, msg.AssetCode)
 	if !assetFound {
 		return ErrInvalidAsset(k.codespace)
 	}
-	_, oracleFound := k.GetOracle(ctx, msg.From.String())
+	_, oracleFound := k.assetOracles(ctx, asset).get(msg.From.String())
 	if !oracleFound {
 		return ErrInvalidOracle(k.codespace)
 	}
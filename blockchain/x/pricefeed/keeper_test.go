@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -78,6 +79,9 @@ func TestKeeper_GetSetCurrentPrice(t *testing.T) {
 	ctx := helper.mApp.BaseApp.NewContext(false, abci.Header{})
 	// Odd number of oracles
 	helper.keeper.AddAsset(ctx, "tst", "test asset")
+	for _, addr := range helper.addrs {
+		require.NoError(t, helper.keeper.AddOracle(ctx, addr.String()))
+	}
 	helper.keeper.SetPrice(
 		ctx, helper.addrs[0], "tst",
 		sdk.MustNewDecFromStr("0.33"),
@@ -91,8 +95,7 @@ func TestKeeper_GetSetCurrentPrice(t *testing.T) {
 		sdk.MustNewDecFromStr("0.34"),
 		sdk.NewInt(10))
 	// Set current price
-	err := helper.keeper.SetCurrentPrices(ctx)
-	require.NoError(t, err)
+	helper.keeper.SetCurrentPrices(ctx)
 	// Get Current price
 	price := helper.keeper.GetCurrentPrice(ctx, "tst")
 	require.Equal(t, price.Price.Equal(sdk.MustNewDecFromStr("0.34")), true)
@@ -102,9 +105,225 @@ func TestKeeper_GetSetCurrentPrice(t *testing.T) {
 		ctx, helper.addrs[3], "tst",
 		sdk.MustNewDecFromStr("0.36"),
 		sdk.NewInt(10))
-	err = helper.keeper.SetCurrentPrices(ctx)
-	require.NoError(t, err)
+	helper.keeper.SetCurrentPrices(ctx)
 	price = helper.keeper.GetCurrentPrice(ctx, "tst")
 	require.Equal(t, price.Price.Equal(sdk.MustNewDecFromStr("0.345")), true)
 
 }
+
+// TestKeeper_AddRemoveReplaceOracle tests changing the oracle list with proposals, and that removed oracles' prices are deleted
+func TestKeeper_AddRemoveReplaceOracle(t *testing.T) {
+	helper := getMockApp(t, 3, GenesisState{}, nil)
+	header := abci.Header{Height: helper.mApp.LastBlockHeight() + 1}
+	helper.mApp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := helper.mApp.BaseApp.NewContext(false, abci.Header{Height: 5})
+	handler := NewProposalHandler(helper.keeper)
+	helper.keeper.AddAsset(ctx, "tst", "test asset")
+	helper.keeper.AddAsset(ctx, "tst2", "2nd test asset")
+
+	// Add oracles
+	require.NoError(t, handler(ctx, NewAddOracleProposal("title", "description", helper.addrs[0])))
+	require.NoError(t, handler(ctx, NewAddOracleProposal("title", "description", helper.addrs[1])))
+	require.Error(t, handler(ctx, NewAddOracleProposal("title", "description", helper.addrs[1])))
+	require.Equal(t, Oracles{{helper.addrs[0].String(), 5}, {helper.addrs[1].String(), 5}}, helper.keeper.GetOracles(ctx))
+
+	// Post prices from both oracles
+	for _, assetCode := range []string{"tst", "tst2"} {
+		for _, addr := range helper.addrs[:2] {
+			require.NoError(t, helper.keeper.ValidatePostPrice(ctx, NewMsgPostPrice(addr, assetCode, sdk.MustNewDecFromStr("0.33"), sdk.NewInt(10))))
+			_, err := helper.keeper.SetPrice(ctx, addr, assetCode, sdk.MustNewDecFromStr("0.33"), sdk.NewInt(10))
+			require.NoError(t, err)
+		}
+	}
+
+	// Remove the first oracle, its prices are deleted
+	require.NoError(t, handler(ctx, NewRemoveOracleProposal("title", "description", helper.addrs[0])))
+	require.Error(t, handler(ctx, NewRemoveOracleProposal("title", "description", helper.addrs[0])))
+	require.Equal(t, Oracles{{helper.addrs[1].String(), 5}}, helper.keeper.GetOracles(ctx))
+	require.Error(t, helper.keeper.ValidatePostPrice(ctx, NewMsgPostPrice(helper.addrs[0], "tst", sdk.MustNewDecFromStr("0.33"), sdk.NewInt(10))))
+	for _, assetCode := range []string{"tst", "tst2"} {
+		rawPrices := helper.keeper.GetRawPrices(ctx, assetCode)
+		require.Equal(t, 1, len(rawPrices))
+		require.Equal(t, helper.addrs[1].String(), rawPrices[0].OracleAddress)
+	}
+
+	// Replace the second oracle
+	ctx = ctx.WithBlockHeight(8)
+	require.Error(t, handler(ctx, NewReplaceOracleProposal("title", "description", helper.addrs[0], helper.addrs[2])))
+	require.NoError(t, handler(ctx, NewReplaceOracleProposal("title", "description", helper.addrs[1], helper.addrs[2])))
+	require.Equal(t, Oracles{{helper.addrs[2].String(), 8}}, helper.keeper.GetOracles(ctx))
+	require.Equal(t, 0, len(helper.keeper.GetRawPrices(ctx, "tst")))
+	require.Equal(t, 0, len(helper.keeper.GetRawPrices(ctx, "tst2")))
+}
+
+// TestKeeper_AssetOracles tests that an asset's own oracles replace the global oracle list for posting prices and computing the median
+func TestKeeper_AssetOracles(t *testing.T) {
+	helper := getMockApp(t, 3, GenesisState{}, nil)
+	header := abci.Header{Height: helper.mApp.LastBlockHeight() + 1}
+	helper.mApp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := helper.mApp.BaseApp.NewContext(false, abci.Header{Height: 5})
+	handler := NewProposalHandler(helper.keeper)
+	helper.keeper.AddAsset(ctx, "btc", "test asset")
+	helper.keeper.AddAsset(ctx, "xrp", "2nd test asset")
+	require.NoError(t, helper.keeper.AddOracle(ctx, helper.addrs[0].String()))
+	require.NoError(t, helper.keeper.AddOracle(ctx, helper.addrs[1].String()))
+	helper.keeper.SetPrice(ctx, helper.addrs[0], "xrp", sdk.MustNewDecFromStr("0.30"), sdk.NewInt(10))
+
+	// Give xrp its own oracle, removing the global oracle's xrp price
+	require.Error(t, handler(ctx, NewAssetOraclesProposal("title", "description", "nan", []sdk.AccAddress{helper.addrs[2]})))
+	require.NoError(t, handler(ctx, NewAssetOraclesProposal("title", "description", "xrp", []sdk.AccAddress{helper.addrs[2]})))
+	require.Equal(t, Oracles{{helper.addrs[2].String(), 5}}, helper.keeper.GetAssetOracles(ctx, "xrp"))
+	require.Equal(t, helper.keeper.GetOracles(ctx), helper.keeper.GetAssetOracles(ctx, "btc"))
+	require.Equal(t, 0, len(helper.keeper.GetRawPrices(ctx, "xrp")))
+
+	// Only xrp's oracle can post xrp prices, and it can't post btc prices
+	require.NoError(t, helper.keeper.ValidatePostPrice(ctx, NewMsgPostPrice(helper.addrs[2], "xrp", sdk.MustNewDecFromStr("0.33"), sdk.NewInt(10))))
+	require.Error(t, helper.keeper.ValidatePostPrice(ctx, NewMsgPostPrice(helper.addrs[0], "xrp", sdk.MustNewDecFromStr("0.33"), sdk.NewInt(10))))
+	require.Error(t, helper.keeper.ValidatePostPrice(ctx, NewMsgPostPrice(helper.addrs[2], "btc", sdk.MustNewDecFromStr("9000"), sdk.NewInt(10))))
+
+	// The median ignores prices from oracles not allowed for the asset
+	helper.keeper.SetPrice(ctx, helper.addrs[0], "btc", sdk.MustNewDecFromStr("8000"), sdk.NewInt(10))
+	helper.keeper.SetPrice(ctx, helper.addrs[2], "btc", sdk.MustNewDecFromStr("9000"), sdk.NewInt(10))
+	helper.keeper.SetPrice(ctx, helper.addrs[2], "xrp", sdk.MustNewDecFromStr("0.33"), sdk.NewInt(10))
+	helper.keeper.SetCurrentPrices(ctx)
+	require.Equal(t, sdk.MustNewDecFromStr("8000"), helper.keeper.GetCurrentPrice(ctx, "btc").Price)
+	require.Equal(t, sdk.MustNewDecFromStr("0.33"), helper.keeper.GetCurrentPrice(ctx, "xrp").Price)
+
+	// xrp's only oracle can't be removed until xrp is explicitly reset to the global oracle list
+	require.Error(t, handler(ctx, NewRemoveOracleProposal("title", "description", helper.addrs[2])))
+	require.Equal(t, Oracles{{helper.addrs[2].String(), 5}}, helper.keeper.GetAssetOracles(ctx, "xrp"))
+	require.Equal(t, 1, len(helper.keeper.GetRawPrices(ctx, "xrp")))
+	require.NoError(t, handler(ctx, NewAssetOraclesProposal("title", "description", "xrp", nil)))
+	require.Equal(t, helper.keeper.GetOracles(ctx), helper.keeper.GetAssetOracles(ctx, "xrp"))
+	require.NoError(t, helper.keeper.ValidatePostPrice(ctx, NewMsgPostPrice(helper.addrs[0], "xrp", sdk.MustNewDecFromStr("0.33"), sdk.NewInt(10))))
+	require.Equal(t, 0, len(helper.keeper.GetRawPrices(ctx, "xrp")))
+}
+
+// TestKeeper_AssetQuorum tests the current price is only updated when enough oracles have posted prices, and is otherwise kept and marked stale or missing
+func TestKeeper_AssetQuorum(t *testing.T) {
+	helper := getMockApp(t, 3, GenesisState{}, nil)
+	header := abci.Header{Height: helper.mApp.LastBlockHeight() + 1}
+	helper.mApp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := helper.mApp.BaseApp.NewContext(false, abci.Header{Height: 5})
+	handler := NewProposalHandler(helper.keeper)
+	helper.keeper.AddAsset(ctx, "tst", "test asset")
+	for _, addr := range helper.addrs {
+		require.NoError(t, helper.keeper.AddOracle(ctx, addr.String()))
+	}
+	// a majority of 3 oracles is 2
+	require.Error(t, handler(ctx, NewAssetQuorumProposal("title", "description", "nan", 0, sdk.MustNewDecFromStr("0.51"))))
+	require.NoError(t, handler(ctx, NewAssetQuorumProposal("title", "description", "tst", 0, sdk.MustNewDecFromStr("0.51"))))
+
+	// Without quorum and without a previous price, the current price is zero and stale
+	helper.keeper.SetPrice(ctx, helper.addrs[0], "tst", sdk.MustNewDecFromStr("0.33"), sdk.NewInt(10))
+	helper.keeper.SetCurrentPrices(ctx)
+	price := helper.keeper.GetCurrentPrice(ctx, "tst")
+	require.True(t, price.Price.IsZero())
+	require.Equal(t, PriceStale, price.Status)
+
+	// With quorum the price is updated
+	helper.keeper.SetPrice(ctx, helper.addrs[1], "tst", sdk.MustNewDecFromStr("0.35"), sdk.NewInt(6))
+	helper.keeper.SetCurrentPrices(ctx)
+	price = helper.keeper.GetCurrentPrice(ctx, "tst")
+	require.Equal(t, sdk.MustNewDecFromStr("0.34"), price.Price)
+	require.Equal(t, PriceFresh, price.Status)
+	require.Equal(t, int64(5), price.UpdatedHeight)
+
+	// When a price expires quorum is lost, so the previous price is kept and marked stale
+	ctx = ctx.WithBlockHeight(7)
+	helper.keeper.SetCurrentPrices(ctx)
+	price = helper.keeper.GetCurrentPrice(ctx, "tst")
+	require.Equal(t, sdk.MustNewDecFromStr("0.34"), price.Price)
+	require.Equal(t, PriceStale, price.Status)
+	require.Equal(t, int64(5), price.UpdatedHeight)
+
+	// A min number of posts above the fraction also has to be met
+	helper.keeper.SetPrice(ctx, helper.addrs[1], "tst", sdk.MustNewDecFromStr("0.37"), sdk.NewInt(10))
+	require.NoError(t, helper.keeper.SetAssetQuorum(ctx, "tst", 3, sdk.MustNewDecFromStr("0.51")))
+	helper.keeper.SetCurrentPrices(ctx)
+	require.Equal(t, PriceStale, helper.keeper.GetCurrentPrice(ctx, "tst").Status)
+	helper.keeper.SetPrice(ctx, helper.addrs[2], "tst", sdk.MustNewDecFromStr("0.36"), sdk.NewInt(10))
+	helper.keeper.SetCurrentPrices(ctx)
+	price = helper.keeper.GetCurrentPrice(ctx, "tst")
+	require.Equal(t, sdk.MustNewDecFromStr("0.36"), price.Price)
+	require.Equal(t, PriceFresh, price.Status)
+	require.Equal(t, int64(7), price.UpdatedHeight)
+}
+
+// TestKeeper_CurrentPriceStatus tests an asset without valid prices doesn't stop other assets' prices updating, and that status changes are tagged
+func TestKeeper_CurrentPriceStatus(t *testing.T) {
+	helper := getMockApp(t, 1, GenesisState{}, nil)
+	header := abci.Header{Height: helper.mApp.LastBlockHeight() + 1}
+	helper.mApp.BeginBlock(abci.RequestBeginBlock{Header: header})
+	ctx := helper.mApp.BaseApp.NewContext(false, abci.Header{Height: 5})
+	helper.keeper.AddAsset(ctx, "btc", "bitcoin")
+	helper.keeper.AddAsset(ctx, "xrp", "ripple")
+	require.NoError(t, helper.keeper.AddOracle(ctx, helper.addrs[0].String()))
+
+	// Both assets have prices
+	helper.keeper.SetPrice(ctx, helper.addrs[0], "btc", sdk.MustNewDecFromStr("8000"), sdk.NewInt(10))
+	helper.keeper.SetPrice(ctx, helper.addrs[0], "xrp", sdk.MustNewDecFromStr("0.33"), sdk.NewInt(6))
+	tags := helper.keeper.SetCurrentPrices(ctx)
+	require.Equal(t, sdk.NewTags(
+		TagPriceStatus, "btc:"+PriceFresh,
+		TagPriceStatus, "xrp:"+PriceFresh,
+	), tags)
+
+	// Unchanged statuses aren't tagged
+	helper.keeper.SetPrice(ctx, helper.addrs[0], "btc", sdk.MustNewDecFromStr("8500"), sdk.NewInt(10))
+	require.Equal(t, sdk.EmptyTags(), helper.keeper.SetCurrentPrices(ctx))
+
+	// The xrp price expires, which doesn't stop the btc price updating
+	ctx = ctx.WithBlockHeight(7)
+	helper.keeper.SetPrice(ctx, helper.addrs[0], "btc", sdk.MustNewDecFromStr("9000"), sdk.NewInt(10))
+	tags = helper.keeper.SetCurrentPrices(ctx)
+	require.Equal(t, sdk.NewTags(TagPriceStatus, "xrp:"+PriceMissing), tags)
+	xrpPrice := helper.keeper.GetCurrentPrice(ctx, "xrp")
+	require.Equal(t, sdk.MustNewDecFromStr("0.33"), xrpPrice.Price)
+	require.Equal(t, PriceMissing, xrpPrice.Status)
+	require.Equal(t, int64(5), xrpPrice.UpdatedHeight)
+	btcPrice := helper.keeper.GetCurrentPrice(ctx, "btc")
+	require.Equal(t, sdk.MustNewDecFromStr("9000"), btcPrice.Price)
+	require.Equal(t, PriceFresh, btcPrice.Status)
+	require.Equal(t, int64(7), btcPrice.UpdatedHeight)
+}
+
+func TestOracleProposals_ValidateBasic(t *testing.T) {
+	addr1 := sdk.AccAddress([]byte("someName"))
+	addr2 := sdk.AccAddress([]byte("anotherName"))
+	tests := []struct {
+		name       string
+		proposal   gov.Content
+		expectPass bool
+	}{
+		{"add", NewAddOracleProposal("title", "description", addr1), true},
+		{"addEmptyAddr", NewAddOracleProposal("title", "description", sdk.AccAddress{}), false},
+		{"addNoTitle", NewAddOracleProposal("", "description", addr1), false},
+		{"remove", NewRemoveOracleProposal("title", "description", addr1), true},
+		{"removeEmptyAddr", NewRemoveOracleProposal("title", "description", sdk.AccAddress{}), false},
+		{"replace", NewReplaceOracleProposal("title", "description", addr1, addr2), true},
+		{"replaceEmptyNewAddr", NewReplaceOracleProposal("title", "description", addr1, sdk.AccAddress{}), false},
+		{"replaceSameAddr", NewReplaceOracleProposal("title", "description", addr1, addr1), false},
+		{"assetOracles", NewAssetOraclesProposal("title", "description", "xrp", []sdk.AccAddress{addr1, addr2}), true},
+		{"assetOraclesEmptyList", NewAssetOraclesProposal("title", "description", "xrp", nil), true},
+		{"assetOraclesNoAsset", NewAssetOraclesProposal("title", "description", "", []sdk.AccAddress{addr1}), false},
+		{"assetOraclesRepeated", NewAssetOraclesProposal("title", "description", "xrp", []sdk.AccAddress{addr1, addr1}), false},
+		{"assetQuorum", NewAssetQuorumProposal("title", "description", "xrp", 2, sdk.MustNewDecFromStr("0.51")), true},
+		{"assetQuorumNoFraction", NewAssetQuorumProposal("title", "description", "xrp", 2, sdk.Dec{}), false},
+		{"assetQuorumNegativePosts", NewAssetQuorumProposal("title", "description", "xrp", -1, sdk.ZeroDec()), false},
+		{"assetQuorumFractionAboveOne", NewAssetQuorumProposal("title", "description", "xrp", 0, sdk.MustNewDecFromStr("1.5")), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectPass {
+				require.Nil(t, tc.proposal.ValidateBasic())
+			} else {
+				require.NotNil(t, tc.proposal.ValidateBasic())
+			}
+		})
+	}
+	// invalid quorums are reported as bad input
+	err := NewAssetQuorumProposal("title", "description", "xrp", -1, sdk.ZeroDec()).ValidateBasic()
+	require.Equal(t, DefaultCodespace, err.Codespace())
+	require.Equal(t, CodeInvalidQuorum, err.Code())
+}
@@ -8,7 +8,7 @@ var ParamStoreKeyOracleList = []byte("oraclelist")
 // ParamKeyTable keytable
 func ParamKeyTable() params.KeyTable {
 	return params.NewKeyTable(
-		ParamStoreKeyOracleList, []string{},
+		ParamStoreKeyOracleList, Oracles{},
 	)
 }
 
@@ -17,8 +17,12 @@ Keys:								Values:
 pricefeed						N/A (top level prefix)
 pricefeed:raw:x 		[]PostedPrice{AssetCode: string, OracleAddress: string, Price: sdk.Dec, Expiry: sdk.Int}
 pricefeed:current:x CurrentPrice{AssetCode: string, Price: sdk.Dec, Expiry: sdk.Int}
-pricefeed:oracles:x []Oracle{OracleAddress: string}
-pricefeed:assets 		[]Asset{AssetCode:string, Description: string}
+pricefeed:assets 		[]Asset{AssetCode:string, Description: string, Oracles: []Oracle}
+
+The oracles are stored in the params store, under the "pricefeed" subspace:
+oraclelist					[]Oracle{OracleAddress: string, AddedHeight: int64}
+They are changed by governance with the AddOracleProposal, RemoveOracleProposal and ReplaceOracleProposal, which also delete the raw prices of removed oracles.
+An asset with its own oracles (set with the AssetOraclesProposal) only accepts prices from them, otherwise it accepts prices from the oracle list.
 
 To update the price for a particular oracle after they have made a MsgPostPrice transaction:
 prices := keeper.GetPrices(AssetCode)
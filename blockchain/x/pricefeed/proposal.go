@@ -0,0 +1,283 @@
+package pricefeed
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+const (
+	ProposalTypeAddOracle     = "AddOracle"
+	ProposalTypeRemoveOracle  = "RemoveOracle"
+	ProposalTypeReplaceOracle = "ReplaceOracle"
+	ProposalTypeAssetOracles  = "AssetOracles"
+	ProposalTypeAssetQuorum   = "AssetQuorum"
+)
+
+var _ gov.Content = AddOracleProposal{}
+var _ gov.Content = RemoveOracleProposal{}
+var _ gov.Content = ReplaceOracleProposal{}
+var _ gov.Content = AssetOraclesProposal{}
+var _ gov.Content = AssetQuorumProposal{}
+
+func init() {
+	gov.RegisterProposalType(ProposalTypeAddOracle)
+	gov.RegisterProposalTypeCodec(AddOracleProposal{}, "pricefeed/AddOracleProposal")
+	gov.RegisterProposalType(ProposalTypeRemoveOracle)
+	gov.RegisterProposalTypeCodec(RemoveOracleProposal{}, "pricefeed/RemoveOracleProposal")
+	gov.RegisterProposalType(ProposalTypeReplaceOracle)
+	gov.RegisterProposalTypeCodec(ReplaceOracleProposal{}, "pricefeed/ReplaceOracleProposal")
+	gov.RegisterProposalType(ProposalTypeAssetOracles)
+	gov.RegisterProposalTypeCodec(AssetOraclesProposal{}, "pricefeed/AssetOraclesProposal")
+	gov.RegisterProposalType(ProposalTypeAssetQuorum)
+	gov.RegisterProposalTypeCodec(AssetQuorumProposal{}, "pricefeed/AssetQuorumProposal")
+}
+
+// AddOracleProposal adds an oracle to the oracle list, allowing it to post prices for any asset without its own oracles.
+type AddOracleProposal struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Oracle      sdk.AccAddress `json:"oracle"`
+}
+
+// NewAddOracleProposal creates a new AddOracleProposal
+func NewAddOracleProposal(title, description string, oracle sdk.AccAddress) AddOracleProposal {
+	return AddOracleProposal{title, description, oracle}
+}
+
+// GetTitle implements gov.Content
+func (p AddOracleProposal) GetTitle() string { return p.Title }
+
+// GetDescription implements gov.Content
+func (p AddOracleProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute implements gov.Content
+func (p AddOracleProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType implements gov.Content
+func (p AddOracleProposal) ProposalType() string { return ProposalTypeAddOracle }
+
+// ValidateBasic implements gov.Content
+func (p AddOracleProposal) ValidateBasic() sdk.Error {
+	err := gov.ValidateAbstract(DefaultCodespace, p)
+	if err != nil {
+		return err
+	}
+	if p.Oracle.Empty() {
+		return sdk.ErrInvalidAddress("oracle address cannot be empty")
+	}
+	return nil
+}
+
+func (p AddOracleProposal) String() string {
+	return fmt.Sprintf(`Add Oracle Proposal:
+  Title:       %s
+  Description: %s
+  Oracle:      %s
+`, p.Title, p.Description, p.Oracle)
+}
+
+// RemoveOracleProposal removes an oracle from the oracle list and the oracles of every asset, and deletes the prices it has posted.
+// It is used to revoke an oracle, for example if its key is compromised.
+type RemoveOracleProposal struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Oracle      sdk.AccAddress `json:"oracle"`
+}
+
+// NewRemoveOracleProposal creates a new RemoveOracleProposal
+func NewRemoveOracleProposal(title, description string, oracle sdk.AccAddress) RemoveOracleProposal {
+	return RemoveOracleProposal{title, description, oracle}
+}
+
+// GetTitle implements gov.Content
+func (p RemoveOracleProposal) GetTitle() string { return p.Title }
+
+// GetDescription implements gov.Content
+func (p RemoveOracleProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute implements gov.Content
+func (p RemoveOracleProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType implements gov.Content
+func (p RemoveOracleProposal) ProposalType() string { return ProposalTypeRemoveOracle }
+
+// ValidateBasic implements gov.Content
+func (p RemoveOracleProposal) ValidateBasic() sdk.Error {
+	err := gov.ValidateAbstract(DefaultCodespace, p)
+	if err != nil {
+		return err
+	}
+	if p.Oracle.Empty() {
+		return sdk.ErrInvalidAddress("oracle address cannot be empty")
+	}
+	return nil
+}
+
+func (p RemoveOracleProposal) String() string {
+	return fmt.Sprintf(`Remove Oracle Proposal:
+  Title:       %s
+  Description: %s
+  Oracle:      %s
+`, p.Title, p.Description, p.Oracle)
+}
+
+// ReplaceOracleProposal swaps an oracle for a new one in one step, for example to rotate an oracle's key.
+// The prices posted by the old oracle are deleted.
+type ReplaceOracleProposal struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	OldOracle   sdk.AccAddress `json:"old_oracle"`
+	NewOracle   sdk.AccAddress `json:"new_oracle"`
+}
+
+// NewReplaceOracleProposal creates a new ReplaceOracleProposal
+func NewReplaceOracleProposal(title, description string, oldOracle, newOracle sdk.AccAddress) ReplaceOracleProposal {
+	return ReplaceOracleProposal{title, description, oldOracle, newOracle}
+}
+
+// GetTitle implements gov.Content
+func (p ReplaceOracleProposal) GetTitle() string { return p.Title }
+
+// GetDescription implements gov.Content
+func (p ReplaceOracleProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute implements gov.Content
+func (p ReplaceOracleProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType implements gov.Content
+func (p ReplaceOracleProposal) ProposalType() string { return ProposalTypeReplaceOracle }
+
+// ValidateBasic implements gov.Content
+func (p ReplaceOracleProposal) ValidateBasic() sdk.Error {
+	err := gov.ValidateAbstract(DefaultCodespace, p)
+	if err != nil {
+		return err
+	}
+	if p.OldOracle.Empty() || p.NewOracle.Empty() {
+		return sdk.ErrInvalidAddress("oracle address cannot be empty")
+	}
+	if p.OldOracle.Equals(p.NewOracle) {
+		return ErrDuplicateOracle(DefaultCodespace)
+	}
+	return nil
+}
+
+func (p ReplaceOracleProposal) String() string {
+	return fmt.Sprintf(`Replace Oracle Proposal:
+  Title:       %s
+  Description: %s
+  Old Oracle:  %s
+  New Oracle:  %s
+`, p.Title, p.Description, p.OldOracle, p.NewOracle)
+}
+
+// AssetOraclesProposal sets the oracles allowed to post prices for one asset, instead of the global oracle list.
+// Prices posted for the asset by any other oracles are deleted. An empty list makes the asset use the global oracle list again.
+type AssetOraclesProposal struct {
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	AssetCode   string           `json:"asset_code"`
+	Oracles     []sdk.AccAddress `json:"oracles"`
+}
+
+// NewAssetOraclesProposal creates a new AssetOraclesProposal
+func NewAssetOraclesProposal(title, description string, assetCode string, oracles []sdk.AccAddress) AssetOraclesProposal {
+	return AssetOraclesProposal{title, description, assetCode, oracles}
+}
+
+// GetTitle implements gov.Content
+func (p AssetOraclesProposal) GetTitle() string { return p.Title }
+
+// GetDescription implements gov.Content
+func (p AssetOraclesProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute implements gov.Content
+func (p AssetOraclesProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType implements gov.Content
+func (p AssetOraclesProposal) ProposalType() string { return ProposalTypeAssetOracles }
+
+// ValidateBasic implements gov.Content
+func (p AssetOraclesProposal) ValidateBasic() sdk.Error {
+	err := gov.ValidateAbstract(DefaultCodespace, p)
+	if err != nil {
+		return err
+	}
+	if len(p.AssetCode) == 0 {
+		return ErrEmptyInput(DefaultCodespace)
+	}
+	seen := make(map[string]bool)
+	for _, oracle := range p.Oracles {
+		if oracle.Empty() {
+			return sdk.ErrInvalidAddress("oracle address cannot be empty")
+		}
+		if seen[oracle.String()] {
+			return ErrDuplicateOracle(DefaultCodespace)
+		}
+		seen[oracle.String()] = true
+	}
+	return nil
+}
+
+func (p AssetOraclesProposal) String() string {
+	return fmt.Sprintf(`Asset Oracles Proposal:
+  Title:       %s
+  Description: %s
+  Asset Code:  %s
+  Oracles:     %v
+`, p.Title, p.Description, p.AssetCode, p.Oracles)
+}
+
+// AssetQuorumProposal sets how many oracles must have unexpired prices for an asset to update its current price.
+// Both the minimum number of prices and the minimum fraction of the asset's oracles must be met, otherwise the previous price is kept and marked stale.
+type AssetQuorumProposal struct {
+	Title           string  `json:"title"`
+	Description     string  `json:"description"`
+	AssetCode       string  `json:"asset_code"`
+	MinPosts        int     `json:"min_posts"`
+	MinPostFraction sdk.Dec `json:"min_post_fraction"`
+}
+
+// NewAssetQuorumProposal creates a new AssetQuorumProposal
+func NewAssetQuorumProposal(title, description string, assetCode string, minPosts int, minPostFraction sdk.Dec) AssetQuorumProposal {
+	return AssetQuorumProposal{title, description, assetCode, minPosts, minPostFraction}
+}
+
+// GetTitle implements gov.Content
+func (p AssetQuorumProposal) GetTitle() string { return p.Title }
+
+// GetDescription implements gov.Content
+func (p AssetQuorumProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute implements gov.Content
+func (p AssetQuorumProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType implements gov.Content
+func (p AssetQuorumProposal) ProposalType() string { return ProposalTypeAssetQuorum }
+
+// ValidateBasic implements gov.Content
+func (p AssetQuorumProposal) ValidateBasic() sdk.Error {
+	err := gov.ValidateAbstract(DefaultCodespace, p)
+	if err != nil {
+		return err
+	}
+	if len(p.AssetCode) == 0 || p.MinPostFraction.IsNil() {
+		return ErrEmptyInput(DefaultCodespace)
+	}
+	if p.MinPosts < 0 || p.MinPostFraction.IsNegative() || p.MinPostFraction.GT(sdk.OneDec()) {
+		return ErrInvalidQuorum(DefaultCodespace)
+	}
+	return nil
+}
+
+func (p AssetQuorumProposal) String() string {
+	return fmt.Sprintf(`Asset Quorum Proposal:
+  Title:             %s
+  Description:       %s
+  Asset Code:        %s
+  Min Posts:         %d
+  Min Post Fraction: %s
+`, p.Title, p.Description, p.AssetCode, p.MinPosts, p.MinPostFraction)
+}
@@ -11,6 +11,7 @@ import (
 // price Takes an [assetcode] and returns CurrentPrice for that asset
 // pricefeed Takes an [assetcode] and returns the raw []PostedPrice for that asset
 // assets Returns []Assets in the pricefeed system
+// oracles Returns the []Oracle allowed to post prices, with the height each was added at. Takes an optional [assetcode] to return the oracles for that asset
 
 const (
 	// QueryCurrentPrice command for current price queries
@@ -19,13 +20,17 @@ const (
 	QueryRawPrices = "rawprices"
 	// QueryAssets command for assets query
 	QueryAssets = "assets"
+	// QueryOracles command for oracles query
+	QueryOracles = "oracles"
 )
 
 // implement fmt.Stringer
 func (cp CurrentPrice) String() string {
 	return strings.TrimSpace(fmt.Sprintf(`AssetCode: %s
 Price: %s
-Expiry: %s`, cp.AssetCode, cp.Price, cp.Expiry))
+Expiry: %s
+Status: %s
+UpdatedHeight: %d`, cp.AssetCode, cp.Price, cp.Expiry, cp.Status, cp.UpdatedHeight))
 }
 
 // implement fmt.Stringer
@@ -38,8 +43,34 @@ Expiry: %s`, pp.AssetCode, pp.OracleAddress, pp.Price, pp.Expiry))
 
 // implement fmt.Stringer
 func (a Asset) String() string {
+	oracles := "global"
+	if len(a.Oracles) != 0 {
+		var addresses []string
+		for _, o := range a.Oracles {
+			addresses = append(addresses, o.OracleAddress)
+		}
+		oracles = strings.Join(addresses, ", ")
+	}
 	return strings.TrimSpace(fmt.Sprintf(`AssetCode: %s
-Description: %s`, a.AssetCode, a.Description))
+Description: %s
+Oracles: %s
+MinPosts: %d
+MinPostFraction: %s`, a.AssetCode, a.Description, oracles, a.MinPosts, a.MinPostFraction))
+}
+
+// implement fmt.Stringer
+func (o Oracle) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`OracleAddress: %s
+AddedHeight: %d`, o.OracleAddress, o.AddedHeight))
+}
+
+// implement fmt.Stringer
+func (os Oracles) String() string {
+	var oracleStrings []string
+	for _, o := range os {
+		oracleStrings = append(oracleStrings, o.String())
+	}
+	return strings.Join(oracleStrings, "\n")
 }
 
 // QueryRawPricesResp response to a rawprice query
@@ -68,6 +99,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryRawPrices(ctx, path[1:], req, keeper)
 		case QueryAssets:
 			return queryAssets(ctx, req, keeper)
+		case QueryOracles:
+			return queryOracles(ctx, path[1:], req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown pricefeed query endpoint")
 		}
@@ -123,3 +156,24 @@ func queryAssets(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []b
 
 	return bz, nil
 }
+
+func queryOracles(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	oracles := keeper.GetOracles(ctx)
+	if len(path) != 0 && len(path[0]) != 0 {
+		assetCode := path[0]
+		_, found := keeper.GetAsset(ctx, assetCode)
+		if !found {
+			return []byte{}, sdk.ErrUnknownRequest("asset not found")
+		}
+		oracles = keeper.GetAssetOracles(ctx, assetCode)
+	}
+	if oracles == nil {
+		oracles = Oracles{}
+	}
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, oracles)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+
+	return bz, nil
+}
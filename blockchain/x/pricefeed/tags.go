@@ -0,0 +1,7 @@
+package pricefeed
+
+// Pricefeed tags
+const (
+	// TagPriceStatus is emitted once for each asset whose price status changed, with the value "<asset-code>:<status>" (eg "btc:missing")
+	TagPriceStatus = "price-status"
+)
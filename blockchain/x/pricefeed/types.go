@@ -6,20 +6,73 @@ import (
 
 // Asset struct that represents an asset in the pricefeed
 type Asset struct {
-	AssetCode   string `json:"asset_code"`
-	Description string `json:"description"`
+	AssetCode       string  `json:"asset_code"`
+	Description     string  `json:"description"`
+	Oracles         Oracles `json:"oracles"`           // oracles allowed to post prices for this asset, if empty the global oracle list is used
+	MinPosts        int     `json:"min_posts"`         // minimum number of unexpired oracle prices needed to update the current price
+	MinPostFraction sdk.Dec `json:"min_post_fraction"` // minimum fraction of the asset's oracles that must have unexpired prices to update the current price
 }
 
 // Oracle struct that documents which address an oracle is using
 type Oracle struct {
 	OracleAddress string `json:"oracle_address"`
+	AddedHeight   int64  `json:"added_height"` // block height the oracle was added at
 }
 
+// Oracles is a list of oracles
+type Oracles []Oracle
+
+// get returns the oracle with an address, if it is in the list
+func (os Oracles) get(address string) (Oracle, bool) {
+	for _, o := range os {
+		if o.OracleAddress == address {
+			return o, true
+		}
+	}
+	return Oracle{}, false
+}
+
+// remove returns a copy of the list without the oracle with an address, and whether it was in the list
+func (os Oracles) remove(address string) (Oracles, bool) {
+	var kept Oracles
+	for _, o := range os {
+		if o.OracleAddress != address {
+			kept = append(kept, o)
+		}
+	}
+	return kept, len(kept) != len(os)
+}
+
+// replace returns a copy of the list with the oracle with an address swapped for a new oracle, and whether it was in the list
+func (os Oracles) replace(address string, newOracle Oracle) (Oracles, bool) {
+	replaced := make(Oracles, len(os))
+	copy(replaced, os)
+	for i, o := range replaced {
+		if o.OracleAddress == address {
+			replaced[i] = newOracle
+			return replaced, true
+		}
+	}
+	return os, false
+}
+
+// Statuses of a current price
+const (
+	// PriceFresh means the price was updated from enough unexpired oracle prices
+	PriceFresh = "fresh"
+	// PriceStale means too few oracles have unexpired prices to update the price, so it is an old price
+	PriceStale = "stale"
+	// PriceMissing means no oracle has an unexpired price, so it is an old price
+	PriceMissing = "missing"
+)
+
 // CurrentPrice struct that contains the metadata of a current price for a particular asset in the pricefeed module.
 type CurrentPrice struct {
-	AssetCode string  `json:"asset_code"`
-	Price     sdk.Dec `json:"price"`
-	Expiry    sdk.Int `json:"expiry"`
+	AssetCode     string  `json:"asset_code"`
+	Price         sdk.Dec `json:"price"`
+	Expiry        sdk.Int `json:"expiry"`
+	Status        string  `json:"status"`         // fresh, stale or missing
+	UpdatedHeight int64   `json:"updated_height"` // block height the price was last updated at
 }
 
 // PostedPrice struct represented a price for an asset posted by a specific oracle